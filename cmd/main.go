@@ -15,10 +15,44 @@ import (
 	"syscall"
 	"time"
 	"uzinfocom-todo/config"
+	apikeyhttp "uzinfocom-todo/internal/apikey/delivery/http"
+	apikeyrepository "uzinfocom-todo/internal/apikey/repository"
+	apikeyusecase "uzinfocom-todo/internal/apikey/usecase"
+	authhttp "uzinfocom-todo/internal/auth/delivery/http"
+	authrepository "uzinfocom-todo/internal/auth/repository"
+	authusecase "uzinfocom-todo/internal/auth/usecase"
+	checklisthttp "uzinfocom-todo/internal/checklist/delivery/http"
+	checklistrepository "uzinfocom-todo/internal/checklist/repository"
+	checklistusecase "uzinfocom-todo/internal/checklist/usecase"
+	listhttp "uzinfocom-todo/internal/list/delivery/http"
+	listrepository "uzinfocom-todo/internal/list/repository"
+	listusecase "uzinfocom-todo/internal/list/usecase"
+	mfahttp "uzinfocom-todo/internal/mfa/delivery/http"
+	mfarepository "uzinfocom-todo/internal/mfa/repository"
+	mfausecase "uzinfocom-todo/internal/mfa/usecase"
+	authmiddleware "uzinfocom-todo/internal/middleware"
+	otphttp "uzinfocom-todo/internal/otp/delivery/http"
+	otprepository "uzinfocom-todo/internal/otp/repository"
+	otpusecase "uzinfocom-todo/internal/otp/usecase"
+	"uzinfocom-todo/internal/reminder"
+	reminderhttp "uzinfocom-todo/internal/reminder/delivery/http"
+	reminderrepository "uzinfocom-todo/internal/reminder/repository"
+	reminderusecase "uzinfocom-todo/internal/reminder/usecase"
+	sessionhttp "uzinfocom-todo/internal/session/delivery/http"
+	sessionrepository "uzinfocom-todo/internal/session/repository"
+	sessionusecase "uzinfocom-todo/internal/session/usecase"
+	taghttp "uzinfocom-todo/internal/tag/delivery/http"
+	tagrepository "uzinfocom-todo/internal/tag/repository"
+	tagusecase "uzinfocom-todo/internal/tag/usecase"
+	"uzinfocom-todo/internal/user"
 	uhttp "uzinfocom-todo/internal/user/delivery/http"
 	"uzinfocom-todo/internal/user/repository"
 	"uzinfocom-todo/internal/user/usecase"
 	"uzinfocom-todo/pkg/db/db_postgres"
+	"uzinfocom-todo/pkg/notify"
+	"uzinfocom-todo/pkg/ratelimit"
+	"uzinfocom-todo/pkg/sms"
+	"uzinfocom-todo/pkg/util"
 )
 
 func main() {
@@ -67,12 +101,114 @@ func main() {
 
 	r := chi.NewRouter()
 	r.Use(middleware.Logger)
+	r.Use(authmiddleware.ProblemDetails())
+	r.Use(authmiddleware.MaxBodySize(cfg.Server.MaxRequestBodyBytes))
+	r.Use(authmiddleware.Locale())
+
+	sessionRepo := sessionrepository.NewSessionRepository(db)
+	sessionUC := sessionusecase.NewSessionUseCase(sessionRepo, cfg)
+	sessionH := sessionhttp.NewSessionHandler(cfg, sessionUC)
+
+	listRepo := listrepository.NewListRepository(db)
+	listUC := listusecase.NewListUseCase(listRepo, cfg)
+	listH := listhttp.NewListHandler(cfg, listUC)
+
+	tagRepo := tagrepository.NewTagRepository(db)
+	tagUC := tagusecase.NewTagUseCase(tagRepo, cfg)
+	tagH := taghttp.NewTagHandler(cfg, tagUC)
+
+	checklistRepo := checklistrepository.NewChecklistRepository(db)
+	checklistUC := checklistusecase.NewChecklistUseCase(checklistRepo, cfg)
+	checklistH := checklisthttp.NewChecklistHandler(cfg, checklistUC)
+
+	notifier, err := notify.NewNotifier(cfg)
+
+	if err != nil {
+		log.Fatal("Error creating notifier: ", err)
+	}
+
+	reminderRepo := reminderrepository.NewReminderRepository(db)
+	reminderUC := reminderusecase.NewReminderUseCase(reminderRepo, notifier, cfg)
+	reminderH := reminderhttp.NewReminderHandler(cfg, reminderUC)
 
 	repo := repository.NewUserRepository(db)
-	uc := usecase.NewUserUseCase(repo, cfg)
-	uh := uhttp.NewUserHandler(cfg, uc)
+	uc := usecase.NewUserUseCase(repo, listRepo, sessionUC, cfg)
+
+	keySet, err := util.LoadKeySet(cfg)
+
+	if err != nil {
+		log.Fatal("Error loading jwt keys: ", err)
+	}
+
+	authRepo := authrepository.NewAuthRepository(db)
+	authUC := authusecase.NewAuthUseCase(authRepo, repo, sessionUC, keySet, cfg)
+	authH := authhttp.NewAuthHandler(cfg, authUC)
+
+	mfaRepo := mfarepository.NewMfaRepository(db)
+	mfaUC := mfausecase.NewMfaUseCase(mfaRepo, repo, keySet, cfg)
+	mfaH := mfahttp.NewMfaHandler(cfg, mfaUC, authUC)
+
+	uh := uhttp.NewUserHandler(cfg, uc, authUC, mfaUC)
+
+	apiKeyRepo := apikeyrepository.NewApiKeyRepository(db)
+	apiKeyUC := apikeyusecase.NewApiKeyUseCase(apiKeyRepo, cfg)
+	apiKeyH := apikeyhttp.NewApiKeyHandler(cfg, apiKeyUC)
+
+	jwtMiddleware := authmiddleware.AuthJwtMiddleware(keySet, sessionUC)
+	authMiddleware := authmiddleware.AuthApiKeyOrJwtMiddleware(keySet, sessionUC, apiKeyUC)
+
+	rateLimitStore, err := ratelimit.NewStore(cfg, db)
+
+	if err != nil {
+		log.Fatal("Error creating rate limit store: ", err)
+	}
+
+	authRateLimit := authmiddleware.Chain(
+		authmiddleware.RateLimit(rateLimitStore, "ip", ratelimit.Limit{
+			Requests: cfg.Server.RateLimitIpRequests,
+			Period:   cfg.Server.RateLimitIpPeriod,
+		}, authmiddleware.RateLimitKeyByIP),
+		authmiddleware.RateLimit(rateLimitStore, "phone", ratelimit.Limit{
+			Requests: cfg.Server.RateLimitPhoneRequests,
+			Period:   cfg.Server.RateLimitPhonePeriod,
+		}, authmiddleware.RateLimitKeyByPhoneNumber),
+	)
+	todoMiddleware := authmiddleware.Chain(
+		authMiddleware,
+		authmiddleware.RateLimit(rateLimitStore, "user", ratelimit.Limit{
+			Requests: cfg.Server.RateLimitUserRequests,
+			Period:   cfg.Server.RateLimitUserPeriod,
+		}, authmiddleware.RateLimitKeyByUser),
+		authmiddleware.Timezone(),
+	)
+
+	uhttp.MapRoutes(r, uh, jwtMiddleware, todoMiddleware, authRateLimit)
+	listhttp.MapRoutes(r, listH, todoMiddleware)
+	taghttp.MapRoutes(r, tagH, todoMiddleware)
+	checklisthttp.MapRoutes(r, checklistH, todoMiddleware)
+	reminderhttp.MapRoutes(r, reminderH, todoMiddleware)
+	sessionhttp.MapRoutes(r, sessionH, jwtMiddleware)
+	apikeyhttp.MapRoutes(r, apiKeyH, jwtMiddleware)
+	mfahttp.MapRoutes(r, mfaH, jwtMiddleware, authRateLimit)
+
+	smsSender, err := sms.NewSender(cfg)
+
+	if err != nil {
+		log.Fatal("Error creating sms sender: ", err)
+	}
+
+	otpRepo := otprepository.NewOtpRepository(db)
+	otpUC := otpusecase.NewOtpUseCase(otpRepo, repo, sessionUC, smsSender, cfg)
+	otpH := otphttp.NewOtpHandler(cfg, otpUC, authUC, mfaUC)
+
+	otphttp.MapRoutes(r, otpH, jwtMiddleware, authRateLimit)
+	authhttp.MapRoutes(r, authH, authRateLimit)
 
-	uhttp.MapRoutes(r, uh, cfg)
+	purgerCtx, stopPurger := context.WithCancel(context.Background())
+	go runTrashPurger(purgerCtx, uc, cfg.Server.TrashPurgeInterval)
+
+	schedulerCtx, stopScheduler := context.WithCancel(context.Background())
+	go runReminderScheduler(schedulerCtx, reminderUC, cfg.Server.ReminderInterval)
 
 	server := http.Server{
 		Addr:    ":" + cfg.Server.Port,
@@ -90,6 +226,9 @@ func main() {
 
 	<-quit
 
+	stopPurger()
+	stopScheduler()
+
 	ctx, shutdown := context.WithTimeout(context.Background(), 5*time.Second)
 	defer shutdown()
 
@@ -105,3 +244,60 @@ func main() {
 		log.Println("Down success")
 	}
 }
+
+// runTrashPurger runs once at startup and then every interval until ctx is cancelled
+func runTrashPurger(ctx context.Context, uc user.UseCase, interval time.Duration) {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		purgeCtx, cancel := context.WithTimeout(ctx, time.Minute)
+		deleted, restErr := uc.PurgeExpiredTrash(purgeCtx)
+		cancel()
+
+		if restErr != nil {
+			log.Printf("trash purger: %v", restErr)
+		} else if deleted > 0 {
+			log.Printf("trash purger: deleted %d tasks", deleted)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// runReminderScheduler delivers due reminders every interval until ctx is cancelled. Several instances
+// can run it at once, each reminder is claimed by only one of them.
+func runReminderScheduler(ctx context.Context, uc reminder.UseCase, interval time.Duration) {
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		sendCtx, cancel := context.WithTimeout(ctx, time.Minute)
+		sent, restErr := uc.SendDueReminders(sendCtx)
+		cancel()
+
+		if restErr != nil {
+			log.Printf("reminder scheduler: %v", restErr)
+		} else if sent > 0 {
+			log.Printf("reminder scheduler: sent %d reminders", sent)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -7,19 +7,65 @@ import (
 	"time"
 )
 
+const (
+	AccountDeletionDelete    = "delete"
+	AccountDeletionAnonymise = "anonymise"
+)
+
 type Config struct {
 	Server   ServerConfig
 	Postgres PostgresConfig
+	Otp      OtpConfig
+	Sms      SmsConfig
+	Jwt      JwtConfig
+	Totp     TotpConfig
+	Notify   NotifyConfig
 }
 
 type ServerConfig struct {
 	AppVersion        string
 	Port              string
-	JwtSecretKey      string
+	OtpSecretKey      string
 	ReadTimeout       time.Duration
 	WriteTimeout      time.Duration
 	CtxDefaultTimeout time.Duration
 	Debug             bool
+
+	MaxRequestBodyBytes int64
+
+	PasswordMinLength      int
+	PasswordMaxLength      int
+	PasswordRequireDigit   bool
+	PasswordRequireLetter  bool
+	PasswordRequireSpecial bool
+	PasswordHashCost       int
+
+	SessionCacheTTL time.Duration
+
+	AdminPhoneNumbers []string
+
+	RateLimitStore         string
+	RateLimitIpRequests    int
+	RateLimitIpPeriod      time.Duration
+	RateLimitPhoneRequests int
+	RateLimitPhonePeriod   time.Duration
+	RateLimitUserRequests  int
+	RateLimitUserPeriod    time.Duration
+
+	LoginMaxFailures int
+	LoginLockout     time.Duration
+	LoginMaxLockout  time.Duration
+
+	AccountDeletionPolicy string
+
+	TrashRetention     time.Duration
+	TrashPurgeInterval time.Duration
+
+	RecurrenceHorizon time.Duration
+
+	ReminderInterval    time.Duration
+	ReminderBatchSize   int
+	ReminderMaxAttempts int
 }
 
 type PostgresConfig struct {
@@ -32,12 +78,61 @@ type PostgresConfig struct {
 	PgDriver           string
 }
 
+// JwtConfig lists the keys tokens are signed and verified with. GenerateMissingKey creates the private key
+// file of the signing key when it does not exist yet, it is meant for development only.
+type JwtConfig struct {
+	SigningKeyId       string
+	Keys               []JwtKeyConfig
+	GenerateMissingKey bool
+}
+
+type JwtKeyConfig struct {
+	KeyId          string
+	PrivateKeyFile string
+	PublicKeyFile  string
+}
+
+type TotpConfig struct {
+	Issuer            string
+	RecoveryCodeCount int
+	MaxAttempts       int
+}
+
+type OtpConfig struct {
+	CodeLength         int
+	CodeTTL            time.Duration
+	MaxAttempts        int
+	MaxRequestsPerHour int
+}
+
+type SmsConfig struct {
+	Sender   string
+	FilePath string
+}
+
+type NotifyConfig struct {
+	Notifier       string
+	WebhookUrl     string
+	WebhookSecret  string
+	WebhookTimeout time.Duration
+	SmtpHost       string
+	SmtpPort       int
+	SmtpUsername   string
+	SmtpPassword   string
+	SmtpFrom       string
+	SmtpTimeout    time.Duration
+}
+
 func LoadConfig(filename string) (*viper.Viper, error) {
 	v := viper.New()
 
 	v.SetConfigName(filename)
 	v.AddConfigPath(".")
 	v.AutomaticEnv()
+	// secrets are not kept in the config file
+	if err := v.BindEnv("server.OtpSecretKey", "OTP_SECRET_KEY"); err != nil {
+		return nil, err
+	}
 	if err := v.ReadInConfig(); err != nil {
 		if _, ok := err.(viper.ConfigFileNotFoundError); ok {
 			return nil, errors.New("config file not found")
@@ -56,5 +151,15 @@ func ParseConfig(v *viper.Viper) (*Config, error) {
 		log.Printf("unable to decode into struct, %v", err)
 		return nil, err
 	}
+
+	if c.Server.OtpSecretKey == "" {
+		return nil, errors.New("OTP_SECRET_KEY is not set")
+	}
+
+	switch c.Server.AccountDeletionPolicy {
+	case "", AccountDeletionDelete, AccountDeletionAnonymise:
+	default:
+		return nil, errors.New("unknown account deletion policy: " + c.Server.AccountDeletionPolicy)
+	}
 	return &c, nil
 }
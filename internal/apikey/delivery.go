@@ -0,0 +1,9 @@
+package apikey
+
+import "net/http"
+
+type Handler interface {
+	CreateApiKey() http.HandlerFunc
+	GetApiKeys() http.HandlerFunc
+	RevokeApiKey() http.HandlerFunc
+}
@@ -0,0 +1,110 @@
+package http
+
+import (
+	"encoding/json"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"net/http"
+	"uzinfocom-todo/config"
+	"uzinfocom-todo/internal/apikey"
+	"uzinfocom-todo/internal/models"
+	"uzinfocom-todo/internal/models/request_objects"
+	"uzinfocom-todo/internal/models/response_objects"
+	"uzinfocom-todo/pkg/http_errors"
+	"uzinfocom-todo/pkg/i18n"
+)
+
+type apiKeyHandler struct {
+	cfg      *config.Config
+	apiKeyUC apikey.UseCase
+}
+
+func NewApiKeyHandler(cfg *config.Config, apiKeyUC apikey.UseCase) apikey.Handler {
+	return &apiKeyHandler{
+		cfg:      cfg,
+		apiKeyUC: apiKeyUC,
+	}
+}
+
+func (h *apiKeyHandler) CreateApiKey() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var responseObject *response_objects.ResponseObject
+		var requestApiKey request_objects.RequestApiKey
+		userId := r.Context().Value("user").(models.User).UserId
+
+		err := json.NewDecoder(r.Body).Decode(&requestApiKey)
+
+		if err != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), http_errors.InvalidJson("api key"))
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(jsonResponse)
+			return
+		}
+
+		defer r.Body.Close()
+
+		responseApiKey, restErr := h.apiKeyUC.CreateApiKey(r.Context(), userId, requestApiKey)
+
+		if restErr != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(restErr.Status())
+			w.Write(jsonResponse)
+			return
+		}
+
+		responseObject = response_objects.NewSuccessResponseObject(r.Context(), i18n.MessageApiKeyCreated, responseApiKey)
+		jsonResponse, _ := json.Marshal(responseObject)
+		w.WriteHeader(http.StatusCreated)
+		w.Write(jsonResponse)
+	}
+}
+
+func (h *apiKeyHandler) GetApiKeys() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var responseObject *response_objects.ResponseObject
+		userId := r.Context().Value("user").(models.User).UserId
+
+		responseApiKeys, restErr := h.apiKeyUC.GetApiKeys(r.Context(), userId)
+
+		if restErr != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(restErr.Status())
+			w.Write(jsonResponse)
+			return
+		}
+
+		responseObject = response_objects.NewSuccessResponseObject(r.Context(), i18n.MessageApiKeysFetched, responseApiKeys)
+		jsonResponse, _ := json.Marshal(responseObject)
+		w.WriteHeader(http.StatusOK)
+		w.Write(jsonResponse)
+	}
+}
+
+func (h *apiKeyHandler) RevokeApiKey() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var responseObject *response_objects.ResponseObject
+		userId := r.Context().Value("user").(models.User).UserId
+		apiKeyId, _ := uuid.Parse(chi.URLParam(r, "apiKeyId"))
+
+		restErr := h.apiKeyUC.RevokeApiKey(r.Context(), userId, apiKeyId)
+
+		if restErr != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(restErr.Status())
+			w.Write(jsonResponse)
+			return
+		}
+
+		responseObject = response_objects.NewSuccessResponseObject(r.Context(), i18n.MessageApiKeyRevoked, nil)
+		jsonResponse, _ := json.Marshal(responseObject)
+		w.WriteHeader(http.StatusOK)
+		w.Write(jsonResponse)
+	}
+}
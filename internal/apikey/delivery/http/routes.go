@@ -0,0 +1,16 @@
+package http
+
+import (
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"uzinfocom-todo/internal/apikey"
+)
+
+func MapRoutes(router *chi.Mux, h apikey.Handler, jwtMiddleware func(http.Handler) http.Handler) {
+	router.Route("/api/api-keys", func(r chi.Router) {
+		r.Use(jwtMiddleware)
+		r.Post("/", h.CreateApiKey())
+		r.Get("/", h.GetApiKeys())
+		r.Delete("/{apiKeyId}", h.RevokeApiKey())
+	})
+}
@@ -0,0 +1,15 @@
+package apikey
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"uzinfocom-todo/internal/models"
+)
+
+type Repository interface {
+	Create(ctx context.Context, apiKey models.ApiKey, expiresInDays int) (*models.ApiKey, error)
+	GetByUserId(ctx context.Context, userId uuid.UUID) ([]models.ApiKey, error)
+	GetByHash(ctx context.Context, keyHash string) (*models.ApiKey, *models.User, error)
+	Revoke(ctx context.Context, userId uuid.UUID, apiKeyId uuid.UUID) (bool, error)
+	TouchLastUsed(ctx context.Context, apiKeyId uuid.UUID) error
+}
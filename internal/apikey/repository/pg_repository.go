@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"strings"
+	"uzinfocom-todo/internal/apikey"
+	"uzinfocom-todo/internal/models"
+)
+
+type apiKeyRepository struct {
+	db *sqlx.DB
+}
+
+func NewApiKeyRepository(db *sqlx.DB) apikey.Repository {
+	return &apiKeyRepository{
+		db: db,
+	}
+}
+
+// Create stores the key; expiresInDays <= 0 creates a key that never expires
+func (r *apiKeyRepository) Create(ctx context.Context, apiKey models.ApiKey, expiresInDays int) (*models.ApiKey, error) {
+	query := `INSERT INTO api_keys (api_key_id, user_id, name, prefix, key_hash, scopes, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, CASE WHEN $7 > 0 THEN CURRENT_TIMESTAMP + make_interval(days => $7) END)
+		RETURNING api_key_id, user_id, name, prefix, key_hash, scopes, expires_at, last_used_at, revoked_at, created_at`
+	createdApiKey := models.ApiKey{}
+	var scopes string
+
+	if err := r.db.QueryRowxContext(
+		ctx,
+		query,
+		uuid.New(),
+		apiKey.UserId,
+		apiKey.Name,
+		apiKey.Prefix,
+		apiKey.KeyHash,
+		strings.Join(apiKey.Scopes, " "),
+		expiresInDays,
+	).Scan(
+		&createdApiKey.ApiKeyId,
+		&createdApiKey.UserId,
+		&createdApiKey.Name,
+		&createdApiKey.Prefix,
+		&createdApiKey.KeyHash,
+		&scopes,
+		&createdApiKey.ExpiresAt,
+		&createdApiKey.LastUsedAt,
+		&createdApiKey.RevokedAt,
+		&createdApiKey.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	createdApiKey.Scopes = strings.Fields(scopes)
+	return &createdApiKey, nil
+}
+
+func (r *apiKeyRepository) GetByUserId(ctx context.Context, userId uuid.UUID) ([]models.ApiKey, error) {
+	query := `SELECT api_key_id, user_id, name, prefix, key_hash, scopes, expires_at, last_used_at, revoked_at, created_at
+		FROM api_keys WHERE user_id = $1 AND revoked_at IS NULL ORDER BY created_at DESC`
+	apiKeys := []models.ApiKey{}
+
+	rows, err := r.db.QueryxContext(ctx, query, userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		k := models.ApiKey{}
+		var scopes string
+		if err = rows.Scan(
+			&k.ApiKeyId,
+			&k.UserId,
+			&k.Name,
+			&k.Prefix,
+			&k.KeyHash,
+			&scopes,
+			&k.ExpiresAt,
+			&k.LastUsedAt,
+			&k.RevokedAt,
+			&k.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		k.Scopes = strings.Fields(scopes)
+		apiKeys = append(apiKeys, k)
+	}
+
+	return apiKeys, rows.Err()
+}
+
+// GetByHash returns a usable key together with its owner. Revoked and expired keys, as well as keys of
+// disabled accounts, are reported as sql.ErrNoRows.
+func (r *apiKeyRepository) GetByHash(ctx context.Context, keyHash string) (*models.ApiKey, *models.User, error) {
+	query := `SELECT k.api_key_id, k.user_id, k.name, k.prefix, k.key_hash, k.scopes, k.expires_at, k.last_used_at, k.revoked_at, k.created_at,
+			u.user_id, u.name, u.phone_number, u.role, u.timezone, u.locale
+		FROM api_keys k JOIN users u ON u.user_id = k.user_id
+		WHERE k.key_hash = $1 AND k.revoked_at IS NULL AND (k.expires_at IS NULL OR k.expires_at > CURRENT_TIMESTAMP)
+			AND u.disabled_at IS NULL`
+	k := models.ApiKey{}
+	u := models.User{}
+	var scopes string
+
+	if err := r.db.QueryRowxContext(ctx, query, keyHash).Scan(
+		&k.ApiKeyId,
+		&k.UserId,
+		&k.Name,
+		&k.Prefix,
+		&k.KeyHash,
+		&scopes,
+		&k.ExpiresAt,
+		&k.LastUsedAt,
+		&k.RevokedAt,
+		&k.CreatedAt,
+		&u.UserId,
+		&u.Name,
+		&u.PhoneNumber,
+		&u.Role,
+		&u.Timezone,
+		&u.Locale,
+	); err != nil {
+		return nil, nil, err
+	}
+
+	k.Scopes = strings.Fields(scopes)
+	return &k, &u, nil
+}
+
+func (r *apiKeyRepository) Revoke(ctx context.Context, userId uuid.UUID, apiKeyId uuid.UUID) (bool, error) {
+	query := `UPDATE api_keys SET revoked_at = CURRENT_TIMESTAMP WHERE api_key_id = $1 AND user_id = $2 AND revoked_at IS NULL`
+
+	res, err := r.db.ExecContext(ctx, query, apiKeyId, userId)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+func (r *apiKeyRepository) TouchLastUsed(ctx context.Context, apiKeyId uuid.UUID) error {
+	query := `UPDATE api_keys SET last_used_at = CURRENT_TIMESTAMP WHERE api_key_id = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, apiKeyId); err != nil {
+		return err
+	}
+	return nil
+}
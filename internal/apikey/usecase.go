@@ -0,0 +1,17 @@
+package apikey
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"uzinfocom-todo/internal/models"
+	"uzinfocom-todo/internal/models/request_objects"
+	"uzinfocom-todo/internal/models/response_objects"
+	"uzinfocom-todo/pkg/http_errors"
+)
+
+type UseCase interface {
+	CreateApiKey(ctx context.Context, userId uuid.UUID, requestApiKey request_objects.RequestApiKey) (*response_objects.ResponseApiKey, http_errors.RestErr)
+	GetApiKeys(ctx context.Context, userId uuid.UUID) (*[]response_objects.ResponseApiKey, http_errors.RestErr)
+	RevokeApiKey(ctx context.Context, userId uuid.UUID, apiKeyId uuid.UUID) http_errors.RestErr
+	Authenticate(ctx context.Context, rawKey string) (*models.User, *models.ApiKey, http_errors.RestErr)
+}
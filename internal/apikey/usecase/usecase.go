@@ -0,0 +1,173 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"github.com/google/uuid"
+	"log"
+	"strings"
+	"uzinfocom-todo/config"
+	"uzinfocom-todo/internal/apikey"
+	"uzinfocom-todo/internal/models"
+	"uzinfocom-todo/internal/models/request_objects"
+	"uzinfocom-todo/internal/models/response_objects"
+	"uzinfocom-todo/pkg/http_errors"
+)
+
+const (
+	keyPrefix       = "utd_"
+	keyRandomBytes  = 32
+	keyPrefixLength = 12
+	maxNameLength   = 64
+)
+
+var allowedScopes = map[string]bool{
+	models.ScopeTasksRead:  true,
+	models.ScopeTasksWrite: true,
+}
+
+type apiKeyUseCase struct {
+	apiKeyRepo apikey.Repository
+	cfg        *config.Config
+}
+
+func NewApiKeyUseCase(apiKeyRepo apikey.Repository, cfg *config.Config) apikey.UseCase {
+	return &apiKeyUseCase{
+		apiKeyRepo: apiKeyRepo,
+		cfg:        cfg,
+	}
+}
+
+// CreateApiKey generates a new key. The plain key is only returned here, the database keeps its hash.
+func (uc *apiKeyUseCase) CreateApiKey(ctx context.Context, userId uuid.UUID, requestApiKey request_objects.RequestApiKey) (*response_objects.ResponseApiKey, http_errors.RestErr) {
+	name := strings.TrimSpace(requestApiKey.Name)
+
+	if name == "" || len(name) > maxNameLength {
+		return nil, http_errors.InvalidLength("name", maxNameLength)
+	}
+
+	if requestApiKey.ExpiresInDays < 0 {
+		return nil, http_errors.NegativeValue("expires_in_days")
+	}
+
+	scopes := requestApiKey.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{models.ScopeTasksRead, models.ScopeTasksWrite}
+	}
+
+	for _, scope := range scopes {
+		if !allowedScopes[scope] {
+			return nil, http_errors.UnknownScope(scope)
+		}
+	}
+
+	rawKey, err := generateKey()
+	if err != nil {
+		return nil, http_errors.ParseErrors(err)
+	}
+
+	apiKey := models.ApiKey{
+		UserId:  userId,
+		Name:    name,
+		Prefix:  rawKey[:keyPrefixLength],
+		KeyHash: hashKey(rawKey),
+		Scopes:  scopes,
+	}
+
+	createdApiKey, err := uc.apiKeyRepo.Create(ctx, apiKey, requestApiKey.ExpiresInDays)
+	if err != nil {
+		return nil, http_errors.ParseErrors(err)
+	}
+
+	responseApiKey := toResponseApiKey(*createdApiKey)
+	responseApiKey.Key = rawKey
+	return &responseApiKey, nil
+}
+
+func (uc *apiKeyUseCase) GetApiKeys(ctx context.Context, userId uuid.UUID) (*[]response_objects.ResponseApiKey, http_errors.RestErr) {
+	apiKeys, err := uc.apiKeyRepo.GetByUserId(ctx, userId)
+	if err != nil {
+		return nil, http_errors.ParseErrors(err)
+	}
+
+	responseApiKeys := []response_objects.ResponseApiKey{}
+
+	for _, k := range apiKeys {
+		responseApiKeys = append(responseApiKeys, toResponseApiKey(k))
+	}
+
+	return &responseApiKeys, nil
+}
+
+func (uc *apiKeyUseCase) RevokeApiKey(ctx context.Context, userId uuid.UUID, apiKeyId uuid.UUID) http_errors.RestErr {
+	revoked, err := uc.apiKeyRepo.Revoke(ctx, userId, apiKeyId)
+	if err != nil {
+		return http_errors.ParseErrors(err)
+	}
+
+	if !revoked {
+		return http_errors.ApiKeyNotFound()
+	}
+	return nil
+}
+
+func (uc *apiKeyUseCase) Authenticate(ctx context.Context, rawKey string) (*models.User, *models.ApiKey, http_errors.RestErr) {
+	if !strings.HasPrefix(rawKey, keyPrefix) {
+		return nil, nil, http_errors.InvalidApiKey()
+	}
+
+	apiKey, u, err := uc.apiKeyRepo.GetByHash(ctx, hashKey(rawKey))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil, http_errors.InvalidApiKey()
+		}
+		return nil, nil, http_errors.ParseErrors(err)
+	}
+
+	if err = uc.apiKeyRepo.TouchLastUsed(ctx, apiKey.ApiKeyId); err != nil {
+		log.Printf("apikey: updating last used of %s failed: %v", apiKey.ApiKeyId, err)
+	}
+
+	return u, apiKey, nil
+}
+
+func generateKey() (string, error) {
+	b := make([]byte, keyRandomBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return keyPrefix + base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashKey does not need a slow hash: the key carries 256 bits of entropy
+func hashKey(rawKey string) string {
+	sum := sha256.Sum256([]byte(rawKey))
+	return hex.EncodeToString(sum[:])
+}
+
+func toResponseApiKey(k models.ApiKey) response_objects.ResponseApiKey {
+	responseApiKey := response_objects.ResponseApiKey{
+		ApiKeyId:  k.ApiKeyId,
+		Name:      k.Name,
+		Prefix:    k.Prefix,
+		Scopes:    k.Scopes,
+		CreatedAt: k.CreatedAt.Format("02-01-2006 15:04"),
+	}
+
+	if k.ExpiresAt != nil {
+		expiresAt := k.ExpiresAt.Format("02-01-2006 15:04")
+		responseApiKey.ExpiresAt = &expiresAt
+	}
+
+	if k.LastUsedAt != nil {
+		lastUsedAt := k.LastUsedAt.Format("02-01-2006 15:04")
+		responseApiKey.LastUsedAt = &lastUsedAt
+	}
+
+	return responseApiKey
+}
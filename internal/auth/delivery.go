@@ -0,0 +1,8 @@
+package auth
+
+import "net/http"
+
+type Handler interface {
+	RefreshToken() http.HandlerFunc
+	Jwks() http.HandlerFunc
+}
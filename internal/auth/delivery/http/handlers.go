@@ -0,0 +1,70 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"uzinfocom-todo/config"
+	"uzinfocom-todo/internal/auth"
+	"uzinfocom-todo/internal/models/request_objects"
+	"uzinfocom-todo/internal/models/response_objects"
+	"uzinfocom-todo/pkg/http_errors"
+	"uzinfocom-todo/pkg/i18n"
+)
+
+type authHandler struct {
+	cfg    *config.Config
+	authUC auth.UseCase
+}
+
+func NewAuthHandler(cfg *config.Config, authUC auth.UseCase) auth.Handler {
+	return &authHandler{
+		cfg:    cfg,
+		authUC: authUC,
+	}
+}
+
+func (h *authHandler) RefreshToken() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var responseObject *response_objects.ResponseObject
+		var requestToken request_objects.RequestRefreshToken
+
+		err := json.NewDecoder(r.Body).Decode(&requestToken)
+
+		if err != nil || requestToken.RefreshToken == "" {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), http_errors.InvalidJson("refresh token"))
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(jsonResponse)
+			return
+		}
+
+		defer r.Body.Close()
+
+		tokens, restErr := h.authUC.RefreshTokens(r.Context(), requestToken.RefreshToken)
+
+		if restErr != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(restErr.Status())
+			w.Write(jsonResponse)
+			return
+		}
+
+		responseObject = response_objects.NewSuccessResponseObject(r.Context(), i18n.MessageTokensRefreshed, tokens)
+		jsonResponse, _ := json.Marshal(responseObject)
+		w.WriteHeader(http.StatusOK)
+		w.Write(jsonResponse)
+	}
+}
+
+func (h *authHandler) Jwks() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "public, max-age=300")
+
+		jsonResponse, _ := json.Marshal(h.authUC.GetJwks())
+		w.WriteHeader(http.StatusOK)
+		w.Write(jsonResponse)
+	}
+}
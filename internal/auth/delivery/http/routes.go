@@ -0,0 +1,15 @@
+package http
+
+import (
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"uzinfocom-todo/internal/auth"
+)
+
+func MapRoutes(router *chi.Mux, h auth.Handler, rateLimitMiddleware func(http.Handler) http.Handler) {
+	router.Route("/api/token", func(r chi.Router) {
+		r.With(rateLimitMiddleware).Post("/refresh", h.RefreshToken())
+	})
+
+	router.Get("/.well-known/jwks.json", h.Jwks())
+}
@@ -0,0 +1,15 @@
+package auth
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"time"
+	"uzinfocom-todo/internal/models"
+)
+
+type Repository interface {
+	CreateRefreshToken(ctx context.Context, token models.RefreshToken, ttl time.Duration) error
+	GetRefreshTokenByHash(ctx context.Context, tokenHash string) (*models.RefreshToken, error)
+	MarkRefreshTokenUsed(ctx context.Context, tokenId uuid.UUID) (bool, error)
+	RevokeFamily(ctx context.Context, familyId uuid.UUID) error
+}
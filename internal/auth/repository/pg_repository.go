@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"time"
+	"uzinfocom-todo/internal/auth"
+	"uzinfocom-todo/internal/models"
+)
+
+type authRepository struct {
+	db *sqlx.DB
+}
+
+func NewAuthRepository(db *sqlx.DB) auth.Repository {
+	return &authRepository{
+		db: db,
+	}
+}
+
+func (r *authRepository) CreateRefreshToken(ctx context.Context, token models.RefreshToken, ttl time.Duration) error {
+	query := `INSERT INTO refresh_tokens (token_id, family_id, user_id, token_hash, expires_at)
+		VALUES ($1, $2, $3, $4, CURRENT_TIMESTAMP + make_interval(secs => $5))`
+
+	if _, err := r.db.ExecContext(
+		ctx,
+		query,
+		token.TokenId,
+		token.FamilyId,
+		token.UserId,
+		token.TokenHash,
+		ttl.Seconds(),
+	); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *authRepository) GetRefreshTokenByHash(ctx context.Context, tokenHash string) (*models.RefreshToken, error) {
+	query := `SELECT token_id, family_id, user_id, token_hash, expires_at, used_at, revoked_at, created_at
+		FROM refresh_tokens WHERE token_hash = $1`
+	token := models.RefreshToken{}
+
+	if err := r.db.QueryRowxContext(ctx, query, tokenHash).Scan(
+		&token.TokenId,
+		&token.FamilyId,
+		&token.UserId,
+		&token.TokenHash,
+		&token.ExpiresAt,
+		&token.UsedAt,
+		&token.RevokedAt,
+		&token.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+// MarkRefreshTokenUsed reports false if the token has already been used or revoked
+func (r *authRepository) MarkRefreshTokenUsed(ctx context.Context, tokenId uuid.UUID) (bool, error) {
+	query := `UPDATE refresh_tokens SET used_at = CURRENT_TIMESTAMP
+		WHERE token_id = $1 AND used_at IS NULL AND revoked_at IS NULL AND expires_at > CURRENT_TIMESTAMP`
+
+	res, err := r.db.ExecContext(ctx, query, tokenId)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+func (r *authRepository) RevokeFamily(ctx context.Context, familyId uuid.UUID) error {
+	query := `UPDATE refresh_tokens SET revoked_at = CURRENT_TIMESTAMP WHERE family_id = $1 AND revoked_at IS NULL`
+
+	if _, err := r.db.ExecContext(ctx, query, familyId); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,14 @@
+package auth
+
+import (
+	"context"
+	"uzinfocom-todo/internal/models"
+	"uzinfocom-todo/pkg/http_errors"
+	"uzinfocom-todo/pkg/util"
+)
+
+type UseCase interface {
+	IssueTokens(ctx context.Context, user *models.User, session models.Session) (*models.Tokens, http_errors.RestErr)
+	RefreshTokens(ctx context.Context, refreshToken string) (*models.Tokens, http_errors.RestErr)
+	GetJwks() util.Jwks
+}
@@ -0,0 +1,158 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"github.com/google/uuid"
+	"log"
+	"net/http"
+	"uzinfocom-todo/config"
+	"uzinfocom-todo/internal/auth"
+	"uzinfocom-todo/internal/models"
+	"uzinfocom-todo/internal/session"
+	"uzinfocom-todo/internal/user"
+	"uzinfocom-todo/pkg/http_errors"
+	"uzinfocom-todo/pkg/util"
+)
+
+type authUseCase struct {
+	authRepo  auth.Repository
+	userRepo  user.Repository
+	sessionUC session.UseCase
+	keySet    *util.KeySet
+	cfg       *config.Config
+}
+
+func NewAuthUseCase(authRepo auth.Repository, userRepo user.Repository, sessionUC session.UseCase, keySet *util.KeySet, cfg *config.Config) auth.UseCase {
+	return &authUseCase{
+		authRepo:  authRepo,
+		userRepo:  userRepo,
+		sessionUC: sessionUC,
+		keySet:    keySet,
+		cfg:       cfg,
+	}
+}
+
+// IssueTokens opens a new session for a freshly authenticated user. The session id is also
+// the refresh token family id, so the family lives and dies with the session.
+func (uc *authUseCase) IssueTokens(ctx context.Context, user *models.User, s models.Session) (*models.Tokens, http_errors.RestErr) {
+	s.UserId = user.UserId
+
+	createdSession, restErr := uc.sessionUC.CreateSession(ctx, s)
+	if restErr != nil {
+		return nil, restErr
+	}
+
+	return uc.issueTokens(ctx, user, createdSession.SessionId)
+}
+
+// RefreshTokens rotates the given refresh token. Presenting a token that was already rotated
+// means it has leaked, so the whole family is revoked and the user has to log in again.
+func (uc *authUseCase) RefreshTokens(ctx context.Context, refreshToken string) (*models.Tokens, http_errors.RestErr) {
+	claims, err := util.ParseToken(refreshToken, util.RefreshTokenType, uc.keySet)
+	if err != nil {
+		return nil, http_errors.InvalidRefreshToken()
+	}
+
+	storedToken, err := uc.authRepo.GetRefreshTokenByHash(ctx, hashToken(refreshToken))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, http_errors.InvalidRefreshToken()
+		}
+		return nil, http_errors.ParseErrors(err)
+	}
+
+	if storedToken.RevokedAt != nil || claims.SessionId != storedToken.FamilyId.String() {
+		return nil, http_errors.InvalidRefreshToken()
+	}
+
+	_, active, restErr := uc.sessionUC.GetActiveUser(ctx, storedToken.FamilyId)
+	if restErr != nil {
+		return nil, restErr
+	}
+
+	if !active {
+		return nil, http_errors.SessionRevoked()
+	}
+
+	if storedToken.UsedAt != nil {
+		return nil, uc.revokeReusedFamily(ctx, storedToken)
+	}
+
+	marked, err := uc.authRepo.MarkRefreshTokenUsed(ctx, storedToken.TokenId)
+	if err != nil {
+		return nil, http_errors.ParseErrors(err)
+	}
+
+	if !marked {
+		// a concurrent request rotated the same token first
+		return nil, uc.revokeReusedFamily(ctx, storedToken)
+	}
+
+	foundUser, err := uc.userRepo.GetById(ctx, storedToken.UserId)
+	if err != nil {
+		return nil, http_errors.ParseErrors(err)
+	}
+
+	if foundUser.DisabledAt != nil {
+		return nil, http_errors.AccountDisabled()
+	}
+
+	foundUser.SanitizePassword()
+	return uc.issueTokens(ctx, foundUser, storedToken.FamilyId)
+}
+
+func (uc *authUseCase) GetJwks() util.Jwks {
+	return uc.keySet.Jwks()
+}
+
+func (uc *authUseCase) issueTokens(ctx context.Context, user *models.User, familyId uuid.UUID) (*models.Tokens, http_errors.RestErr) {
+	accessToken, err := util.GenerateJWTToken(user, familyId, uc.keySet)
+	if err != nil {
+		return nil, http_errors.ParseErrors(err)
+	}
+
+	tokenId := uuid.New()
+
+	refreshToken, err := util.GenerateRefreshToken(user, familyId, tokenId, uc.keySet)
+	if err != nil {
+		return nil, http_errors.ParseErrors(err)
+	}
+
+	storedToken := models.RefreshToken{
+		TokenId:   tokenId,
+		FamilyId:  familyId,
+		UserId:    user.UserId,
+		TokenHash: hashToken(refreshToken),
+	}
+
+	if err = uc.authRepo.CreateRefreshToken(ctx, storedToken, util.RefreshTokenTTL); err != nil {
+		return nil, http_errors.ParseErrors(err)
+	}
+
+	return &models.Tokens{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+func (uc *authUseCase) revokeReusedFamily(ctx context.Context, storedToken *models.RefreshToken) http_errors.RestErr {
+	log.Printf("auth: refresh token reuse detected for user %s, revoking family %s", storedToken.UserId, storedToken.FamilyId)
+
+	if err := uc.authRepo.RevokeFamily(ctx, storedToken.FamilyId); err != nil {
+		return http_errors.ParseErrors(err)
+	}
+
+	if restErr := uc.sessionUC.RevokeSession(ctx, storedToken.UserId, storedToken.FamilyId); restErr != nil && restErr.Status() != http.StatusNotFound {
+		return restErr
+	}
+	return http_errors.RefreshTokenReused()
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,10 @@
+package checklist
+
+import "net/http"
+
+type Handler interface {
+	GetItems() http.HandlerFunc
+	CreateItem() http.HandlerFunc
+	UpdateItem() http.HandlerFunc
+	DeleteItem() http.HandlerFunc
+}
@@ -0,0 +1,211 @@
+package http
+
+import (
+	"encoding/json"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"net/http"
+	"uzinfocom-todo/config"
+	"uzinfocom-todo/internal/checklist"
+	"uzinfocom-todo/internal/models"
+	"uzinfocom-todo/internal/models/request_objects"
+	"uzinfocom-todo/internal/models/response_objects"
+	"uzinfocom-todo/pkg/http_errors"
+	"uzinfocom-todo/pkg/i18n"
+)
+
+type checklistHandler struct {
+	cfg         *config.Config
+	checklistUC checklist.UseCase
+}
+
+func NewChecklistHandler(cfg *config.Config, checklistUC checklist.UseCase) checklist.Handler {
+	return &checklistHandler{
+		cfg:         cfg,
+		checklistUC: checklistUC,
+	}
+}
+
+func (h *checklistHandler) GetItems() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var responseObject *response_objects.ResponseObject
+		userId := r.Context().Value("user").(models.User).UserId
+		taskId, err := uuid.Parse(chi.URLParam(r, "taskId"))
+
+		if err != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), http_errors.InvalidTaskId())
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(jsonResponse)
+			return
+		}
+
+		responseItems, restErr := h.checklistUC.GetItems(r.Context(), userId, taskId)
+
+		if restErr != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(restErr.Status())
+			w.Write(jsonResponse)
+			return
+		}
+
+		responseObject = response_objects.NewSuccessResponseObject(r.Context(), i18n.MessageChecklistItemsFetched, responseItems)
+		jsonResponse, _ := json.Marshal(responseObject)
+		w.WriteHeader(http.StatusOK)
+		w.Write(jsonResponse)
+	}
+}
+
+func (h *checklistHandler) CreateItem() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var responseObject *response_objects.ResponseObject
+		var requestItem request_objects.RequestChecklistItem
+		userId := r.Context().Value("user").(models.User).UserId
+		taskId, err := uuid.Parse(chi.URLParam(r, "taskId"))
+
+		if err != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), http_errors.InvalidTaskId())
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(jsonResponse)
+			return
+		}
+
+		err = json.NewDecoder(r.Body).Decode(&requestItem)
+
+		if err != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), http_errors.InvalidJson("checklist item"))
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(jsonResponse)
+			return
+		}
+
+		defer r.Body.Close()
+
+		responseItem, restErr := h.checklistUC.CreateItem(r.Context(), userId, taskId, requestItem)
+
+		if restErr != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(restErr.Status())
+			w.Write(jsonResponse)
+			return
+		}
+
+		responseObject = response_objects.NewSuccessResponseObject(r.Context(), i18n.MessageChecklistItemCreated, responseItem)
+		jsonResponse, _ := json.Marshal(responseObject)
+		w.WriteHeader(http.StatusCreated)
+		w.Write(jsonResponse)
+	}
+}
+
+func (h *checklistHandler) UpdateItem() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var responseObject *response_objects.ResponseObject
+		var requestItem request_objects.RequestChecklistItem
+		userId := r.Context().Value("user").(models.User).UserId
+		taskId, err := uuid.Parse(chi.URLParam(r, "taskId"))
+
+		if err != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), http_errors.InvalidTaskId())
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(jsonResponse)
+			return
+		}
+
+		itemId, err := uuid.Parse(chi.URLParam(r, "itemId"))
+
+		if err != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), http_errors.InvalidChecklistItemId())
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(jsonResponse)
+			return
+		}
+
+		err = json.NewDecoder(r.Body).Decode(&requestItem)
+
+		if err != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), http_errors.InvalidJson("checklist item"))
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(jsonResponse)
+			return
+		}
+
+		defer r.Body.Close()
+
+		update, restErr := h.checklistUC.UpdateItem(r.Context(), userId, taskId, itemId, requestItem)
+
+		if restErr != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(restErr.Status())
+			w.Write(jsonResponse)
+			return
+		}
+
+		messageId := i18n.MessageChecklistItemUpdated
+		if update.TaskCompleted {
+			messageId = i18n.MessageChecklistItemUpdatedTaskDone
+		}
+
+		responseObject = response_objects.NewSuccessResponseObject(r.Context(), messageId, update)
+		jsonResponse, _ := json.Marshal(responseObject)
+		w.WriteHeader(http.StatusOK)
+		w.Write(jsonResponse)
+	}
+}
+
+func (h *checklistHandler) DeleteItem() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var responseObject *response_objects.ResponseObject
+		userId := r.Context().Value("user").(models.User).UserId
+		taskId, err := uuid.Parse(chi.URLParam(r, "taskId"))
+
+		if err != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), http_errors.InvalidTaskId())
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(jsonResponse)
+			return
+		}
+
+		itemId, err := uuid.Parse(chi.URLParam(r, "itemId"))
+
+		if err != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), http_errors.InvalidChecklistItemId())
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(jsonResponse)
+			return
+		}
+
+		update, restErr := h.checklistUC.DeleteItem(r.Context(), userId, taskId, itemId)
+
+		if restErr != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(restErr.Status())
+			w.Write(jsonResponse)
+			return
+		}
+
+		messageId := i18n.MessageChecklistItemDeleted
+		if update.TaskCompleted {
+			messageId = i18n.MessageChecklistItemDeletedTaskDone
+		}
+
+		responseObject = response_objects.NewSuccessResponseObject(r.Context(), messageId, update)
+		jsonResponse, _ := json.Marshal(responseObject)
+		w.WriteHeader(http.StatusOK)
+		w.Write(jsonResponse)
+	}
+}
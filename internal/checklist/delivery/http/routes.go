@@ -0,0 +1,19 @@
+package http
+
+import (
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"uzinfocom-todo/internal/checklist"
+	"uzinfocom-todo/internal/middleware"
+	"uzinfocom-todo/internal/models"
+)
+
+func MapRoutes(router *chi.Mux, h checklist.Handler, authMiddleware func(http.Handler) http.Handler) {
+	router.Route("/todo/{taskId}/checklist", func(r chi.Router) {
+		r.Use(authMiddleware)
+		r.With(middleware.RequireScopes(models.ScopeTasksRead)).Get("/", h.GetItems())
+		r.With(middleware.RequireScopes(models.ScopeTasksWrite)).Post("/", h.CreateItem())
+		r.With(middleware.RequireScopes(models.ScopeTasksWrite)).Patch("/{itemId}", h.UpdateItem())
+		r.With(middleware.RequireScopes(models.ScopeTasksWrite)).Delete("/{itemId}", h.DeleteItem())
+	})
+}
@@ -0,0 +1,16 @@
+package checklist
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"uzinfocom-todo/internal/models"
+)
+
+type Repository interface {
+	TaskExists(ctx context.Context, userId uuid.UUID, taskId uuid.UUID) (bool, error)
+	GetByTaskId(ctx context.Context, taskId uuid.UUID) ([]models.ChecklistItem, error)
+	GetById(ctx context.Context, taskId uuid.UUID, itemId uuid.UUID) (*models.ChecklistItem, error)
+	Create(ctx context.Context, item models.ChecklistItem) (*models.ChecklistItem, error)
+	Update(ctx context.Context, item models.ChecklistItem) (bool, bool, error)
+	Delete(ctx context.Context, taskId uuid.UUID, itemId uuid.UUID) (bool, bool, error)
+}
@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"uzinfocom-todo/internal/checklist"
+	"uzinfocom-todo/internal/models"
+	"uzinfocom-todo/pkg/db/db_postgres"
+)
+
+const itemColumns = `item_id, task_id, title, is_done, position, created_at, deleted_at`
+
+type checklistRepository struct {
+	db *sqlx.DB
+}
+
+func NewChecklistRepository(db *sqlx.DB) checklist.Repository {
+	return &checklistRepository{
+		db: db,
+	}
+}
+
+func itemScanDest(i *models.ChecklistItem) []interface{} {
+	return []interface{}{
+		&i.ItemId,
+		&i.TaskId,
+		&i.Title,
+		&i.IsDone,
+		&i.Position,
+		&i.CreatedAt,
+		&i.DeletedAt,
+	}
+}
+
+func (r *checklistRepository) TaskExists(ctx context.Context, userId uuid.UUID, taskId uuid.UUID) (bool, error) {
+	return db_postgres.TaskExists(ctx, r.db, userId, taskId)
+}
+
+func (r *checklistRepository) GetByTaskId(ctx context.Context, taskId uuid.UUID) ([]models.ChecklistItem, error) {
+	query := `SELECT ` + itemColumns + ` FROM checklist_items WHERE task_id = $1 AND deleted_at IS NULL
+		ORDER BY position, created_at`
+	items := []models.ChecklistItem{}
+
+	rows, err := r.db.QueryxContext(ctx, query, taskId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		i := models.ChecklistItem{}
+		if err = rows.Scan(itemScanDest(&i)...); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (r *checklistRepository) GetById(ctx context.Context, taskId uuid.UUID, itemId uuid.UUID) (*models.ChecklistItem, error) {
+	query := `SELECT ` + itemColumns + ` FROM checklist_items WHERE item_id = $1 AND task_id = $2 AND deleted_at IS NULL`
+	i := models.ChecklistItem{}
+
+	if err := r.db.QueryRowxContext(ctx, query, itemId, taskId).Scan(itemScanDest(&i)...); err != nil {
+		return nil, err
+	}
+	return &i, nil
+}
+
+// Create appends the item after the other items of the task unless a position is given
+func (r *checklistRepository) Create(ctx context.Context, item models.ChecklistItem) (*models.ChecklistItem, error) {
+	query := `INSERT INTO checklist_items (item_id, task_id, title, position)
+		VALUES ($1, $2, $3, COALESCE($4, (SELECT COALESCE(MAX(position), 0) + 1 FROM checklist_items WHERE task_id = $2)))
+		RETURNING ` + itemColumns
+	created := models.ChecklistItem{}
+	var position *int
+
+	if item.Position != 0 {
+		position = &item.Position
+	}
+
+	if err := r.db.QueryRowxContext(ctx, query, uuid.New(), item.TaskId, item.Title, position).Scan(itemScanDest(&created)...); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// Update saves the item and reports whether it existed and whether the change completed its task
+func (r *checklistRepository) Update(ctx context.Context, item models.ChecklistItem) (bool, bool, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, false, err
+	}
+
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(
+		ctx,
+		`UPDATE checklist_items SET title = $1, is_done = $2, position = $3 WHERE item_id = $4 AND task_id = $5 AND deleted_at IS NULL`,
+		item.Title,
+		item.IsDone,
+		item.Position,
+		item.ItemId,
+		item.TaskId,
+	)
+	if err != nil {
+		return false, false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil || affected == 0 {
+		return false, false, err
+	}
+
+	completed, err := completeTaskIfChecklistDone(ctx, tx, item.TaskId)
+	if err != nil {
+		return false, false, err
+	}
+	return true, completed, tx.Commit()
+}
+
+// Delete removes the item and reports whether it existed and whether the remaining items completed its task
+func (r *checklistRepository) Delete(ctx context.Context, taskId uuid.UUID, itemId uuid.UUID) (bool, bool, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, false, err
+	}
+
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `DELETE FROM checklist_items WHERE item_id = $1 AND task_id = $2 AND deleted_at IS NULL`, itemId, taskId)
+	if err != nil {
+		return false, false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil || affected == 0 {
+		return false, false, err
+	}
+
+	completed, err := completeTaskIfChecklistDone(ctx, tx, taskId)
+	if err != nil {
+		return false, false, err
+	}
+	return true, completed, tx.Commit()
+}
+
+// completeTaskIfChecklistDone marks an auto_complete task done once all of its items are. Blocked tasks, tasks that
+// have not started yet and recurring tasks, whose checklist is shared by every occurrence, are left alone.
+func completeTaskIfChecklistDone(ctx context.Context, tx *sqlx.Tx, taskId uuid.UUID) (bool, error) {
+	query := `UPDATE tasks SET status = 'done',
+			rank = (SELECT COALESCE(MAX(rank), 0) + $2 FROM tasks t WHERE t.user_id = tasks.user_id AND t.status = 'done' AND t.deletedAt IS NULL)
+		WHERE task_id = $1 AND auto_complete AND status IN ('todo', 'in_progress') AND deletedAt IS NULL
+			AND start_time <= CURRENT_TIMESTAMP AND recurrence_rule IS NULL
+			AND EXISTS (SELECT 1 FROM checklist_items WHERE task_id = $1 AND deleted_at IS NULL)
+			AND NOT EXISTS (SELECT 1 FROM checklist_items WHERE task_id = $1 AND deleted_at IS NULL AND is_done IS FALSE)`
+
+	res, err := tx.ExecContext(ctx, query, taskId, models.TaskRankStep)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
@@ -0,0 +1,16 @@
+package checklist
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"uzinfocom-todo/internal/models/request_objects"
+	"uzinfocom-todo/internal/models/response_objects"
+	"uzinfocom-todo/pkg/http_errors"
+)
+
+type UseCase interface {
+	GetItems(ctx context.Context, userId uuid.UUID, taskId uuid.UUID) (*[]response_objects.ResponseChecklistItem, http_errors.RestErr)
+	CreateItem(ctx context.Context, userId uuid.UUID, taskId uuid.UUID, requestItem request_objects.RequestChecklistItem) (*response_objects.ResponseChecklistItem, http_errors.RestErr)
+	UpdateItem(ctx context.Context, userId uuid.UUID, taskId uuid.UUID, itemId uuid.UUID, requestItem request_objects.RequestChecklistItem) (*response_objects.ResponseChecklistUpdate, http_errors.RestErr)
+	DeleteItem(ctx context.Context, userId uuid.UUID, taskId uuid.UUID, itemId uuid.UUID) (*response_objects.ResponseChecklistUpdate, http_errors.RestErr)
+}
@@ -0,0 +1,156 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"strings"
+	"unicode/utf8"
+	"uzinfocom-todo/config"
+	"uzinfocom-todo/internal/checklist"
+	"uzinfocom-todo/internal/models"
+	"uzinfocom-todo/internal/models/request_objects"
+	"uzinfocom-todo/internal/models/response_objects"
+	"uzinfocom-todo/pkg/http_errors"
+)
+
+const maxTitleLength = 255
+
+type checklistUseCase struct {
+	checklistRepo checklist.Repository
+	cfg           *config.Config
+}
+
+func NewChecklistUseCase(checklistRepo checklist.Repository, cfg *config.Config) checklist.UseCase {
+	return &checklistUseCase{
+		checklistRepo: checklistRepo,
+		cfg:           cfg,
+	}
+}
+
+func (uc *checklistUseCase) GetItems(ctx context.Context, userId uuid.UUID, taskId uuid.UUID) (*[]response_objects.ResponseChecklistItem, http_errors.RestErr) {
+	if restErr := uc.checkTask(ctx, userId, taskId); restErr != nil {
+		return nil, restErr
+	}
+
+	items, err := uc.checklistRepo.GetByTaskId(ctx, taskId)
+	if err != nil {
+		return nil, http_errors.ParseErrors(err)
+	}
+
+	responseItems := make([]response_objects.ResponseChecklistItem, 0, len(items))
+	for _, i := range items {
+		responseItems = append(responseItems, response_objects.NewResponseChecklistItem(i))
+	}
+	return &responseItems, nil
+}
+
+func (uc *checklistUseCase) CreateItem(ctx context.Context, userId uuid.UUID, taskId uuid.UUID, requestItem request_objects.RequestChecklistItem) (*response_objects.ResponseChecklistItem, http_errors.RestErr) {
+	if requestItem.Title == nil {
+		return nil, http_errors.Required("title")
+	}
+
+	if restErr := uc.checkTask(ctx, userId, taskId); restErr != nil {
+		return nil, restErr
+	}
+
+	item := models.ChecklistItem{TaskId: taskId}
+	if restErr := applyRequestItem(&item, requestItem); restErr != nil {
+		return nil, restErr
+	}
+
+	created, err := uc.checklistRepo.Create(ctx, item)
+	if err != nil {
+		return nil, http_errors.ParseErrors(err)
+	}
+
+	responseItem := response_objects.NewResponseChecklistItem(*created)
+	return &responseItem, nil
+}
+
+// UpdateItem changes only the fields present in the request. Completing the last open item completes
+// the task when its auto_complete rule is on.
+func (uc *checklistUseCase) UpdateItem(ctx context.Context, userId uuid.UUID, taskId uuid.UUID, itemId uuid.UUID, requestItem request_objects.RequestChecklistItem) (*response_objects.ResponseChecklistUpdate, http_errors.RestErr) {
+	if restErr := uc.checkTask(ctx, userId, taskId); restErr != nil {
+		return nil, restErr
+	}
+
+	item, err := uc.checklistRepo.GetById(ctx, taskId, itemId)
+	if err != nil {
+		return nil, parseChecklistErrors(err)
+	}
+
+	if restErr := applyRequestItem(item, requestItem); restErr != nil {
+		return nil, restErr
+	}
+
+	updated, completed, err := uc.checklistRepo.Update(ctx, *item)
+	if err != nil {
+		return nil, http_errors.ParseErrors(err)
+	}
+
+	if !updated {
+		return nil, http_errors.ChecklistItemNotFound()
+	}
+
+	responseItem := response_objects.NewResponseChecklistItem(*item)
+	return &response_objects.ResponseChecklistUpdate{Item: &responseItem, TaskCompleted: completed}, nil
+}
+
+func (uc *checklistUseCase) DeleteItem(ctx context.Context, userId uuid.UUID, taskId uuid.UUID, itemId uuid.UUID) (*response_objects.ResponseChecklistUpdate, http_errors.RestErr) {
+	if restErr := uc.checkTask(ctx, userId, taskId); restErr != nil {
+		return nil, restErr
+	}
+
+	deleted, completed, err := uc.checklistRepo.Delete(ctx, taskId, itemId)
+	if err != nil {
+		return nil, http_errors.ParseErrors(err)
+	}
+
+	if !deleted {
+		return nil, http_errors.ChecklistItemNotFound()
+	}
+	return &response_objects.ResponseChecklistUpdate{TaskCompleted: completed}, nil
+}
+
+func (uc *checklistUseCase) checkTask(ctx context.Context, userId uuid.UUID, taskId uuid.UUID) http_errors.RestErr {
+	exists, err := uc.checklistRepo.TaskExists(ctx, userId, taskId)
+	if err != nil {
+		return http_errors.ParseErrors(err)
+	}
+
+	if !exists {
+		return http_errors.TaskNotFound()
+	}
+	return nil
+}
+
+func applyRequestItem(item *models.ChecklistItem, requestItem request_objects.RequestChecklistItem) http_errors.RestErr {
+	if requestItem.Title != nil {
+		title := strings.TrimSpace(*requestItem.Title)
+		if title == "" || utf8.RuneCountInString(title) > maxTitleLength {
+			return http_errors.InvalidLength("title", maxTitleLength)
+		}
+		item.Title = title
+	}
+
+	if requestItem.IsDone != nil {
+		item.IsDone = *requestItem.IsDone
+	}
+
+	if requestItem.Position != nil {
+		if *requestItem.Position < 0 {
+			return http_errors.NegativeValue("position")
+		}
+		item.Position = *requestItem.Position
+	}
+	return nil
+}
+
+func parseChecklistErrors(err error) http_errors.RestErr {
+	if errors.Is(err, sql.ErrNoRows) {
+		return http_errors.ChecklistItemNotFound()
+	}
+	return http_errors.ParseErrors(err)
+}
@@ -0,0 +1,11 @@
+package list
+
+import "net/http"
+
+type Handler interface {
+	CreateList() http.HandlerFunc
+	GetLists() http.HandlerFunc
+	GetList() http.HandlerFunc
+	UpdateList() http.HandlerFunc
+	DeleteList() http.HandlerFunc
+}
@@ -0,0 +1,197 @@
+package http
+
+import (
+	"encoding/json"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"net/http"
+	"strconv"
+	"uzinfocom-todo/config"
+	"uzinfocom-todo/internal/list"
+	"uzinfocom-todo/internal/models"
+	"uzinfocom-todo/internal/models/request_objects"
+	"uzinfocom-todo/internal/models/response_objects"
+	"uzinfocom-todo/pkg/http_errors"
+	"uzinfocom-todo/pkg/i18n"
+)
+
+type listHandler struct {
+	cfg    *config.Config
+	listUC list.UseCase
+}
+
+func NewListHandler(cfg *config.Config, listUC list.UseCase) list.Handler {
+	return &listHandler{
+		cfg:    cfg,
+		listUC: listUC,
+	}
+}
+
+func (h *listHandler) CreateList() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var responseObject *response_objects.ResponseObject
+		var requestList request_objects.RequestList
+		userId := r.Context().Value("user").(models.User).UserId
+
+		err := json.NewDecoder(r.Body).Decode(&requestList)
+
+		if err != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), http_errors.InvalidJson("list"))
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(jsonResponse)
+			return
+		}
+
+		defer r.Body.Close()
+
+		responseList, restErr := h.listUC.CreateList(r.Context(), userId, requestList)
+
+		if restErr != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(restErr.Status())
+			w.Write(jsonResponse)
+			return
+		}
+
+		responseObject = response_objects.NewSuccessResponseObject(r.Context(), i18n.MessageListCreated, responseList)
+		jsonResponse, _ := json.Marshal(responseObject)
+		w.WriteHeader(http.StatusCreated)
+		w.Write(jsonResponse)
+	}
+}
+
+func (h *listHandler) GetLists() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var responseObject *response_objects.ResponseObject
+		userId := r.Context().Value("user").(models.User).UserId
+		includeArchived, _ := strconv.ParseBool(r.URL.Query().Get("include_archived"))
+
+		responseLists, restErr := h.listUC.GetLists(r.Context(), userId, includeArchived)
+
+		if restErr != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(restErr.Status())
+			w.Write(jsonResponse)
+			return
+		}
+
+		responseObject = response_objects.NewSuccessResponseObject(r.Context(), i18n.MessageListsFetched, responseLists)
+		jsonResponse, _ := json.Marshal(responseObject)
+		w.WriteHeader(http.StatusOK)
+		w.Write(jsonResponse)
+	}
+}
+
+func (h *listHandler) GetList() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var responseObject *response_objects.ResponseObject
+		userId := r.Context().Value("user").(models.User).UserId
+		listId, err := uuid.Parse(chi.URLParam(r, "listId"))
+
+		if err != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), http_errors.InvalidListId())
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(jsonResponse)
+			return
+		}
+
+		responseList, restErr := h.listUC.GetList(r.Context(), userId, listId)
+
+		if restErr != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(restErr.Status())
+			w.Write(jsonResponse)
+			return
+		}
+
+		responseObject = response_objects.NewSuccessResponseObject(r.Context(), i18n.MessageListFetched, responseList)
+		jsonResponse, _ := json.Marshal(responseObject)
+		w.WriteHeader(http.StatusOK)
+		w.Write(jsonResponse)
+	}
+}
+
+func (h *listHandler) UpdateList() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var responseObject *response_objects.ResponseObject
+		var requestList request_objects.RequestList
+		userId := r.Context().Value("user").(models.User).UserId
+		listId, err := uuid.Parse(chi.URLParam(r, "listId"))
+
+		if err != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), http_errors.InvalidListId())
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(jsonResponse)
+			return
+		}
+
+		err = json.NewDecoder(r.Body).Decode(&requestList)
+
+		if err != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), http_errors.InvalidJson("list"))
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(jsonResponse)
+			return
+		}
+
+		defer r.Body.Close()
+
+		responseList, restErr := h.listUC.UpdateList(r.Context(), userId, listId, requestList)
+
+		if restErr != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(restErr.Status())
+			w.Write(jsonResponse)
+			return
+		}
+
+		responseObject = response_objects.NewSuccessResponseObject(r.Context(), i18n.MessageListUpdated, responseList)
+		jsonResponse, _ := json.Marshal(responseObject)
+		w.WriteHeader(http.StatusOK)
+		w.Write(jsonResponse)
+	}
+}
+
+func (h *listHandler) DeleteList() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var responseObject *response_objects.ResponseObject
+		userId := r.Context().Value("user").(models.User).UserId
+		listId, err := uuid.Parse(chi.URLParam(r, "listId"))
+
+		if err != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), http_errors.InvalidListId())
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(jsonResponse)
+			return
+		}
+
+		restErr := h.listUC.DeleteList(r.Context(), userId, listId)
+
+		if restErr != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(restErr.Status())
+			w.Write(jsonResponse)
+			return
+		}
+
+		responseObject = response_objects.NewSuccessResponseObject(r.Context(), i18n.MessageListDeleted, nil)
+		jsonResponse, _ := json.Marshal(responseObject)
+		w.WriteHeader(http.StatusOK)
+		w.Write(jsonResponse)
+	}
+}
@@ -0,0 +1,20 @@
+package http
+
+import (
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"uzinfocom-todo/internal/list"
+	"uzinfocom-todo/internal/middleware"
+	"uzinfocom-todo/internal/models"
+)
+
+func MapRoutes(router *chi.Mux, h list.Handler, authMiddleware func(http.Handler) http.Handler) {
+	router.Route("/lists", func(r chi.Router) {
+		r.Use(authMiddleware)
+		r.With(middleware.RequireScopes(models.ScopeTasksWrite)).Post("/", h.CreateList())
+		r.With(middleware.RequireScopes(models.ScopeTasksRead)).Get("/", h.GetLists())
+		r.With(middleware.RequireScopes(models.ScopeTasksRead)).Get("/{listId}", h.GetList())
+		r.With(middleware.RequireScopes(models.ScopeTasksWrite)).Patch("/{listId}", h.UpdateList())
+		r.With(middleware.RequireScopes(models.ScopeTasksWrite)).Delete("/{listId}", h.DeleteList())
+	})
+}
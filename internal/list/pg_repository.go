@@ -0,0 +1,16 @@
+package list
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"uzinfocom-todo/internal/models"
+)
+
+type Repository interface {
+	Create(ctx context.Context, list models.List) (*models.List, error)
+	GetByUserId(ctx context.Context, userId uuid.UUID, includeArchived bool) ([]models.List, error)
+	GetById(ctx context.Context, userId uuid.UUID, listId uuid.UUID) (*models.List, error)
+	GetInbox(ctx context.Context, userId uuid.UUID) (*models.List, error)
+	Update(ctx context.Context, list models.List) (bool, error)
+	Delete(ctx context.Context, userId uuid.UUID, listId uuid.UUID) (bool, error)
+}
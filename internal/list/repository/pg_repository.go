@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"uzinfocom-todo/internal/list"
+	"uzinfocom-todo/internal/models"
+	"uzinfocom-todo/pkg/db/db_postgres"
+)
+
+// listNameConstraint keeps the names of the lists of a user unique
+const listNameConstraint = "lists_user_id_name_key"
+
+const listColumns = `list_id, user_id, name, colour, archived, position, is_inbox, created_at`
+
+type listRepository struct {
+	db *sqlx.DB
+}
+
+func NewListRepository(db *sqlx.DB) list.Repository {
+	return &listRepository{
+		db: db,
+	}
+}
+
+func listScanDest(l *models.List) []interface{} {
+	return []interface{}{
+		&l.ListId,
+		&l.UserId,
+		&l.Name,
+		&l.Colour,
+		&l.Archived,
+		&l.Position,
+		&l.IsInbox,
+		&l.CreatedAt,
+	}
+}
+
+// Create appends the list after the user's other lists unless a position is given
+func (r *listRepository) Create(ctx context.Context, l models.List) (*models.List, error) {
+	query := `INSERT INTO lists (list_id, user_id, name, colour, archived, position)
+		VALUES ($1, $2, $3, $4, $5, COALESCE($6, (SELECT COALESCE(MAX(position), 0) + 1 FROM lists WHERE user_id = $2)))
+		RETURNING ` + listColumns
+	created := models.List{}
+	var position *int
+
+	if l.Position != 0 {
+		position = &l.Position
+	}
+
+	if err := r.db.QueryRowxContext(
+		ctx,
+		query,
+		uuid.New(),
+		l.UserId,
+		l.Name,
+		l.Colour,
+		l.Archived,
+		position,
+	).Scan(listScanDest(&created)...); err != nil {
+		if db_postgres.IsUniqueViolation(err, listNameConstraint) {
+			return nil, models.ErrListExists
+		}
+		return nil, err
+	}
+	return &created, nil
+}
+
+func (r *listRepository) GetByUserId(ctx context.Context, userId uuid.UUID, includeArchived bool) ([]models.List, error) {
+	query := `SELECT ` + listColumns + ` FROM lists WHERE user_id = $1 AND ($2 OR NOT archived)
+		ORDER BY is_inbox DESC, position, name`
+	lists := []models.List{}
+
+	rows, err := r.db.QueryxContext(ctx, query, userId, includeArchived)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		l := models.List{}
+		if err = rows.Scan(listScanDest(&l)...); err != nil {
+			return nil, err
+		}
+		lists = append(lists, l)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return lists, nil
+}
+
+func (r *listRepository) GetById(ctx context.Context, userId uuid.UUID, listId uuid.UUID) (*models.List, error) {
+	query := `SELECT ` + listColumns + ` FROM lists WHERE list_id = $1 AND user_id = $2`
+	l := models.List{}
+
+	if err := r.db.QueryRowxContext(ctx, query, listId, userId).Scan(listScanDest(&l)...); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+func (r *listRepository) GetInbox(ctx context.Context, userId uuid.UUID) (*models.List, error) {
+	query := `SELECT ` + listColumns + ` FROM lists WHERE user_id = $1 AND is_inbox`
+	l := models.List{}
+
+	if err := r.db.QueryRowxContext(ctx, query, userId).Scan(listScanDest(&l)...); err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+func (r *listRepository) Update(ctx context.Context, l models.List) (bool, error) {
+	query := `UPDATE lists SET name = $1, colour = $2, archived = $3, position = $4 WHERE list_id = $5 AND user_id = $6`
+
+	res, err := r.db.ExecContext(ctx, query, l.Name, l.Colour, l.Archived, l.Position, l.ListId, l.UserId)
+	if err != nil {
+		if db_postgres.IsUniqueViolation(err, listNameConstraint) {
+			return false, models.ErrListExists
+		}
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// Delete moves the tasks of the list to the user's inbox before removing it, the inbox itself is never deleted
+func (r *listRepository) Delete(ctx context.Context, userId uuid.UUID, listId uuid.UUID) (bool, error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+
+	defer tx.Rollback()
+
+	if _, err = tx.ExecContext(
+		ctx,
+		`UPDATE tasks SET list_id = (SELECT list_id FROM lists WHERE user_id = $1 AND is_inbox)
+			WHERE list_id = $2 AND user_id = $1`,
+		userId,
+		listId,
+	); err != nil {
+		return false, err
+	}
+
+	res, err := tx.ExecContext(ctx, `DELETE FROM lists WHERE list_id = $1 AND user_id = $2 AND NOT is_inbox`, listId, userId)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	if affected == 0 {
+		return false, nil
+	}
+	return true, tx.Commit()
+}
@@ -0,0 +1,17 @@
+package list
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"uzinfocom-todo/internal/models/request_objects"
+	"uzinfocom-todo/internal/models/response_objects"
+	"uzinfocom-todo/pkg/http_errors"
+)
+
+type UseCase interface {
+	CreateList(ctx context.Context, userId uuid.UUID, requestList request_objects.RequestList) (*response_objects.ResponseList, http_errors.RestErr)
+	GetLists(ctx context.Context, userId uuid.UUID, includeArchived bool) (*[]response_objects.ResponseList, http_errors.RestErr)
+	GetList(ctx context.Context, userId uuid.UUID, listId uuid.UUID) (*response_objects.ResponseList, http_errors.RestErr)
+	UpdateList(ctx context.Context, userId uuid.UUID, listId uuid.UUID, requestList request_objects.RequestList) (*response_objects.ResponseList, http_errors.RestErr)
+	DeleteList(ctx context.Context, userId uuid.UUID, listId uuid.UUID) http_errors.RestErr
+}
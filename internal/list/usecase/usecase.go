@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+	"uzinfocom-todo/config"
+	"uzinfocom-todo/internal/list"
+	"uzinfocom-todo/internal/models"
+	"uzinfocom-todo/internal/models/request_objects"
+	"uzinfocom-todo/internal/models/response_objects"
+	"uzinfocom-todo/pkg/http_errors"
+)
+
+const maxNameLength = 64
+
+var colourPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+type listUseCase struct {
+	listRepo list.Repository
+	cfg      *config.Config
+}
+
+func NewListUseCase(listRepo list.Repository, cfg *config.Config) list.UseCase {
+	return &listUseCase{
+		listRepo: listRepo,
+		cfg:      cfg,
+	}
+}
+
+func (uc *listUseCase) CreateList(ctx context.Context, userId uuid.UUID, requestList request_objects.RequestList) (*response_objects.ResponseList, http_errors.RestErr) {
+	if requestList.Name == nil {
+		return nil, http_errors.Required("name")
+	}
+
+	l := models.List{UserId: userId}
+	if restErr := applyRequestList(&l, requestList); restErr != nil {
+		return nil, restErr
+	}
+
+	created, err := uc.listRepo.Create(ctx, l)
+	if err != nil {
+		return nil, parseListErrors(err)
+	}
+
+	responseList := response_objects.NewResponseList(*created)
+	return &responseList, nil
+}
+
+func (uc *listUseCase) GetLists(ctx context.Context, userId uuid.UUID, includeArchived bool) (*[]response_objects.ResponseList, http_errors.RestErr) {
+	lists, err := uc.listRepo.GetByUserId(ctx, userId, includeArchived)
+	if err != nil {
+		return nil, http_errors.ParseErrors(err)
+	}
+
+	responseLists := make([]response_objects.ResponseList, 0, len(lists))
+	for _, l := range lists {
+		responseLists = append(responseLists, response_objects.NewResponseList(l))
+	}
+	return &responseLists, nil
+}
+
+func (uc *listUseCase) GetList(ctx context.Context, userId uuid.UUID, listId uuid.UUID) (*response_objects.ResponseList, http_errors.RestErr) {
+	l, err := uc.listRepo.GetById(ctx, userId, listId)
+	if err != nil {
+		return nil, parseListErrors(err)
+	}
+
+	responseList := response_objects.NewResponseList(*l)
+	return &responseList, nil
+}
+
+// UpdateList changes only the fields present in the request, the inbox can only be recoloured and reordered
+func (uc *listUseCase) UpdateList(ctx context.Context, userId uuid.UUID, listId uuid.UUID, requestList request_objects.RequestList) (*response_objects.ResponseList, http_errors.RestErr) {
+	l, err := uc.listRepo.GetById(ctx, userId, listId)
+	if err != nil {
+		return nil, parseListErrors(err)
+	}
+
+	if l.IsInbox && (requestList.Name != nil && *requestList.Name != l.Name || requestList.Archived != nil && *requestList.Archived) {
+		return nil, http_errors.InboxListChange()
+	}
+
+	if restErr := applyRequestList(l, requestList); restErr != nil {
+		return nil, restErr
+	}
+
+	updated, err := uc.listRepo.Update(ctx, *l)
+	if err != nil {
+		return nil, parseListErrors(err)
+	}
+
+	if !updated {
+		return nil, http_errors.ListNotFound()
+	}
+
+	responseList := response_objects.NewResponseList(*l)
+	return &responseList, nil
+}
+
+func (uc *listUseCase) DeleteList(ctx context.Context, userId uuid.UUID, listId uuid.UUID) http_errors.RestErr {
+	l, err := uc.listRepo.GetById(ctx, userId, listId)
+	if err != nil {
+		return parseListErrors(err)
+	}
+
+	if l.IsInbox {
+		return http_errors.InboxListChange()
+	}
+
+	deleted, err := uc.listRepo.Delete(ctx, userId, listId)
+	if err != nil {
+		return http_errors.ParseErrors(err)
+	}
+
+	if !deleted {
+		return http_errors.ListNotFound()
+	}
+	return nil
+}
+
+func applyRequestList(l *models.List, requestList request_objects.RequestList) http_errors.RestErr {
+	if requestList.Name != nil {
+		name := strings.TrimSpace(*requestList.Name)
+		if name == "" || utf8.RuneCountInString(name) > maxNameLength {
+			return http_errors.InvalidLength("name", maxNameLength)
+		}
+		l.Name = name
+	}
+
+	if requestList.Colour != nil {
+		if *requestList.Colour == "" {
+			l.Colour = nil
+		} else if !colourPattern.MatchString(*requestList.Colour) {
+			return http_errors.InvalidColour()
+		} else {
+			colour := strings.ToLower(*requestList.Colour)
+			l.Colour = &colour
+		}
+	}
+
+	if requestList.Archived != nil {
+		l.Archived = *requestList.Archived
+	}
+
+	if requestList.Position != nil {
+		if *requestList.Position < 0 {
+			return http_errors.NegativeValue("position")
+		}
+		l.Position = *requestList.Position
+	}
+	return nil
+}
+
+func parseListErrors(err error) http_errors.RestErr {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return http_errors.ListNotFound()
+	case errors.Is(err, models.ErrListExists):
+		return http_errors.ListAlreadyExists()
+	default:
+		return http_errors.ParseErrors(err)
+	}
+}
@@ -0,0 +1,10 @@
+package mfa
+
+import "net/http"
+
+type Handler interface {
+	Enroll() http.HandlerFunc
+	Confirm() http.HandlerFunc
+	Disable() http.HandlerFunc
+	Login() http.HandlerFunc
+}
@@ -0,0 +1,171 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"uzinfocom-todo/config"
+	"uzinfocom-todo/internal/auth"
+	"uzinfocom-todo/internal/mfa"
+	"uzinfocom-todo/internal/models"
+	"uzinfocom-todo/internal/models/request_objects"
+	"uzinfocom-todo/internal/models/response_objects"
+	"uzinfocom-todo/pkg/http_errors"
+	"uzinfocom-todo/pkg/i18n"
+	"uzinfocom-todo/pkg/util"
+)
+
+type mfaHandler struct {
+	cfg    *config.Config
+	mfaUC  mfa.UseCase
+	authUC auth.UseCase
+}
+
+func NewMfaHandler(cfg *config.Config, mfaUC mfa.UseCase, authUC auth.UseCase) mfa.Handler {
+	return &mfaHandler{
+		cfg:    cfg,
+		mfaUC:  mfaUC,
+		authUC: authUC,
+	}
+}
+
+func (h *mfaHandler) Enroll() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var responseObject *response_objects.ResponseObject
+		userId := r.Context().Value("user").(models.User).UserId
+
+		enrollment, restErr := h.mfaUC.Enroll(r.Context(), userId)
+
+		if restErr != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(restErr.Status())
+			w.Write(jsonResponse)
+			return
+		}
+
+		responseObject = response_objects.NewSuccessResponseObject(r.Context(), i18n.MessageTotpEnrollmentStarted, enrollment)
+		jsonResponse, _ := json.Marshal(responseObject)
+		w.WriteHeader(http.StatusOK)
+		w.Write(jsonResponse)
+	}
+}
+
+func (h *mfaHandler) Confirm() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var responseObject *response_objects.ResponseObject
+		var requestCode request_objects.RequestTotpCode
+		userId := r.Context().Value("user").(models.User).UserId
+
+		err := json.NewDecoder(r.Body).Decode(&requestCode)
+
+		if err != nil || requestCode.Code == "" {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), http_errors.InvalidJson("authentication code"))
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(jsonResponse)
+			return
+		}
+
+		defer r.Body.Close()
+
+		recoveryCodes, restErr := h.mfaUC.Confirm(r.Context(), userId, requestCode.Code)
+
+		if restErr != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(restErr.Status())
+			w.Write(jsonResponse)
+			return
+		}
+
+		responseObject = response_objects.NewSuccessResponseObject(r.Context(), i18n.MessageTotpEnabled, recoveryCodes)
+		jsonResponse, _ := json.Marshal(responseObject)
+		w.WriteHeader(http.StatusOK)
+		w.Write(jsonResponse)
+	}
+}
+
+func (h *mfaHandler) Disable() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var responseObject *response_objects.ResponseObject
+		var requestCode request_objects.RequestTotpCode
+		userId := r.Context().Value("user").(models.User).UserId
+
+		err := json.NewDecoder(r.Body).Decode(&requestCode)
+
+		if err != nil || requestCode.Code == "" {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), http_errors.InvalidJson("authentication code"))
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(jsonResponse)
+			return
+		}
+
+		defer r.Body.Close()
+
+		restErr := h.mfaUC.Disable(r.Context(), userId, requestCode.Code)
+
+		if restErr != nil {
+			http_errors.SetRetryAfter(w, restErr)
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(restErr.Status())
+			w.Write(jsonResponse)
+			return
+		}
+
+		responseObject = response_objects.NewSuccessResponseObject(r.Context(), i18n.MessageTotpDisabled, nil)
+		jsonResponse, _ := json.Marshal(responseObject)
+		w.WriteHeader(http.StatusOK)
+		w.Write(jsonResponse)
+	}
+}
+
+func (h *mfaHandler) Login() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var responseObject *response_objects.ResponseObject
+		var requestLogin request_objects.RequestTwoFactorLogin
+
+		err := json.NewDecoder(r.Body).Decode(&requestLogin)
+
+		if err != nil || requestLogin.ChallengeToken == "" || requestLogin.Code == "" {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), http_errors.InvalidJson("two-factor login"))
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(jsonResponse)
+			return
+		}
+
+		defer r.Body.Close()
+
+		u, restErr := h.mfaUC.VerifyChallenge(r.Context(), requestLogin.ChallengeToken, requestLogin.Code)
+
+		if restErr != nil {
+			http_errors.SetRetryAfter(w, restErr)
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(restErr.Status())
+			w.Write(jsonResponse)
+			return
+		}
+
+		tokens, restErr := h.authUC.IssueTokens(r.Context(), u, util.SessionFromRequest(r))
+
+		if restErr != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(restErr.Status())
+			w.Write(jsonResponse)
+			return
+		}
+
+		responseObject = response_objects.NewSuccessResponseObject(r.Context(), i18n.MessageTokensIssued, tokens)
+		jsonResponse, _ := json.Marshal(responseObject)
+		w.WriteHeader(http.StatusOK)
+		w.Write(jsonResponse)
+	}
+}
@@ -0,0 +1,20 @@
+package http
+
+import (
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"uzinfocom-todo/internal/mfa"
+)
+
+func MapRoutes(router *chi.Mux, h mfa.Handler, jwtMiddleware func(http.Handler) http.Handler, rateLimitMiddleware func(http.Handler) http.Handler) {
+	router.Route("/api/2fa", func(r chi.Router) {
+		r.With(rateLimitMiddleware).Post("/login", h.Login())
+
+		r.Group(func(r chi.Router) {
+			r.Use(jwtMiddleware)
+			r.Post("/enroll", h.Enroll())
+			r.Post("/confirm", h.Confirm())
+			r.Post("/disable", h.Disable())
+		})
+	})
+}
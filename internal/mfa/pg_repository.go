@@ -0,0 +1,20 @@
+package mfa
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"time"
+	"uzinfocom-todo/internal/models"
+)
+
+type Repository interface {
+	GetTotp(ctx context.Context, userId uuid.UUID) (*models.Totp, error)
+	SetPendingSecret(ctx context.Context, userId uuid.UUID, secret string) (bool, error)
+	Enable(ctx context.Context, userId uuid.UUID, step int64, recoveryCodeHashes []string) error
+	Disable(ctx context.Context, userId uuid.UUID) error
+	UseStep(ctx context.Context, userId uuid.UUID, step int64) (bool, error)
+	UseRecoveryCode(ctx context.Context, userId uuid.UUID, codeHash string) (bool, error)
+	CreateChallenge(ctx context.Context, challengeId uuid.UUID, userId uuid.UUID, expiresAt time.Time) error
+	IncrementChallengeAttempts(ctx context.Context, challengeId uuid.UUID, userId uuid.UUID, maxAttempts int) (bool, error)
+	UseChallenge(ctx context.Context, challengeId uuid.UUID) (bool, error)
+}
@@ -0,0 +1,200 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"time"
+	"uzinfocom-todo/internal/mfa"
+	"uzinfocom-todo/internal/models"
+)
+
+type mfaRepository struct {
+	db *sqlx.DB
+}
+
+func NewMfaRepository(db *sqlx.DB) mfa.Repository {
+	return &mfaRepository{
+		db: db,
+	}
+}
+
+func (r *mfaRepository) GetTotp(ctx context.Context, userId uuid.UUID) (*models.Totp, error) {
+	query := `SELECT user_id, phone_number, totp_secret, totp_enabled_at, totp_last_used_step FROM users WHERE user_id = $1`
+	t := models.Totp{}
+
+	if err := r.db.QueryRowxContext(ctx, query, userId).Scan(
+		&t.UserId,
+		&t.PhoneNumber,
+		&t.Secret,
+		&t.EnabledAt,
+		&t.LastUsedStep,
+	); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// SetPendingSecret stores a secret awaiting confirmation. It reports false if 2FA is already enabled.
+func (r *mfaRepository) SetPendingSecret(ctx context.Context, userId uuid.UUID, secret string) (bool, error) {
+	query := `UPDATE users SET totp_secret = $1, totp_last_used_step = NULL WHERE user_id = $2 AND totp_enabled_at IS NULL`
+
+	res, err := r.db.ExecContext(ctx, query, secret, userId)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+func (r *mfaRepository) Enable(ctx context.Context, userId uuid.UUID, step int64, recoveryCodeHashes []string) error {
+	return r.inTx(ctx, func(tx *sqlx.Tx) error {
+		if _, err := tx.ExecContext(
+			ctx,
+			`UPDATE users SET totp_enabled_at = CURRENT_TIMESTAMP, totp_last_used_step = $1 WHERE user_id = $2`,
+			step,
+			userId,
+		); err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, `DELETE FROM recovery_codes WHERE user_id = $1`, userId); err != nil {
+			return err
+		}
+
+		for _, codeHash := range recoveryCodeHashes {
+			if _, err := tx.ExecContext(
+				ctx,
+				`INSERT INTO recovery_codes (code_id, user_id, code_hash) VALUES ($1, $2, $3)`,
+				uuid.New(),
+				userId,
+				codeHash,
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (r *mfaRepository) Disable(ctx context.Context, userId uuid.UUID) error {
+	return r.inTx(ctx, func(tx *sqlx.Tx) error {
+		if _, err := tx.ExecContext(
+			ctx,
+			`UPDATE users SET totp_secret = NULL, totp_enabled_at = NULL, totp_last_used_step = NULL WHERE user_id = $1`,
+			userId,
+		); err != nil {
+			return err
+		}
+
+		_, err := tx.ExecContext(ctx, `DELETE FROM recovery_codes WHERE user_id = $1`, userId)
+		return err
+	})
+}
+
+// UseStep records the time step of an accepted code and reports false if that step or a later one was already used
+func (r *mfaRepository) UseStep(ctx context.Context, userId uuid.UUID, step int64) (bool, error) {
+	query := `UPDATE users SET totp_last_used_step = $1
+		WHERE user_id = $2 AND (totp_last_used_step IS NULL OR totp_last_used_step < $1)`
+
+	res, err := r.db.ExecContext(ctx, query, step, userId)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+func (r *mfaRepository) UseRecoveryCode(ctx context.Context, userId uuid.UUID, codeHash string) (bool, error) {
+	query := `UPDATE recovery_codes SET used_at = CURRENT_TIMESTAMP WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL`
+
+	res, err := r.db.ExecContext(ctx, query, userId, codeHash)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// CreateChallenge stores a challenge issued after the first login step and drops the expired ones of the user
+func (r *mfaRepository) CreateChallenge(ctx context.Context, challengeId uuid.UUID, userId uuid.UUID, expiresAt time.Time) error {
+	return r.inTx(ctx, func(tx *sqlx.Tx) error {
+		if _, err := tx.ExecContext(
+			ctx,
+			`DELETE FROM totp_challenges WHERE user_id = $1 AND expires_at < CURRENT_TIMESTAMP`,
+			userId,
+		); err != nil {
+			return err
+		}
+
+		_, err := tx.ExecContext(
+			ctx,
+			`INSERT INTO totp_challenges (challenge_id, user_id, expires_at) VALUES ($1, $2, $3)`,
+			challengeId,
+			userId,
+			expiresAt,
+		)
+		return err
+	})
+}
+
+// IncrementChallengeAttempts counts one more code entered for a challenge. It reports false when the challenge
+// is used, expired or has already reached maxAttempts, such a challenge cannot be completed anymore.
+func (r *mfaRepository) IncrementChallengeAttempts(ctx context.Context, challengeId uuid.UUID, userId uuid.UUID, maxAttempts int) (bool, error) {
+	query := `UPDATE totp_challenges SET attempts = attempts + 1
+		WHERE challenge_id = $1 AND user_id = $2 AND used_at IS NULL AND expires_at > CURRENT_TIMESTAMP AND attempts < $3`
+
+	res, err := r.db.ExecContext(ctx, query, challengeId, userId, maxAttempts)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// UseChallenge marks a completed challenge as used and reports false if it already was
+func (r *mfaRepository) UseChallenge(ctx context.Context, challengeId uuid.UUID) (bool, error) {
+	query := `UPDATE totp_challenges SET used_at = CURRENT_TIMESTAMP WHERE challenge_id = $1 AND used_at IS NULL`
+
+	res, err := r.db.ExecContext(ctx, query, challengeId)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+func (r *mfaRepository) inTx(ctx context.Context, fn func(tx *sqlx.Tx) error) error {
+	tx, err := r.db.BeginTxx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return err
+	}
+
+	if err = fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,17 @@
+package mfa
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"uzinfocom-todo/internal/models"
+	"uzinfocom-todo/internal/models/response_objects"
+	"uzinfocom-todo/pkg/http_errors"
+)
+
+type UseCase interface {
+	Enroll(ctx context.Context, userId uuid.UUID) (*response_objects.ResponseTotpEnrollment, http_errors.RestErr)
+	Confirm(ctx context.Context, userId uuid.UUID, code string) (*response_objects.ResponseRecoveryCodes, http_errors.RestErr)
+	Disable(ctx context.Context, userId uuid.UUID, code string) http_errors.RestErr
+	IssueChallenge(ctx context.Context, user *models.User) (*response_objects.ResponseTwoFactorChallenge, http_errors.RestErr)
+	VerifyChallenge(ctx context.Context, challengeToken string, code string) (*models.User, http_errors.RestErr)
+}
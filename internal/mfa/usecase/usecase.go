@@ -0,0 +1,311 @@
+package usecase
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+	"uzinfocom-todo/config"
+	"uzinfocom-todo/internal/mfa"
+	"uzinfocom-todo/internal/models"
+	"uzinfocom-todo/internal/models/response_objects"
+	"uzinfocom-todo/internal/user"
+	"uzinfocom-todo/pkg/http_errors"
+	"uzinfocom-todo/pkg/totp"
+	"uzinfocom-todo/pkg/util"
+)
+
+const (
+	defaultIssuer            = "Uzinfocom Todo"
+	defaultRecoveryCodeCount = 10
+	defaultMaxAttempts       = 5
+	recoveryCodeBytes        = 5
+	// accept the previous and the next code to tolerate clock drift of the user's device
+	allowedSkew = 1
+)
+
+type mfaUseCase struct {
+	mfaRepo  mfa.Repository
+	userRepo user.Repository
+	keySet   *util.KeySet
+	cfg      *config.Config
+}
+
+func NewMfaUseCase(mfaRepo mfa.Repository, userRepo user.Repository, keySet *util.KeySet, cfg *config.Config) mfa.UseCase {
+	return &mfaUseCase{
+		mfaRepo:  mfaRepo,
+		userRepo: userRepo,
+		keySet:   keySet,
+		cfg:      cfg,
+	}
+}
+
+// Enroll generates a new secret. 2FA stays disabled until the secret is confirmed with a valid code.
+func (uc *mfaUseCase) Enroll(ctx context.Context, userId uuid.UUID) (*response_objects.ResponseTotpEnrollment, http_errors.RestErr) {
+	t, err := uc.mfaRepo.GetTotp(ctx, userId)
+	if err != nil {
+		return nil, http_errors.ParseErrors(err)
+	}
+
+	if t.EnabledAt != nil {
+		return nil, http_errors.TotpAlreadyEnabled()
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, http_errors.ParseErrors(err)
+	}
+
+	stored, err := uc.mfaRepo.SetPendingSecret(ctx, userId, secret)
+	if err != nil {
+		return nil, http_errors.ParseErrors(err)
+	}
+
+	if !stored {
+		return nil, http_errors.TotpAlreadyEnabled()
+	}
+
+	return &response_objects.ResponseTotpEnrollment{
+		Secret:     secret,
+		OtpauthUri: totp.URI(uc.issuer(), t.PhoneNumber, secret),
+	}, nil
+}
+
+func (uc *mfaUseCase) Confirm(ctx context.Context, userId uuid.UUID, code string) (*response_objects.ResponseRecoveryCodes, http_errors.RestErr) {
+	t, err := uc.mfaRepo.GetTotp(ctx, userId)
+	if err != nil {
+		return nil, http_errors.ParseErrors(err)
+	}
+
+	if t.EnabledAt != nil {
+		return nil, http_errors.TotpAlreadyEnabled()
+	}
+
+	if t.Secret == nil {
+		return nil, http_errors.TotpNotEnrolled()
+	}
+
+	step, ok := totp.Validate(*t.Secret, code, time.Now(), allowedSkew)
+	if !ok {
+		return nil, http_errors.InvalidTotpCode()
+	}
+
+	recoveryCodes := make([]string, uc.recoveryCodeCount())
+	recoveryCodeHashes := make([]string, len(recoveryCodes))
+
+	for i := range recoveryCodes {
+		recoveryCode, err := generateRecoveryCode()
+		if err != nil {
+			return nil, http_errors.ParseErrors(err)
+		}
+		recoveryCodes[i] = recoveryCode
+		recoveryCodeHashes[i] = uc.hashRecoveryCode(recoveryCode)
+	}
+
+	if err = uc.mfaRepo.Enable(ctx, userId, step, recoveryCodeHashes); err != nil {
+		return nil, http_errors.ParseErrors(err)
+	}
+
+	return &response_objects.ResponseRecoveryCodes{RecoveryCodes: recoveryCodes}, nil
+}
+
+func (uc *mfaUseCase) Disable(ctx context.Context, userId uuid.UUID, code string) http_errors.RestErr {
+	t, err := uc.mfaRepo.GetTotp(ctx, userId)
+	if err != nil {
+		return http_errors.ParseErrors(err)
+	}
+
+	if t.EnabledAt == nil {
+		return http_errors.TotpNotEnabled()
+	}
+
+	if restErr := uc.verifyCode(ctx, t, code); restErr != nil {
+		return restErr
+	}
+
+	if err = uc.userRepo.ResetFailedLogins(ctx, userId); err != nil {
+		return http_errors.ParseErrors(err)
+	}
+
+	if err = uc.mfaRepo.Disable(ctx, userId); err != nil {
+		return http_errors.ParseErrors(err)
+	}
+	return nil
+}
+
+func (uc *mfaUseCase) IssueChallenge(ctx context.Context, user *models.User) (*response_objects.ResponseTwoFactorChallenge, http_errors.RestErr) {
+	challengeId := uuid.New()
+	expiresAt := time.Now().Add(util.ChallengeTokenTTL)
+
+	if err := uc.mfaRepo.CreateChallenge(ctx, challengeId, user.UserId, expiresAt); err != nil {
+		return nil, http_errors.ParseErrors(err)
+	}
+
+	challengeToken, err := util.GenerateChallengeToken(user, challengeId, expiresAt, uc.keySet)
+	if err != nil {
+		return nil, http_errors.ParseErrors(err)
+	}
+
+	return &response_objects.ResponseTwoFactorChallenge{
+		TwoFactorRequired: true,
+		ChallengeToken:    challengeToken,
+	}, nil
+}
+
+// VerifyChallenge completes the second login step with either a TOTP code or an unused recovery code.
+// A challenge accepts at most maxAttempts codes, every wrong one also counts towards the account lockout.
+func (uc *mfaUseCase) VerifyChallenge(ctx context.Context, challengeToken string, code string) (*models.User, http_errors.RestErr) {
+	claims, err := util.ParseToken(challengeToken, util.ChallengeTokenType, uc.keySet)
+	if err != nil {
+		return nil, http_errors.InvalidChallenge()
+	}
+
+	userId, err := uuid.Parse(claims.UserId)
+	if err != nil {
+		return nil, http_errors.InvalidChallenge()
+	}
+
+	challengeId, err := uuid.Parse(claims.ID)
+	if err != nil {
+		return nil, http_errors.InvalidChallenge()
+	}
+
+	allowed, err := uc.mfaRepo.IncrementChallengeAttempts(ctx, challengeId, userId, uc.maxAttempts())
+	if err != nil {
+		return nil, http_errors.ParseErrors(err)
+	}
+
+	if !allowed {
+		return nil, http_errors.InvalidChallenge()
+	}
+
+	t, err := uc.mfaRepo.GetTotp(ctx, userId)
+	if err != nil {
+		return nil, http_errors.ParseErrors(err)
+	}
+
+	if t.EnabledAt == nil {
+		return nil, http_errors.InvalidChallenge()
+	}
+
+	if restErr := uc.verifyCode(ctx, t, code); restErr != nil {
+		return nil, restErr
+	}
+
+	used, err := uc.mfaRepo.UseChallenge(ctx, challengeId)
+	if err != nil {
+		return nil, http_errors.ParseErrors(err)
+	}
+
+	if !used {
+		return nil, http_errors.InvalidChallenge()
+	}
+
+	if err = uc.userRepo.ResetFailedLogins(ctx, userId); err != nil {
+		return nil, http_errors.ParseErrors(err)
+	}
+
+	foundUser, err := uc.userRepo.GetById(ctx, userId)
+	if err != nil {
+		return nil, http_errors.ParseErrors(err)
+	}
+
+	if foundUser.DisabledAt != nil {
+		return nil, http_errors.AccountDisabled()
+	}
+
+	foundUser.SanitizePassword()
+	return foundUser, nil
+}
+
+// verifyCode accepts a TOTP code whose time step has not been used yet, or an unused recovery code.
+// Codes are not checked while the account is locked and a wrong one counts as a failed login.
+func (uc *mfaUseCase) verifyCode(ctx context.Context, t *models.Totp, code string) http_errors.RestErr {
+	locked, err := uc.userRepo.GetLoginLock(ctx, t.UserId)
+	if err != nil {
+		return http_errors.ParseErrors(err)
+	}
+
+	if locked > 0 {
+		return http_errors.AccountLocked(locked)
+	}
+
+	valid, err := uc.checkCode(ctx, t, strings.TrimSpace(code))
+	if err != nil {
+		return http_errors.ParseErrors(err)
+	}
+
+	if !valid {
+		return uc.registerFailedCode(ctx, t.UserId)
+	}
+	return nil
+}
+
+func (uc *mfaUseCase) checkCode(ctx context.Context, t *models.Totp, code string) (bool, error) {
+	if len(code) == totp.Digits {
+		step, ok := totp.Validate(*t.Secret, code, time.Now(), allowedSkew)
+		if !ok {
+			return false, nil
+		}
+		return uc.mfaRepo.UseStep(ctx, t.UserId, step)
+	}
+
+	return uc.mfaRepo.UseRecoveryCode(ctx, t.UserId, uc.hashRecoveryCode(code))
+}
+
+// registerFailedCode records a wrong code and tells the client whether the account got locked by it
+func (uc *mfaUseCase) registerFailedCode(ctx context.Context, userId uuid.UUID) http_errors.RestErr {
+	locked, err := uc.userRepo.RegisterFailedLogin(ctx, userId, util.LoginMaxFailures(uc.cfg), util.LoginLockout(uc.cfg), util.LoginMaxLockout(uc.cfg))
+	if err != nil {
+		return http_errors.ParseErrors(err)
+	}
+
+	if locked > 0 {
+		return http_errors.AccountLocked(locked)
+	}
+	return http_errors.InvalidTotpCode()
+}
+
+func (uc *mfaUseCase) hashRecoveryCode(code string) string {
+	normalized := strings.ToUpper(strings.ReplaceAll(code, "-", ""))
+	mac := hmac.New(sha256.New, []byte(uc.cfg.Server.OtpSecretKey))
+	mac.Write([]byte(normalized))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (uc *mfaUseCase) issuer() string {
+	if uc.cfg.Totp.Issuer == "" {
+		return defaultIssuer
+	}
+	return uc.cfg.Totp.Issuer
+}
+
+func (uc *mfaUseCase) maxAttempts() int {
+	if uc.cfg.Totp.MaxAttempts <= 0 {
+		return defaultMaxAttempts
+	}
+	return uc.cfg.Totp.MaxAttempts
+}
+
+func (uc *mfaUseCase) recoveryCodeCount() int {
+	if uc.cfg.Totp.RecoveryCodeCount <= 0 {
+		return defaultRecoveryCodeCount
+	}
+	return uc.cfg.Totp.RecoveryCodeCount
+}
+
+// generateRecoveryCode returns a 40 bit code like ABCD-EFGH
+func generateRecoveryCode() (string, error) {
+	b := make([]byte, recoveryCodeBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	code := base32.StdEncoding.EncodeToString(b)
+	return code[:4] + "-" + code[4:], nil
+}
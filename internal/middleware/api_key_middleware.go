@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"uzinfocom-todo/internal/apikey"
+	"uzinfocom-todo/internal/models/response_objects"
+	"uzinfocom-todo/internal/session"
+	"uzinfocom-todo/pkg/util"
+)
+
+// AuthApiKeyOrJwtMiddleware authenticates requests carrying an X-API-Key header by that key
+// and falls back to AuthJwtMiddleware otherwise. Both put the same models.User into the context.
+func AuthApiKeyOrJwtMiddleware(keySet *util.KeySet, sessionUC session.UseCase, apiKeyUC apikey.UseCase) func(http.Handler) http.Handler {
+	jwtMiddleware := AuthJwtMiddleware(keySet, sessionUC)
+
+	return func(next http.Handler) http.Handler {
+		jwtNext := jwtMiddleware(next)
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var responseObject *response_objects.ResponseObject
+			rawKey := r.Header.Get("X-API-Key")
+
+			if rawKey == "" {
+				jwtNext.ServeHTTP(w, r)
+				return
+			}
+
+			u, apiKey, restErr := apiKeyUC.Authenticate(r.Context(), rawKey)
+
+			if restErr != nil {
+				w.WriteHeader(restErr.Status())
+				responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+				jsonResponse, _ := json.Marshal(responseObject)
+				w.Write(jsonResponse)
+				return
+			}
+
+			ctx := withUser(r.Context(), *u)
+			ctx = context.WithValue(ctx, "scopes", apiKey.Scopes)
+			r = r.WithContext(ctx)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -3,68 +3,87 @@ package middleware
 import (
 	"context"
 	"encoding/json"
-	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 	"net/http"
 	"strings"
 	"uzinfocom-todo/internal/models"
 	"uzinfocom-todo/internal/models/response_objects"
+	"uzinfocom-todo/internal/session"
+	"uzinfocom-todo/pkg/http_errors"
+	"uzinfocom-todo/pkg/util"
 )
 
-func AuthJwtMiddleware(secretKey string) func(http.Handler) http.Handler {
+// AuthJwtMiddleware authenticates requests by their bearer access token. The user comes from the session
+// lookup with the time zone and locale of their profile, which the Timezone middleware and the translations rely on.
+func AuthJwtMiddleware(keySet *util.KeySet, sessionUC session.UseCase) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			var responseObject *response_objects.ResponseObject
 			tokenString := r.Header.Get("Authorization")
 
-			if tokenString == "" {
-				w.WriteHeader(http.StatusUnauthorized)
-				responseObject = response_objects.NewResponseObject(false, "invalid token", nil)
+			if !strings.HasPrefix(tokenString, "Bearer ") {
+				restErr := http_errors.InvalidToken()
+				w.WriteHeader(restErr.Status())
+				responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
 				jsonResponse, _ := json.Marshal(responseObject)
 				w.Write(jsonResponse)
 				return
 			}
 
-			splitToken := strings.Split(tokenString, "Bearer ")
-			reqToken := splitToken[1]
+			reqToken := strings.TrimPrefix(tokenString, "Bearer ")
 
-			token, err := jwt.Parse(reqToken, func(token *jwt.Token) (interface{}, error) {
-				return []byte(secretKey), nil
-			})
+			claims, err := util.ParseToken(reqToken, util.AccessTokenType, keySet)
 
 			if err != nil {
-				w.WriteHeader(http.StatusUnauthorized)
-				responseObject = response_objects.NewResponseObject(false, "invalid token", nil)
+				restErr := http_errors.InvalidToken()
+				w.WriteHeader(restErr.Status())
+				responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
 				jsonResponse, _ := json.Marshal(responseObject)
 				w.Write(jsonResponse)
 				return
 			}
 
-			if !token.Valid {
-				w.WriteHeader(http.StatusUnauthorized)
-				responseObject = response_objects.NewResponseObject(false, "invalid token", nil)
+			sessionId, err := uuid.Parse(claims.SessionId)
+
+			if err != nil {
+				restErr := http_errors.InvalidToken()
+				w.WriteHeader(restErr.Status())
+				responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
 				jsonResponse, _ := json.Marshal(responseObject)
 				w.Write(jsonResponse)
 				return
 			}
 
-			claims, ok := token.Claims.(jwt.MapClaims)
-			if !ok {
-				w.WriteHeader(http.StatusUnauthorized)
-				responseObject = response_objects.NewResponseObject(false, "invalid token", nil)
+			sessionUser, active, restErr := sessionUC.GetActiveUser(r.Context(), sessionId)
+
+			if restErr != nil {
+				w.WriteHeader(restErr.Status())
+				responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
 				jsonResponse, _ := json.Marshal(responseObject)
 				w.Write(jsonResponse)
 				return
 			}
-			userId, _ := uuid.Parse(claims["user_id"].(string))
-			name, _ := claims["name"].(string)
-			phoneNumber, _ := claims["phone_number"].(string)
-			user := models.User{
-				UserId:      userId,
-				Name:        name,
-				PhoneNumber: phoneNumber,
+
+			if !active {
+				restErr = http_errors.SessionRevoked()
+				w.WriteHeader(restErr.Status())
+				responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+				jsonResponse, _ := json.Marshal(responseObject)
+				w.Write(jsonResponse)
+				return
+			}
+
+			u := models.User{
+				UserId:      sessionUser.UserId,
+				Name:        sessionUser.Name,
+				PhoneNumber: sessionUser.PhoneNumber,
+				Role:        sessionUser.Role,
+				Timezone:    sessionUser.Timezone,
+				Locale:      sessionUser.Locale,
 			}
-			ctx := context.WithValue(r.Context(), "user", user)
+			ctx := withUser(r.Context(), u)
+			ctx = context.WithValue(ctx, "session_id", sessionId)
+			ctx = context.WithValue(ctx, "scopes", claims.Scopes)
 			r = r.WithContext(ctx)
 			next.ServeHTTP(w, r)
 		})
@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"net/http"
+)
+
+const defaultMaxBodyBytes = 1 << 20
+
+// MaxBodySize makes reading more than limit bytes of a request body fail, so neither the middlewares
+// that peek into the body nor the handlers read an unbounded one. A limit of zero uses 1 MiB.
+func MaxBodySize(limit int64) func(http.Handler) http.Handler {
+	if limit <= 0 {
+		limit = defaultMaxBodyBytes
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Body != nil {
+				r.Body = http.MaxBytesReader(w, r.Body, limit)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
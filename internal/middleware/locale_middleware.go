@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"uzinfocom-todo/internal/models"
+	"uzinfocom-todo/pkg/i18n"
+)
+
+// Locale puts the best supported language of the Accept-Language header into the context as "locale".
+// It is mounted once in front of every route, the auth middlewares fall back to the locale of the
+// user's profile when the header has none.
+func Locale() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Vary", "Accept-Language")
+
+			if locale := i18n.Negotiate(r.Header.Get("Accept-Language")); locale != "" {
+				ctx := context.WithValue(r.Context(), "locale", locale)
+				r = r.WithContext(ctx)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// withUser puts the authenticated user into the context as "user", together with the locale of
+// their profile when the request did not ask for one
+func withUser(ctx context.Context, u models.User) context.Context {
+	ctx = context.WithValue(ctx, "user", u)
+
+	if _, ok := ctx.Value("locale").(string); !ok && u.Locale != "" {
+		ctx = context.WithValue(ctx, "locale", u.Locale)
+	}
+	return ctx
+}
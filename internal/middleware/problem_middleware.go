@@ -0,0 +1,122 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strings"
+	"uzinfocom-todo/internal/models/response_objects"
+	"uzinfocom-todo/pkg/http_errors"
+	"uzinfocom-todo/pkg/validator"
+)
+
+// ProblemContentType is the media type of RFC 7807 problem details
+const ProblemContentType = "application/problem+json"
+
+// problem is an RFC 7807 problem details object, code, errors and data are extension members
+type problem struct {
+	Type     string                 `json:"type"`
+	Title    string                 `json:"title"`
+	Status   int                    `json:"status"`
+	Detail   string                 `json:"detail,omitempty"`
+	Instance string                 `json:"instance,omitempty"`
+	Code     string                 `json:"code"`
+	Errors   []validator.FieldError `json:"errors,omitempty"`
+	Data     interface{}            `json:"data,omitempty"`
+}
+
+// ProblemDetails answers failed requests with application/problem+json instead of a ResponseObject
+// when the client asks for it in its Accept header. Successful responses are not changed.
+func ProblemDetails() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Add("Vary", "Accept")
+
+			if !acceptsProblem(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			pw := &problemWriter{ResponseWriter: w}
+			next.ServeHTTP(pw, r)
+			pw.flush(r)
+		})
+	}
+}
+
+func acceptsProblem(r *http.Request) bool {
+	for _, value := range r.Header.Values("Accept") {
+		for _, mediaRange := range strings.Split(value, ",") {
+			mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(mediaRange))
+			if err == nil && mediaType == ProblemContentType {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// problemWriter holds back the body of an error response until flush rewrites it
+type problemWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *problemWriter) WriteHeader(status int) {
+	if w.status != 0 {
+		return
+	}
+
+	w.status = status
+	if status < http.StatusBadRequest {
+		w.ResponseWriter.WriteHeader(status)
+	}
+}
+
+func (w *problemWriter) Write(b []byte) (int, error) {
+	if w.status == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if w.status < http.StatusBadRequest {
+		return w.ResponseWriter.Write(b)
+	}
+	return w.body.Write(b)
+}
+
+func (w *problemWriter) flush(r *http.Request) {
+	if w.status < http.StatusBadRequest {
+		return
+	}
+
+	var responseObject response_objects.ResponseObject
+
+	if err := json.Unmarshal(w.body.Bytes(), &responseObject); err != nil || responseObject.Status {
+		w.ResponseWriter.WriteHeader(w.status)
+		w.ResponseWriter.Write(w.body.Bytes())
+		return
+	}
+
+	code := responseObject.Code
+	if code == "" {
+		code = http_errors.StatusCode(w.status)
+	}
+
+	jsonResponse, _ := json.Marshal(problem{
+		Type:     "about:blank",
+		Title:    http.StatusText(w.status),
+		Status:   w.status,
+		Detail:   http_errors.TrimStatus(w.status, responseObject.Message),
+		Instance: r.URL.Path,
+		Code:     code,
+		Errors:   responseObject.Errors,
+		Data:     responseObject.Data,
+	})
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.Header().Del("Content-Length")
+	w.ResponseWriter.WriteHeader(w.status)
+	w.ResponseWriter.Write(jsonResponse)
+}
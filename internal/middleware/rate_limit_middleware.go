@@ -0,0 +1,112 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"uzinfocom-todo/internal/models"
+	"uzinfocom-todo/internal/models/response_objects"
+	"uzinfocom-todo/pkg/http_errors"
+	"uzinfocom-todo/pkg/ratelimit"
+	"uzinfocom-todo/pkg/util"
+)
+
+// maxPeekBodyBytes is how much of the body RateLimitKeyByPhoneNumber reads, the requests it is used on are small
+const maxPeekBodyBytes = 64 << 10
+
+// RateLimitKeyFunc picks the bucket a request is counted against. An empty key skips the limit.
+type RateLimitKeyFunc func(r *http.Request) string
+
+// RateLimit answers 429 with Retry-After once the bucket of the request's key is empty.
+// If the store fails the request is let through, an outage of the limiter must not take the api down.
+func RateLimit(store ratelimit.Store, name string, limit ratelimit.Limit, keyFunc RateLimitKeyFunc) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if !limit.Enabled() {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var responseObject *response_objects.ResponseObject
+			key := keyFunc(r)
+
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			result, err := store.Take(r.Context(), ratelimit.Key(name, key), limit)
+
+			if err != nil {
+				log.Printf("ratelimit: %s: %v", name, err)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if !result.Allowed {
+				restErr := http_errors.TooManyRequests(result.RetryAfter)
+				w.Header().Set("Content-Type", "application/json")
+				http_errors.SetRetryAfter(w, restErr)
+				w.WriteHeader(restErr.Status())
+				responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+				jsonResponse, _ := json.Marshal(responseObject)
+				w.Write(jsonResponse)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Chain applies middlewares in the given order, the first one being the outermost
+func Chain(middlewares ...func(http.Handler) http.Handler) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		for i := len(middlewares) - 1; i >= 0; i-- {
+			next = middlewares[i](next)
+		}
+		return next
+	}
+}
+
+func RateLimitKeyByIP(r *http.Request) string {
+	return util.ClientIP(r)
+}
+
+// RateLimitKeyByUser must be mounted after one of the auth middlewares
+func RateLimitKeyByUser(r *http.Request) string {
+	user, ok := r.Context().Value("user").(models.User)
+	if !ok {
+		return ""
+	}
+	return user.UserId.String()
+}
+
+// RateLimitKeyByPhoneNumber reads phone_number from the json body and puts the body back for the handler.
+// Only the first maxPeekBodyBytes are looked at, the handler still reads the whole body.
+func RateLimitKeyByPhoneNumber(r *http.Request) string {
+	if r.Body == nil {
+		return ""
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxPeekBodyBytes))
+	r.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(body), r.Body), r.Body}
+
+	if err != nil {
+		return ""
+	}
+
+	var payload struct {
+		PhoneNumber string `json:"phone_number"`
+	}
+
+	if err = json.Unmarshal(body, &payload); err != nil {
+		return ""
+	}
+	return strings.TrimSpace(payload.PhoneNumber)
+}
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"uzinfocom-todo/internal/models/response_objects"
+	"uzinfocom-todo/pkg/http_errors"
+)
+
+// RequireScopes lets the request through only if the authenticated token or api key carries every given scope.
+// It must be mounted after one of the auth middlewares.
+func RequireScopes(scopes ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var responseObject *response_objects.ResponseObject
+			granted, _ := r.Context().Value("scopes").([]string)
+
+			for _, scope := range scopes {
+				if !containsScope(granted, scope) {
+					restErr := http_errors.InsufficientScope()
+					w.Header().Set("Content-Type", "application/json")
+					w.WriteHeader(restErr.Status())
+					responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+					jsonResponse, _ := json.Marshal(responseObject)
+					w.Write(jsonResponse)
+					return
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func containsScope(granted []string, scope string) bool {
+	for _, g := range granted {
+		if g == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"uzinfocom-todo/internal/models"
+	"uzinfocom-todo/internal/models/response_objects"
+	"uzinfocom-todo/pkg/http_errors"
+)
+
+// TimezoneHeader lets a client read and write task times in another zone than the one of its profile
+const TimezoneHeader = "X-Timezone"
+
+// TimeFormatHeader lets a client opt into RFC 3339 times in responses
+const TimeFormatHeader = "X-Time-Format"
+
+// Timezone puts the tz parameter, the X-Timezone header or the zone of the user's profile into the context
+// as "location", and the time_format parameter or the X-Time-Format header as "time_format".
+// It must be mounted after one of the auth middlewares.
+func Timezone() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var responseObject *response_objects.ResponseObject
+			name := r.URL.Query().Get("tz")
+
+			if name == "" {
+				name = r.Header.Get(TimezoneHeader)
+			}
+
+			if name == "" {
+				u, _ := r.Context().Value("user").(models.User)
+				name = u.Timezone
+			}
+
+			loc, err := models.LoadTimezone(name)
+
+			if err != nil {
+				restErr := http_errors.InvalidTimezone()
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(restErr.Status())
+				responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+				jsonResponse, _ := json.Marshal(responseObject)
+				w.Write(jsonResponse)
+				return
+			}
+
+			format := r.URL.Query().Get("time_format")
+
+			if format == "" {
+				format = r.Header.Get(TimeFormatHeader)
+			}
+
+			if format != "" && format != models.TimeFormatRFC3339 {
+				restErr := http_errors.InvalidValue("time_format", models.TimeFormatRFC3339)
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(restErr.Status())
+				responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+				jsonResponse, _ := json.Marshal(responseObject)
+				w.Write(jsonResponse)
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), "location", loc)
+			ctx = context.WithValue(ctx, "time_format", format)
+			r = r.WithContext(ctx)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
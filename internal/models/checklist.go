@@ -0,0 +1,17 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// ChecklistItem is a step of a task. Items are soft deleted together with their task.
+type ChecklistItem struct {
+	ItemId    uuid.UUID   `json:"item_id"`
+	TaskId    uuid.UUID   `json:"task_id"`
+	Title     string      `json:"title"`
+	IsDone    bool        `json:"is_done"`
+	Position  int         `json:"position"`
+	CreatedAt time.Time   `json:"created_at"`
+	DeletedAt interface{} `json:"deleted_at"`
+}
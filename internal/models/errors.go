@@ -0,0 +1,10 @@
+package models
+
+import "errors"
+
+// Errors the repositories return for violated constraints, so the use cases do not depend on postgres
+var (
+	ErrPhoneTaken = errors.New("phone number is already taken")
+	ErrListExists = errors.New("list with given name already exists")
+	ErrTagExists  = errors.New("tag with given name already exists")
+)
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+const InboxListName = "Inbox"
+
+type List struct {
+	ListId    uuid.UUID `json:"list_id"`
+	UserId    uuid.UUID `json:"user_id"`
+	Name      string    `json:"name"`
+	Colour    *string   `json:"colour"`
+	Archived  bool      `json:"archived"`
+	Position  int       `json:"position"`
+	IsInbox   bool      `json:"is_inbox"`
+	CreatedAt time.Time `json:"created_at"`
+}
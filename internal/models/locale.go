@@ -0,0 +1,32 @@
+package models
+
+import "context"
+
+const (
+	LocaleEnglish       = "en"
+	LocaleRussian       = "ru"
+	LocaleUzbekLatin    = "uz-Latn"
+	LocaleUzbekCyrillic = "uz-Cyrl"
+
+	DefaultLocale   = LocaleEnglish
+	DefaultTimezone = "UTC"
+)
+
+var SupportedLocales = []string{LocaleEnglish, LocaleRussian, LocaleUzbekLatin, LocaleUzbekCyrillic}
+
+func IsSupportedLocale(locale string) bool {
+	for _, l := range SupportedLocales {
+		if l == locale {
+			return true
+		}
+	}
+	return false
+}
+
+// LocaleFromContext returns the locale put into the context by the locale middleware, DefaultLocale if there is none
+func LocaleFromContext(ctx context.Context) string {
+	if locale, ok := ctx.Value("locale").(string); ok && locale != "" {
+		return locale
+	}
+	return DefaultLocale
+}
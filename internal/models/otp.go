@@ -0,0 +1,27 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type OtpCode struct {
+	OtpId       uuid.UUID  `json:"otp_id"`
+	PhoneNumber string     `json:"phone_number"`
+	CodeHash    string     `json:"-"`
+	Attempts    int        `json:"attempts"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	UsedAt      *time.Time `json:"used_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+type PhoneChangeCode struct {
+	ChangeId       uuid.UUID  `json:"change_id"`
+	UserId         uuid.UUID  `json:"user_id"`
+	NewPhoneNumber string     `json:"new_phone_number"`
+	CodeHash       string     `json:"-"`
+	Attempts       int        `json:"attempts"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	UsedAt         *time.Time `json:"used_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Reminder fires either MinutesBefore the start of its task or at the absolute time RemindAt, exactly
+// one of them is set. A relative reminder follows its task when the task is rescheduled, for a
+// recurring task it fires before every occurrence and OccurrenceStart is the one it fires for next.
+type Reminder struct {
+	ReminderId      uuid.UUID  `json:"reminder_id"`
+	TaskId          uuid.UUID  `json:"task_id"`
+	UserId          uuid.UUID  `json:"user_id"`
+	MinutesBefore   *int       `json:"minutes_before"`
+	RemindAt        *time.Time `json:"remind_at"`
+	OccurrenceStart *time.Time `json:"occurrence_start"`
+	SentAt          *time.Time `json:"sent_at"`
+	Attempts        int        `json:"attempts"`
+	LastError       *string    `json:"last_error"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+// DueReminder is a claimed reminder together with what is needed to deliver it. Occurrence is the start
+// of the occurrence it fires for as the series produces it, the task fields describe that occurrence
+// with its override applied. For a one-off task they are the ones of the task.
+type DueReminder struct {
+	Reminder
+	FireAt         time.Time
+	Occurrence     time.Time
+	TaskTitle      string
+	TaskStart      time.Time
+	TaskEnd        time.Time
+	TaskStatus     string
+	SeriesStart    time.Time
+	RecurrenceRule *string
+	TaskTimezone   string
+	Name           string
+	Phone          string
+	Email          *string
+	Locale         string
+	Timezone       string
+}
@@ -0,0 +1,7 @@
+package request_objects
+
+type RequestApiKey struct {
+	Name          string   `json:"name"`
+	Scopes        []string `json:"scopes"`
+	ExpiresInDays int      `json:"expires_in_days"`
+}
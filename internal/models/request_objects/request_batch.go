@@ -0,0 +1,17 @@
+package request_objects
+
+type RequestTaskBatch struct {
+	Mode       string                 `json:"mode"`
+	Operations []RequestTaskOperation `json:"operations"`
+}
+
+// RequestTaskOperation carries the fields of RequestTask and RequestTaskForUpdate plus the operation and its target
+type RequestTaskOperation struct {
+	Op          string `json:"op"`
+	TaskId      string `json:"task_id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	StartTime   string `json:"start_time"`
+	EndTime     string `json:"end_time"`
+	IsDone      bool   `json:"isDone"`
+}
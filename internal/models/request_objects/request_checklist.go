@@ -0,0 +1,8 @@
+package request_objects
+
+// RequestChecklistItem is used for both creating and updating an item, on update only the present fields change
+type RequestChecklistItem struct {
+	Title    *string `json:"title"`
+	IsDone   *bool   `json:"is_done"`
+	Position *int    `json:"position"`
+}
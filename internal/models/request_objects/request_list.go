@@ -0,0 +1,16 @@
+package request_objects
+
+import "github.com/google/uuid"
+
+// RequestList is used for both creating and updating a list, on update only the present fields change
+type RequestList struct {
+	Name     *string `json:"name"`
+	Colour   *string `json:"colour"`
+	Archived *bool   `json:"archived"`
+	Position *int    `json:"position"`
+}
+
+// RequestMoveTask moves a task to another list, a null list_id moves it to the inbox
+type RequestMoveTask struct {
+	ListId *uuid.UUID `json:"list_id"`
+}
@@ -0,0 +1,14 @@
+package request_objects
+
+type RequestOtp struct {
+	PhoneNumber string `json:"phone_number"`
+}
+
+type RequestOtpVerify struct {
+	PhoneNumber string `json:"phone_number"`
+	Code        string `json:"code"`
+}
+
+type RequestPhoneChangeVerify struct {
+	Code string `json:"code"`
+}
@@ -0,0 +1,7 @@
+package request_objects
+
+// RequestReminder sets either minutes_before the start of the task or an absolute remind_at time
+type RequestReminder struct {
+	MinutesBefore *int   `json:"minutes_before"`
+	RemindAt      string `json:"remind_at"`
+}
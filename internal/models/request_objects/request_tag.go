@@ -0,0 +1,5 @@
+package request_objects
+
+type RequestTag struct {
+	Name string `json:"name"`
+}
@@ -1,15 +1,109 @@
 package request_objects
 
+import (
+	"github.com/google/uuid"
+	"time"
+	"uzinfocom-todo/internal/models"
+	"uzinfocom-todo/pkg/http_errors"
+	"uzinfocom-todo/pkg/message"
+	"uzinfocom-todo/pkg/validator"
+)
+
+// limits of the columns of the tasks table
+const (
+	maxTitleLength          = 64
+	maxDescriptionLength    = 255
+	maxRecurrenceRuleLength = 255
+)
+
 type RequestTask struct {
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	StartTime   string `json:"start_time"`
-	EndTime     string `json:"end_time"`
+	Title        string     `json:"title"`
+	Description  string     `json:"description"`
+	StartTime    string     `json:"start_time"`
+	EndTime      string     `json:"end_time"`
+	ListId       *uuid.UUID `json:"list_id"`
+	AutoComplete bool       `json:"auto_complete"`
+	Priority     string     `json:"priority"`
+	// RecurrenceRule is an RRULE such as FREQ=WEEKLY;BYDAY=MO,WE, empty for a one-off task
+	RecurrenceRule string `json:"recurrence_rule"`
 }
+
+func (r RequestTask) Validate(loc *time.Location) http_errors.RestErr {
+	v := validator.New()
+
+	if v.Required("title", r.Title) {
+		v.MaxLength("title", r.Title, maxTitleLength)
+	}
+	v.MaxLength("description", r.Description, maxDescriptionLength)
+	v.MaxLength("recurrence_rule", r.RecurrenceRule, maxRecurrenceRuleLength)
+
+	hasStart := v.Required("start_time", r.StartTime)
+	hasEnd := v.Required("end_time", r.EndTime)
+
+	if hasStart && hasEnd {
+		validateTimeRange(v, r.StartTime, r.EndTime, loc)
+	}
+	return http_errors.ValidationFailed(v)
+}
+
 type RequestTaskForUpdate struct {
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	StartTime   string `json:"start_time"`
-	EndTime     string `json:"end_time"`
-	IsDone      bool   `json:"isDone"`
+	Title        string `json:"title"`
+	Description  string `json:"description"`
+	StartTime    string `json:"start_time"`
+	EndTime      string `json:"end_time"`
+	IsDone       bool   `json:"isDone"`
+	AutoComplete *bool  `json:"auto_complete"`
+	Status       string `json:"status"`
+	Priority     string `json:"priority"`
+	// RecurrenceRule replaces the rule of the series, an empty string makes the task a one-off one
+	RecurrenceRule *string `json:"recurrence_rule"`
+}
+
+// RequestTaskOccurrence changes a single occurrence of a recurring task, omitted fields are left as they are
+type RequestTaskOccurrence struct {
+	Title       *string `json:"title"`
+	Description *string `json:"description"`
+	StartTime   string  `json:"start_time"`
+	EndTime     string  `json:"end_time"`
+	Status      string  `json:"status"`
+	IsDone      bool    `json:"isDone"`
+}
+
+// RequestTaskRank moves a task within its status column, right after after_id or to the top when it is null
+type RequestTaskRank struct {
+	AfterId *uuid.UUID `json:"after_id"`
+}
+
+// Validate checks the fields that are changed, start_time and end_time are only changed together
+func (r RequestTaskForUpdate) Validate(loc *time.Location) http_errors.RestErr {
+	v := validator.New()
+
+	v.MaxLength("title", r.Title, maxTitleLength)
+	v.MaxLength("description", r.Description, maxDescriptionLength)
+
+	if r.RecurrenceRule != nil {
+		v.MaxLength("recurrence_rule", *r.RecurrenceRule, maxRecurrenceRuleLength)
+	}
+
+	switch {
+	case r.StartTime != "" && r.EndTime != "":
+		validateTimeRange(v, r.StartTime, r.EndTime, loc)
+	case r.StartTime != "":
+		v.Add("end_time", validator.CodeRequired, validator.MessageRequiredWith, message.Params{"other": "start_time"})
+	case r.EndTime != "":
+		v.Add("start_time", validator.CodeRequired, validator.MessageRequiredWith, message.Params{"other": "end_time"})
+	}
+	return http_errors.ValidationFailed(v)
+}
+
+func validateTimeRange(v *validator.Validator, start string, end string, loc *time.Location) {
+	startTime, startErr := models.ParseTime(start, loc)
+	v.Check(startErr == nil, "start_time", validator.CodeInvalidFormat, validator.MessageTime, nil)
+
+	endTime, endErr := models.ParseTime(end, loc)
+	v.Check(endErr == nil, "end_time", validator.CodeInvalidFormat, validator.MessageTime, nil)
+
+	if startErr == nil && endErr == nil {
+		v.Check(endTime.After(startTime), "end_time", validator.CodeInvalidRange, validator.MessageAfter, message.Params{"other": "start_time"})
+	}
 }
@@ -0,0 +1,5 @@
+package request_objects
+
+type RequestRefreshToken struct {
+	RefreshToken string `json:"refresh_token"`
+}
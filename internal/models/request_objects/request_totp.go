@@ -0,0 +1,10 @@
+package request_objects
+
+type RequestTotpCode struct {
+	Code string `json:"code"`
+}
+
+type RequestTwoFactorLogin struct {
+	ChallengeToken string `json:"challenge_token"`
+	Code           string `json:"code"`
+}
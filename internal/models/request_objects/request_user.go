@@ -0,0 +1,18 @@
+package request_objects
+
+type RequestChangePassword struct {
+	OldPassword string `json:"old_password"` // not needed while the account has no password
+	NewPassword string `json:"new_password"`
+}
+
+// RequestUpdateProfile only changes the fields that are present in the body
+type RequestUpdateProfile struct {
+	Name     *string `json:"name"`
+	Timezone *string `json:"timezone"`
+	Locale   *string `json:"locale"`
+	Email    *string `json:"email"` // an empty string removes the address
+}
+
+type RequestDeleteAccount struct {
+	Password string `json:"password"`
+}
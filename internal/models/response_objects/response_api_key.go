@@ -0,0 +1,16 @@
+package response_objects
+
+import (
+	"github.com/google/uuid"
+)
+
+type ResponseApiKey struct {
+	ApiKeyId   uuid.UUID `json:"api_key_id"`
+	Name       string    `json:"name"`
+	Prefix     string    `json:"prefix"`
+	Scopes     []string  `json:"scopes"`
+	ExpiresAt  *string   `json:"expires_at"`
+	LastUsedAt *string   `json:"last_used_at"`
+	CreatedAt  string    `json:"created_at"`
+	Key        string    `json:"key,omitempty"`
+}
@@ -0,0 +1,41 @@
+package response_objects
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"uzinfocom-todo/internal/models"
+	"uzinfocom-todo/pkg/http_errors"
+	"uzinfocom-todo/pkg/i18n"
+	"uzinfocom-todo/pkg/validator"
+)
+
+type ResponseTaskBatch struct {
+	Mode      string                  `json:"mode"`
+	Committed bool                    `json:"committed"`
+	Results   []ResponseTaskOperation `json:"results"`
+}
+
+type ResponseTaskOperation struct {
+	Index   int        `json:"index"`
+	Op      string     `json:"op"`
+	TaskId  *uuid.UUID `json:"task_id,omitempty"`
+	Success bool       `json:"success"`
+	Status  int        `json:"status"`
+	Error   string     `json:"error,omitempty"`
+	Code    string     `json:"code,omitempty"`
+	// Errors lists the rejected fields when the operation failed validation
+	Errors []validator.FieldError `json:"errors,omitempty"`
+}
+
+func (o *ResponseTaskOperation) SetError(ctx context.Context, restErr http_errors.RestErr) {
+	locale := models.LocaleFromContext(ctx)
+	detail := i18n.Translate(locale, restErr.Code(), restErr.Detail(), restErr.Params())
+
+	o.Status = restErr.Status()
+	o.Error = http_errors.NewCodedRestError(restErr.Status(), restErr.Code(), detail).Error()
+	o.Code = restErr.Code()
+
+	if validationErr, ok := restErr.(http_errors.ValidationErr); ok {
+		o.Errors = i18n.TranslateFieldErrors(locale, validationErr.FieldErrors())
+	}
+}
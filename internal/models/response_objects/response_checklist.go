@@ -0,0 +1,28 @@
+package response_objects
+
+import (
+	"github.com/google/uuid"
+	"uzinfocom-todo/internal/models"
+)
+
+type ResponseChecklistItem struct {
+	ItemId   uuid.UUID `json:"item_id"`
+	Title    string    `json:"title"`
+	IsDone   bool      `json:"is_done"`
+	Position int       `json:"position"`
+}
+
+// ResponseChecklistUpdate tells whether the change completed the task through its auto_complete rule
+type ResponseChecklistUpdate struct {
+	Item          *ResponseChecklistItem `json:"item,omitempty"`
+	TaskCompleted bool                   `json:"task_completed"`
+}
+
+func NewResponseChecklistItem(i models.ChecklistItem) ResponseChecklistItem {
+	return ResponseChecklistItem{
+		ItemId:   i.ItemId,
+		Title:    i.Title,
+		IsDone:   i.IsDone,
+		Position: i.Position,
+	}
+}
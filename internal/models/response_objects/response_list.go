@@ -0,0 +1,26 @@
+package response_objects
+
+import (
+	"github.com/google/uuid"
+	"uzinfocom-todo/internal/models"
+)
+
+type ResponseList struct {
+	ListId   uuid.UUID `json:"list_id"`
+	Name     string    `json:"name"`
+	Colour   *string   `json:"colour"`
+	Archived bool      `json:"archived"`
+	Position int       `json:"position"`
+	IsInbox  bool      `json:"is_inbox"`
+}
+
+func NewResponseList(l models.List) ResponseList {
+	return ResponseList{
+		ListId:   l.ListId,
+		Name:     l.Name,
+		Colour:   l.Colour,
+		Archived: l.Archived,
+		Position: l.Position,
+		IsInbox:  l.IsInbox,
+	}
+}
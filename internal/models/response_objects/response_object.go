@@ -1,9 +1,20 @@
 package response_objects
 
+import (
+	"context"
+	"uzinfocom-todo/internal/models"
+	"uzinfocom-todo/pkg/http_errors"
+	"uzinfocom-todo/pkg/i18n"
+	"uzinfocom-todo/pkg/validator"
+)
+
 type ResponseObject struct {
-	Status  bool        `json:"status"`
-	Message string      `json:"message"`
-	Data    interface{} `json:"data,omitempty"`
+	Status     bool                   `json:"status"`
+	Message    string                 `json:"message"`
+	Data       interface{}            `json:"data,omitempty"`
+	NextCursor string                 `json:"next_cursor,omitempty"`
+	Code       string                 `json:"code,omitempty"`
+	Errors     []validator.FieldError `json:"errors,omitempty"`
 }
 
 func NewResponseObject(status bool, message string, data interface{}) *ResponseObject {
@@ -13,3 +24,44 @@ func NewResponseObject(status bool, message string, data interface{}) *ResponseO
 		Data:    data,
 	}
 }
+
+func NewPageResponseObject(status bool, message string, data interface{}, nextCursor string) *ResponseObject {
+	return &ResponseObject{
+		Status:     status,
+		Message:    message,
+		Data:       data,
+		NextCursor: nextCursor,
+	}
+}
+
+// NewErrorResponseObject translates the message of restErr into the locale of the request
+func NewErrorResponseObject(ctx context.Context, restErr http_errors.RestErr) *ResponseObject {
+	locale := models.LocaleFromContext(ctx)
+	detail := i18n.Translate(locale, restErr.Code(), restErr.Detail(), restErr.Params())
+	message := http_errors.NewCodedRestError(restErr.Status(), restErr.Code(), detail).Error()
+
+	responseObject := NewResponseObject(false, message, nil)
+	responseObject.Code = restErr.Code()
+
+	if validationErr, ok := restErr.(http_errors.ValidationErr); ok {
+		responseObject.Errors = i18n.TranslateFieldErrors(locale, validationErr.FieldErrors())
+	}
+	return responseObject
+}
+
+// NewSuccessResponseObject is NewResponseObject for a successful request, its message is the one of
+// messageId in the locale of the request
+func NewSuccessResponseObject(ctx context.Context, messageId string, data interface{}) *ResponseObject {
+	return NewResponseObject(true, successMessage(ctx, messageId), data)
+}
+
+func NewSuccessPageResponseObject(ctx context.Context, messageId string, data interface{}, nextCursor string) *ResponseObject {
+	return NewPageResponseObject(true, successMessage(ctx, messageId), data, nextCursor)
+}
+
+func successMessage(ctx context.Context, messageId string) string {
+	if message, ok := i18n.Message(models.LocaleFromContext(ctx), messageId); ok {
+		return message
+	}
+	return messageId
+}
@@ -0,0 +1,42 @@
+package response_objects
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"uzinfocom-todo/internal/models"
+)
+
+type ResponseReminder struct {
+	ReminderId      uuid.UUID `json:"reminder_id"`
+	TaskId          uuid.UUID `json:"task_id"`
+	MinutesBefore   *int      `json:"minutes_before"`
+	RemindAt        *string   `json:"remind_at"`
+	OccurrenceStart *string   `json:"occurrence_start"`
+	SentAt          *string   `json:"sent_at"`
+	Attempts        int       `json:"attempts"`
+}
+
+func NewResponseReminder(ctx context.Context, r models.Reminder) ResponseReminder {
+	response := ResponseReminder{
+		ReminderId:    r.ReminderId,
+		TaskId:        r.TaskId,
+		MinutesBefore: r.MinutesBefore,
+		Attempts:      r.Attempts,
+	}
+
+	if r.RemindAt != nil {
+		remindAt := models.FormatTime(ctx, *r.RemindAt)
+		response.RemindAt = &remindAt
+	}
+
+	if r.OccurrenceStart != nil {
+		occurrenceStart := models.FormatTime(ctx, *r.OccurrenceStart)
+		response.OccurrenceStart = &occurrenceStart
+	}
+
+	if r.SentAt != nil {
+		sentAt := models.FormatTime(ctx, *r.SentAt)
+		response.SentAt = &sentAt
+	}
+	return response
+}
@@ -0,0 +1,15 @@
+package response_objects
+
+import (
+	"github.com/google/uuid"
+)
+
+type ResponseSession struct {
+	SessionId  uuid.UUID `json:"session_id"`
+	Device     string    `json:"device"`
+	IpAddress  string    `json:"ip_address"`
+	UserAgent  string    `json:"user_agent"`
+	CreatedAt  string    `json:"created_at"`
+	LastSeenAt string    `json:"last_seen_at"`
+	Current    bool      `json:"current"`
+}
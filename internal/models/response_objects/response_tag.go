@@ -0,0 +1,18 @@
+package response_objects
+
+import (
+	"github.com/google/uuid"
+	"uzinfocom-todo/internal/models"
+)
+
+type ResponseTag struct {
+	TagId uuid.UUID `json:"tag_id"`
+	Name  string    `json:"name"`
+}
+
+func NewResponseTag(t models.Tag) ResponseTag {
+	return ResponseTag{
+		TagId: t.TagId,
+		Name:  t.Name,
+	}
+}
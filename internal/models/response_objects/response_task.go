@@ -1,15 +1,82 @@
 package response_objects
 
 import (
+	"context"
 	"github.com/google/uuid"
+	"time"
+	"uzinfocom-todo/internal/models"
 )
 
 type ResponseTask struct {
-	TaskID      uuid.UUID   `json:"task_id"`
-	Title       string      `json:"title"`
-	Description string      `json:"description"`
-	StartTime   string      `json:"start_time"`
-	EndTime     string      `json:"end_time"`
-	IsDone      bool        `json:"is_done"`
-	DeletedAt   interface{} `json:"deleted_at"`
+	TaskID         uuid.UUID     `json:"task_id"`
+	Title          string        `json:"title"`
+	Description    string        `json:"description"`
+	StartTime      string        `json:"start_time"`
+	EndTime        string        `json:"end_time"`
+	IsDone         bool          `json:"is_done"`
+	Status         string        `json:"status"`
+	Priority       string        `json:"priority"`
+	Rank           float64       `json:"rank"`
+	DeletedAt      interface{}   `json:"deleted_at"`
+	ListId         *uuid.UUID    `json:"list_id"`
+	Tags           []ResponseTag `json:"tags"`
+	AutoComplete   bool          `json:"auto_complete"`
+	Progress       *int          `json:"progress"` // percentage of done checklist items, null without a checklist
+	RecurrenceRule *string       `json:"recurrence_rule"`
+	OccurrenceId   string        `json:"occurrence_id,omitempty"`
+	Timezone       string        `json:"timezone"`
+}
+
+func NewResponseTask(ctx context.Context, t models.Task) ResponseTask {
+	tags := make([]ResponseTag, 0, len(t.Tags))
+	for _, tag := range t.Tags {
+		tags = append(tags, NewResponseTag(tag))
+	}
+
+	var progress *int
+	if t.ChecklistTotal > 0 {
+		percent := t.ChecklistDone * 100 / t.ChecklistTotal
+		progress = &percent
+	}
+
+	deletedAt := t.DeletedAt
+	if deleted, ok := t.DeletedAt.(time.Time); ok {
+		deletedAt = models.FormatTime(ctx, deleted)
+	}
+
+	var occurrenceId string
+	if t.Occurrence != nil {
+		occurrenceId = t.Occurrence.UTC().Format(models.OccurrenceIdFormat)
+	}
+
+	return ResponseTask{
+		TaskID:         t.TaskID,
+		Title:          t.Title,
+		Description:    t.Description,
+		StartTime:      models.FormatTime(ctx, t.StartTime),
+		EndTime:        models.FormatTime(ctx, t.EndTime),
+		IsDone:         t.IsDone(),
+		Status:         t.Status,
+		Priority:       t.Priority,
+		Rank:           t.Rank,
+		DeletedAt:      deletedAt,
+		ListId:         t.ListId,
+		Tags:           tags,
+		AutoComplete:   t.AutoComplete,
+		Progress:       progress,
+		RecurrenceRule: t.RecurrenceRule,
+		OccurrenceId:   occurrenceId,
+		Timezone:       t.Timezone,
+	}
+}
+
+type ResponseTaskSearchResult struct {
+	ResponseTask
+	Rank           float64 `json:"rank"`
+	TitleHighlight string  `json:"title_highlight"`
+	Snippet        string  `json:"snippet"`
+}
+
+type ResponsePurged struct {
+	Deleted int64 `json:"deleted"`
 }
@@ -0,0 +1,32 @@
+package response_objects
+
+import (
+	"github.com/google/uuid"
+)
+
+type ResponseUser struct {
+	UserId      uuid.UUID `json:"user_id"`
+	Name        string    `json:"name"`
+	PhoneNumber string    `json:"phone_number"`
+	Role        string    `json:"role"`
+	Disabled    bool      `json:"disabled"`
+}
+
+type ResponseTaskCounts struct {
+	UserId  uuid.UUID `json:"user_id"`
+	Open    int       `json:"open"`
+	Done    int       `json:"done"`
+	Deleted int       `json:"deleted"`
+	Total   int       `json:"total"`
+}
+
+type ResponseProfile struct {
+	UserId           uuid.UUID `json:"user_id"`
+	Name             string    `json:"name"`
+	PhoneNumber      string    `json:"phone_number"`
+	Role             string    `json:"role"`
+	Timezone         string    `json:"timezone"`
+	Locale           string    `json:"locale"`
+	Email            string    `json:"email"`
+	TwoFactorEnabled bool      `json:"two_factor_enabled"`
+}
@@ -0,0 +1,19 @@
+package models
+
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+
+	ScopeTasksRead  = "tasks:read"
+	ScopeTasksWrite = "tasks:write"
+	ScopeAdminUsers = "admin:users"
+)
+
+func ScopesForRole(role string) []string {
+	switch role {
+	case RoleAdmin:
+		return []string{ScopeTasksRead, ScopeTasksWrite, ScopeAdminUsers}
+	default:
+		return []string{ScopeTasksRead, ScopeTasksWrite}
+	}
+}
@@ -0,0 +1,17 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type Session struct {
+	SessionId  uuid.UUID  `json:"session_id"`
+	UserId     uuid.UUID  `json:"user_id"`
+	Device     string     `json:"device"`
+	IpAddress  string     `json:"ip_address"`
+	UserAgent  string     `json:"user_agent"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastSeenAt time.Time  `json:"last_seen_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+}
@@ -0,0 +1,18 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	TagModeAny = "any"
+	TagModeAll = "all"
+)
+
+type Tag struct {
+	TagId     uuid.UUID `json:"tag_id"`
+	UserId    uuid.UUID `json:"user_id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
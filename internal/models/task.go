@@ -6,12 +6,110 @@ import (
 )
 
 type Task struct {
-	TaskID      uuid.UUID   `json:"task_id"`
-	Title       string      `json:"title"`
-	Description string      `json:"description"`
-	StartTime   time.Time   `json:"start_time"`
-	EndTime     time.Time   `json:"end_time"`
-	IsDone      bool        `json:"is_done"`
-	DeletedAt   interface{} `json:"deleted_at"`
-	UserId      uuid.UUID   `json:"user_id"`
+	TaskID         uuid.UUID        `json:"task_id"`
+	Title          string           `json:"title"`
+	Description    string           `json:"description"`
+	StartTime      time.Time        `json:"start_time"`
+	EndTime        time.Time        `json:"end_time"`
+	Status         string           `json:"status"`
+	Priority       string           `json:"priority"`
+	Rank           float64          `json:"rank"`
+	DeletedAt      interface{}      `json:"deleted_at"`
+	UserId         uuid.UUID        `json:"user_id"`
+	ListId         *uuid.UUID       `json:"list_id"`
+	Tags           []Tag            `json:"tags"`
+	AutoComplete   bool             `json:"auto_complete"`
+	ChecklistTotal int              `json:"checklist_total"`
+	ChecklistDone  int              `json:"checklist_done"`
+	RecurrenceRule *string          `json:"recurrence_rule"`
+	Overrides      []TaskOccurrence `json:"overrides"`
+	Occurrence     *time.Time       `json:"occurrence"`
+	Timezone       string           `json:"timezone"` // IANA zone the occurrences of a recurring task are laid out in
+}
+
+// TaskOccurrence holds the changes made to a single occurrence of a recurring task, which is identified
+// by the start the recurrence rule gives it. Nil fields keep the value of the series.
+type TaskOccurrence struct {
+	TaskId          uuid.UUID  `json:"task_id"`
+	OccurrenceStart time.Time  `json:"occurrence_start"`
+	Title           *string    `json:"title"`
+	Description     *string    `json:"description"`
+	StartTime       *time.Time `json:"start_time"`
+	EndTime         *time.Time `json:"end_time"`
+	Status          *string    `json:"status"`
+}
+
+// OccurrenceIdFormat identifies an occurrence by its start in UTC in the RFC 5545 basic format
+const OccurrenceIdFormat = "20060102T150405Z"
+
+func (t Task) Location() *time.Location {
+	loc, err := LoadTimezone(t.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// Workflow statuses of a task
+const (
+	TaskStatusTodo       = "todo"
+	TaskStatusInProgress = "in_progress"
+	TaskStatusBlocked    = "blocked"
+	TaskStatusDone       = "done"
+	TaskStatusCancelled  = "cancelled"
+)
+
+// Listing filters on top of the workflow statuses. Open means todo, in_progress or blocked.
+const (
+	TaskStatusOpen    = "open"
+	TaskStatusDeleted = "deleted"
+	TaskStatusAll     = "all"
+)
+
+const (
+	TaskPriorityLow    = "low"
+	TaskPriorityMedium = "medium"
+	TaskPriorityHigh   = "high"
+	TaskPriorityUrgent = "urgent"
+)
+
+// TaskRankStep is the gap left between neighbouring ranks of a status column
+const TaskRankStep = 1024
+
+// IsDone is kept for clients that only know the old boolean
+func (t Task) IsDone() bool {
+	return t.Status == TaskStatusDone
+}
+
+// IsOpen reports whether the task still occupies its time range
+func (t Task) IsOpen() bool {
+	return IsOpenTaskStatus(t.Status)
+}
+
+func IsOpenTaskStatus(status string) bool {
+	switch status {
+	case TaskStatusTodo, TaskStatusInProgress, TaskStatusBlocked:
+		return true
+	}
+	return false
+}
+
+func IsWorkflowTaskStatus(status string) bool {
+	return IsOpenTaskStatus(status) || status == TaskStatusDone || status == TaskStatusCancelled
+}
+
+func IsValidTaskStatus(status string) bool {
+	switch status {
+	case TaskStatusOpen, TaskStatusDeleted, TaskStatusAll:
+		return true
+	}
+	return IsWorkflowTaskStatus(status)
+}
+
+func IsValidTaskPriority(priority string) bool {
+	switch priority {
+	case TaskPriorityLow, TaskPriorityMedium, TaskPriorityHigh, TaskPriorityUrgent:
+		return true
+	}
+	return false
 }
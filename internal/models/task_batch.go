@@ -0,0 +1,13 @@
+package models
+
+const (
+	BatchModeAllOrNothing = "all_or_nothing"
+	BatchModeBestEffort   = "best_effort"
+
+	TaskOpCreate   = "create"
+	TaskOpUpdate   = "update"
+	TaskOpDelete   = "delete"
+	TaskOpComplete = "complete"
+
+	MaxBatchOperations = 100
+)
@@ -0,0 +1,105 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"strconv"
+	"time"
+)
+
+const (
+	TaskSortStartTime = "start_time"
+	TaskSortEndTime   = "end_time"
+	TaskSortTitle     = "title"
+	TaskSortRank      = "rank"
+
+	SortAsc  = "asc"
+	SortDesc = "desc"
+)
+
+var ErrInvalidCursor = errors.New("cursor is invalid")
+
+// TaskFilter narrows down a task listing. A zero Limit returns every matching task.
+// Tags holds lower-cased tag names, TagMode tells whether a task needs any or all of them.
+// Recurring keeps only recurring (true) or one-off (false) tasks. Expand lists the occurrences of
+// recurring tasks between From and To instead of the tasks themselves.
+type TaskFilter struct {
+	Status    string
+	ListId    *uuid.UUID
+	Tags      []string
+	TagMode   string
+	Recurring *bool
+	Expand    bool
+	From      *time.Time
+	To        *time.Time
+	Query     string
+	Sort      string
+	Order     string
+	Limit     int
+	Cursor    *TaskCursor
+}
+
+// TaskCursor points right after the last task of a page. Sort and Order are kept so a cursor
+// cannot be replayed against a listing with a different ordering.
+type TaskCursor struct {
+	Sort   string    `json:"s"`
+	Order  string    `json:"o"`
+	Value  string    `json:"v"`
+	TaskId uuid.UUID `json:"id"`
+}
+
+func IsValidTaskSort(sort string) bool {
+	switch sort {
+	case TaskSortStartTime, TaskSortEndTime, TaskSortTitle, TaskSortRank:
+		return true
+	}
+	return false
+}
+
+func NewTaskCursor(t Task, sort string, order string) TaskCursor {
+	cursor := TaskCursor{Sort: sort, Order: order, TaskId: t.TaskID}
+
+	switch sort {
+	case TaskSortEndTime:
+		cursor.Value = t.EndTime.UTC().Format(time.RFC3339Nano)
+	case TaskSortTitle:
+		cursor.Value = t.Title
+	case TaskSortRank:
+		cursor.Value = strconv.FormatFloat(t.Rank, 'g', -1, 64)
+	default:
+		cursor.Value = t.StartTime.UTC().Format(time.RFC3339Nano)
+	}
+	return cursor
+}
+
+func (c TaskCursor) Encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func DecodeTaskCursor(s string) (*TaskCursor, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var cursor TaskCursor
+	if err = json.Unmarshal(b, &cursor); err != nil || !IsValidTaskSort(cursor.Sort) {
+		return nil, ErrInvalidCursor
+	}
+
+	switch cursor.Sort {
+	case TaskSortTitle:
+	case TaskSortRank:
+		if _, err = strconv.ParseFloat(cursor.Value, 64); err != nil {
+			return nil, ErrInvalidCursor
+		}
+	default:
+		if _, err = time.Parse(time.RFC3339Nano, cursor.Value); err != nil {
+			return nil, ErrInvalidCursor
+		}
+	}
+	return &cursor, nil
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"testing"
+	"time"
+)
+
+func TestTaskCursorRoundTrip(t *testing.T) {
+	tashkent, err := time.LoadLocation("Asia/Tashkent")
+	if err != nil {
+		t.Skip("time zone database is not available")
+	}
+
+	start := time.Date(2024, 3, 10, 9, 30, 15, 123456789, tashkent)
+	task := Task{
+		TaskID:    uuid.New(),
+		Title:     "standup",
+		StartTime: start,
+		EndTime:   start.Add(time.Hour),
+		Rank:      1.5,
+	}
+
+	tests := []struct {
+		sort  string
+		order string
+		want  string
+	}{
+		{TaskSortStartTime, SortAsc, "2024-03-10T04:30:15.123456789Z"},
+		{TaskSortEndTime, SortDesc, "2024-03-10T05:30:15.123456789Z"},
+		{TaskSortTitle, SortAsc, "standup"},
+		{TaskSortRank, SortDesc, "1.5"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sort, func(t *testing.T) {
+			cursor, err := DecodeTaskCursor(NewTaskCursor(task, tt.sort, tt.order).Encode())
+			if err != nil {
+				t.Fatalf("DecodeTaskCursor: %v", err)
+			}
+
+			if cursor.Value != tt.want || cursor.Sort != tt.sort || cursor.Order != tt.order || cursor.TaskId != task.TaskID {
+				t.Errorf("got %+v, want value %q", cursor, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeTaskCursorRejectsInvalid(t *testing.T) {
+	tests := []string{
+		"not base64!",
+		NewTaskCursor(Task{}, "priority", SortAsc).Encode(),
+		TaskCursor{Sort: TaskSortStartTime, Value: "10-03-2024 09:30"}.Encode(),
+		TaskCursor{Sort: TaskSortRank, Value: "high"}.Encode(),
+	}
+
+	for _, s := range tests {
+		if _, err := DecodeTaskCursor(s); err != ErrInvalidCursor {
+			t.Errorf("DecodeTaskCursor(%q) = %v, want ErrInvalidCursor", s, err)
+		}
+	}
+}
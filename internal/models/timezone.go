@@ -0,0 +1,61 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"time"
+	"uzinfocom-todo/pkg/rrule"
+)
+
+// TimeLayout is the local wall clock format task times have always been accepted and rendered in
+const TimeLayout = "02-01-2006 15:04"
+
+// TimeFormatRFC3339 is the time format a request can opt into to get times with their offset
+const TimeFormatRFC3339 = "rfc3339"
+
+var ErrInvalidTime = errors.New("time must be in RFC 3339 or in the format dd-mm-yyyy hh:mm")
+
+// LoadTimezone loads an IANA time zone. "Local" is accepted by time.LoadLocation but means nothing to a client.
+func LoadTimezone(name string) (*time.Location, error) {
+	if name == "" || name == "Local" {
+		return nil, errors.New("timezone must be a valid IANA time zone name")
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, errors.New("timezone must be a valid IANA time zone name")
+	}
+	return loc, nil
+}
+
+// LocationFromContext returns the time zone of the request, UTC when the request has none
+func LocationFromContext(ctx context.Context) *time.Location {
+	if loc, ok := ctx.Value("location").(*time.Location); ok && loc != nil {
+		return loc
+	}
+	return time.UTC
+}
+
+// ParseTime reads an RFC 3339 time, or a TimeLayout wall clock time in loc that is resolved across DST
+// changes the way rrule.Date does it
+func ParseTime(value string, loc *time.Location) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	wall, err := time.Parse(TimeLayout, value)
+	if err != nil {
+		return time.Time{}, ErrInvalidTime
+	}
+
+	return rrule.Date(wall.Year(), wall.Month(), wall.Day(), wall.Hour(), wall.Minute(), 0, loc), nil
+}
+
+// FormatTime renders t in the time zone of the request, in TimeLayout unless the request asked for RFC 3339
+func FormatTime(ctx context.Context, t time.Time) string {
+	layout := TimeLayout
+	if format, _ := ctx.Value("time_format").(string); format == TimeFormatRFC3339 {
+		layout = time.RFC3339
+	}
+	return t.In(LocationFromContext(ctx)).Format(layout)
+}
@@ -0,0 +1,14 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type Totp struct {
+	UserId       uuid.UUID  `json:"user_id"`
+	PhoneNumber  string     `json:"phone_number"`
+	Secret       *string    `json:"-"`
+	EnabledAt    *time.Time `json:"enabled_at"`
+	LastUsedStep *int64     `json:"-"`
+}
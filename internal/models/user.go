@@ -1,9 +1,70 @@
 package models
 
-import "github.com/google/uuid"
+import (
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+	"time"
+	"uzinfocom-todo/pkg/http_errors"
+	"uzinfocom-todo/pkg/validator"
+)
+
+// limits of the columns of the users table
+const (
+	maxUserNameLength    = 64
+	maxPhoneNumberLength = 20
+)
 
 type User struct {
-	UserId      uuid.UUID `json:"user_id"`
-	Name        string    `json:"name"`
-	PhoneNumber string    `json:"phone_number"`
+	UserId      uuid.UUID  `json:"user_id"`
+	Name        string     `json:"name"`
+	PhoneNumber string     `json:"phone_number"`
+	Password    string     `json:"password,omitempty"`
+	Role        string     `json:"role,omitempty"`
+	DisabledAt  *time.Time `json:"-"`
+	TotpEnabled bool       `json:"-"`
+	Timezone    string     `json:"-"`
+	Locale      string     `json:"-"`
+	Email       string     `json:"-"`
+}
+
+func (u *User) Validate() http_errors.RestErr {
+	v := validator.New()
+
+	if v.Required("name", u.Name) {
+		v.MaxLength("name", u.Name, maxUserNameLength)
+	}
+
+	checkPhoneNumber(v, u.PhoneNumber)
+
+	v.Required("password", u.Password)
+	return http_errors.ValidationFailed(v)
+}
+
+func ValidatePhoneNumber(phoneNumber string) http_errors.RestErr {
+	v := validator.New()
+	checkPhoneNumber(v, phoneNumber)
+	return http_errors.ValidationFailed(v)
+}
+
+func checkPhoneNumber(v *validator.Validator, phoneNumber string) {
+	if v.Required("phone_number", phoneNumber) && v.MaxLength("phone_number", phoneNumber, maxPhoneNumberLength) {
+		v.Phone("phone_number", phoneNumber)
+	}
+}
+
+func (u *User) HashPassword(cost int) error {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(u.Password), cost)
+	if err != nil {
+		return err
+	}
+	u.Password = string(hashedPassword)
+	return nil
+}
+
+func (u *User) ComparePasswords(password string) error {
+	return bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(password))
+}
+
+func (u *User) SanitizePassword() {
+	u.Password = ""
 }
@@ -0,0 +1,10 @@
+package otp
+
+import "net/http"
+
+type Handler interface {
+	RequestCode() http.HandlerFunc
+	VerifyCode() http.HandlerFunc
+	RequestPhoneChange() http.HandlerFunc
+	ConfirmPhoneChange() http.HandlerFunc
+}
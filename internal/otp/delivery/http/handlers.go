@@ -0,0 +1,202 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"uzinfocom-todo/config"
+	"uzinfocom-todo/internal/auth"
+	"uzinfocom-todo/internal/mfa"
+	"uzinfocom-todo/internal/models"
+	"uzinfocom-todo/internal/models/request_objects"
+	"uzinfocom-todo/internal/models/response_objects"
+	"uzinfocom-todo/internal/otp"
+	"uzinfocom-todo/pkg/http_errors"
+	"uzinfocom-todo/pkg/i18n"
+	"uzinfocom-todo/pkg/util"
+)
+
+type otpHandler struct {
+	cfg    *config.Config
+	otpUC  otp.UseCase
+	authUC auth.UseCase
+	mfaUC  mfa.UseCase
+}
+
+func NewOtpHandler(cfg *config.Config, otpUC otp.UseCase, authUC auth.UseCase, mfaUC mfa.UseCase) otp.Handler {
+	return &otpHandler{
+		cfg:    cfg,
+		otpUC:  otpUC,
+		authUC: authUC,
+		mfaUC:  mfaUC,
+	}
+}
+
+func (h *otpHandler) RequestCode() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var responseObject *response_objects.ResponseObject
+		var requestOtp request_objects.RequestOtp
+
+		err := json.NewDecoder(r.Body).Decode(&requestOtp)
+
+		if err != nil || requestOtp.PhoneNumber == "" {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), http_errors.InvalidJson("otp request"))
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(jsonResponse)
+			return
+		}
+
+		defer r.Body.Close()
+
+		restErr := h.otpUC.RequestCode(r.Context(), requestOtp.PhoneNumber)
+
+		if restErr != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(restErr.Status())
+			w.Write(jsonResponse)
+			return
+		}
+
+		responseObject = response_objects.NewSuccessResponseObject(r.Context(), i18n.MessageOtpSent, nil)
+		jsonResponse, _ := json.Marshal(responseObject)
+		w.WriteHeader(http.StatusOK)
+		w.Write(jsonResponse)
+	}
+}
+
+func (h *otpHandler) VerifyCode() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var responseObject *response_objects.ResponseObject
+		var requestOtp request_objects.RequestOtpVerify
+
+		err := json.NewDecoder(r.Body).Decode(&requestOtp)
+
+		if err != nil || requestOtp.PhoneNumber == "" || requestOtp.Code == "" {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), http_errors.InvalidJson("otp verification"))
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(jsonResponse)
+			return
+		}
+
+		defer r.Body.Close()
+
+		u, restErr := h.otpUC.VerifyCode(r.Context(), requestOtp.PhoneNumber, requestOtp.Code)
+
+		if restErr != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(restErr.Status())
+			w.Write(jsonResponse)
+			return
+		}
+
+		if u.TotpEnabled {
+			challenge, restErr := h.mfaUC.IssueChallenge(r.Context(), u)
+
+			if restErr != nil {
+				responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+				jsonResponse, _ := json.Marshal(responseObject)
+				w.WriteHeader(restErr.Status())
+				w.Write(jsonResponse)
+				return
+			}
+
+			responseObject = response_objects.NewSuccessResponseObject(r.Context(), i18n.MessageTwoFactorRequired, challenge)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(http.StatusOK)
+			w.Write(jsonResponse)
+			return
+		}
+
+		tokens, restErr := h.authUC.IssueTokens(r.Context(), u, util.SessionFromRequest(r))
+
+		if restErr != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(restErr.Status())
+			w.Write(jsonResponse)
+			return
+		}
+
+		responseObject = response_objects.NewSuccessResponseObject(r.Context(), i18n.MessageTokensIssued, tokens)
+		jsonResponse, _ := json.Marshal(responseObject)
+		w.WriteHeader(http.StatusOK)
+		w.Write(jsonResponse)
+	}
+}
+
+func (h *otpHandler) RequestPhoneChange() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var responseObject *response_objects.ResponseObject
+		var requestOtp request_objects.RequestOtp
+		userId := r.Context().Value("user").(models.User).UserId
+
+		err := json.NewDecoder(r.Body).Decode(&requestOtp)
+
+		if err != nil || requestOtp.PhoneNumber == "" {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), http_errors.InvalidJson("phone change request"))
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(jsonResponse)
+			return
+		}
+
+		defer r.Body.Close()
+
+		restErr := h.otpUC.RequestPhoneChange(r.Context(), userId, requestOtp.PhoneNumber)
+
+		if restErr != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(restErr.Status())
+			w.Write(jsonResponse)
+			return
+		}
+
+		responseObject = response_objects.NewSuccessResponseObject(r.Context(), i18n.MessagePhoneChangeCodeSent, nil)
+		jsonResponse, _ := json.Marshal(responseObject)
+		w.WriteHeader(http.StatusOK)
+		w.Write(jsonResponse)
+	}
+}
+
+func (h *otpHandler) ConfirmPhoneChange() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var responseObject *response_objects.ResponseObject
+		var requestVerify request_objects.RequestPhoneChangeVerify
+		userId := r.Context().Value("user").(models.User).UserId
+
+		err := json.NewDecoder(r.Body).Decode(&requestVerify)
+
+		if err != nil || requestVerify.Code == "" {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), http_errors.InvalidJson("phone change verification"))
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(jsonResponse)
+			return
+		}
+
+		defer r.Body.Close()
+
+		restErr := h.otpUC.ConfirmPhoneChange(r.Context(), userId, requestVerify.Code)
+
+		if restErr != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(restErr.Status())
+			w.Write(jsonResponse)
+			return
+		}
+
+		responseObject = response_objects.NewSuccessResponseObject(r.Context(), i18n.MessagePhoneNumberChanged, nil)
+		jsonResponse, _ := json.Marshal(responseObject)
+		w.WriteHeader(http.StatusOK)
+		w.Write(jsonResponse)
+	}
+}
@@ -0,0 +1,21 @@
+package http
+
+import (
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"uzinfocom-todo/internal/otp"
+)
+
+func MapRoutes(router *chi.Mux, h otp.Handler, jwtMiddleware func(http.Handler) http.Handler, rateLimitMiddleware func(http.Handler) http.Handler) {
+	router.Route("/api/otp", func(r chi.Router) {
+		r.Use(rateLimitMiddleware)
+		r.Post("/request", h.RequestCode())
+		r.Post("/verify", h.VerifyCode())
+	})
+
+	router.Route("/api/me/phone", func(r chi.Router) {
+		r.Use(jwtMiddleware)
+		r.Post("/", h.RequestPhoneChange())
+		r.Post("/verify", h.ConfirmPhoneChange())
+	})
+}
@@ -0,0 +1,21 @@
+package otp
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"time"
+	"uzinfocom-todo/internal/models"
+)
+
+type Repository interface {
+	Create(ctx context.Context, otpCode models.OtpCode, ttl time.Duration) error
+	CountCreatedWithin(ctx context.Context, phone string, window time.Duration) (int, error)
+	GetLatestActive(ctx context.Context, phone string) (*models.OtpCode, error)
+	IncrementAttempts(ctx context.Context, otpId uuid.UUID, maxAttempts int) (bool, error)
+	MarkUsed(ctx context.Context, otpId uuid.UUID) (bool, error)
+	CreatePhoneChange(ctx context.Context, change models.PhoneChangeCode, ttl time.Duration) error
+	CountPhoneChangesWithin(ctx context.Context, userId uuid.UUID, window time.Duration) (int, error)
+	GetLatestActivePhoneChange(ctx context.Context, userId uuid.UUID) (*models.PhoneChangeCode, error)
+	IncrementPhoneChangeAttempts(ctx context.Context, changeId uuid.UUID, maxAttempts int) (bool, error)
+	ApplyPhoneChange(ctx context.Context, change models.PhoneChangeCode) (bool, error)
+}
@@ -0,0 +1,208 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"time"
+	"uzinfocom-todo/internal/models"
+	"uzinfocom-todo/internal/otp"
+	"uzinfocom-todo/pkg/db/db_postgres"
+)
+
+// phoneNumberConstraint keeps the phone numbers of users unique
+const phoneNumberConstraint = "users_phone_number_key"
+
+type otpRepository struct {
+	db *sqlx.DB
+}
+
+func NewOtpRepository(db *sqlx.DB) otp.Repository {
+	return &otpRepository{
+		db: db,
+	}
+}
+
+func (r *otpRepository) Create(ctx context.Context, otpCode models.OtpCode, ttl time.Duration) error {
+	query := `INSERT INTO otp_codes (otp_id, phone_number, code_hash, expires_at)
+		VALUES ($1, $2, $3, CURRENT_TIMESTAMP + make_interval(secs => $4))`
+
+	if _, err := r.db.ExecContext(
+		ctx,
+		query,
+		uuid.New(),
+		otpCode.PhoneNumber,
+		otpCode.CodeHash,
+		ttl.Seconds(),
+	); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *otpRepository) CountCreatedWithin(ctx context.Context, phone string, window time.Duration) (int, error) {
+	query := `SELECT count(*) FROM otp_codes WHERE phone_number = $1 AND created_at > CURRENT_TIMESTAMP - make_interval(secs => $2)`
+	var count int
+
+	if err := r.db.QueryRowxContext(ctx, query, phone, window.Seconds()).Scan(&count); err != nil {
+		return -1, err
+	}
+	return count, nil
+}
+
+func (r *otpRepository) GetLatestActive(ctx context.Context, phone string) (*models.OtpCode, error) {
+	query := `SELECT otp_id, phone_number, code_hash, attempts, expires_at, used_at, created_at FROM otp_codes
+		WHERE phone_number = $1 AND used_at IS NULL AND expires_at > CURRENT_TIMESTAMP
+		ORDER BY created_at DESC LIMIT 1`
+	otpCode := models.OtpCode{}
+
+	if err := r.db.QueryRowxContext(ctx, query, phone).Scan(
+		&otpCode.OtpId,
+		&otpCode.PhoneNumber,
+		&otpCode.CodeHash,
+		&otpCode.Attempts,
+		&otpCode.ExpiresAt,
+		&otpCode.UsedAt,
+		&otpCode.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &otpCode, nil
+}
+
+// IncrementAttempts counts one more verification attempt and reports false once the limit is already reached
+func (r *otpRepository) IncrementAttempts(ctx context.Context, otpId uuid.UUID, maxAttempts int) (bool, error) {
+	query := `UPDATE otp_codes SET attempts = attempts + 1 WHERE otp_id = $1 AND attempts < $2`
+
+	res, err := r.db.ExecContext(ctx, query, otpId, maxAttempts)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// MarkUsed consumes the code and reports false if it has already been consumed by a concurrent request
+func (r *otpRepository) MarkUsed(ctx context.Context, otpId uuid.UUID) (bool, error) {
+	query := `UPDATE otp_codes SET used_at = CURRENT_TIMESTAMP WHERE otp_id = $1 AND used_at IS NULL`
+
+	res, err := r.db.ExecContext(ctx, query, otpId)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+func (r *otpRepository) CreatePhoneChange(ctx context.Context, change models.PhoneChangeCode, ttl time.Duration) error {
+	query := `INSERT INTO phone_change_codes (change_id, user_id, new_phone_number, code_hash, expires_at)
+		VALUES ($1, $2, $3, $4, CURRENT_TIMESTAMP + make_interval(secs => $5))`
+
+	if _, err := r.db.ExecContext(
+		ctx,
+		query,
+		uuid.New(),
+		change.UserId,
+		change.NewPhoneNumber,
+		change.CodeHash,
+		ttl.Seconds(),
+	); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *otpRepository) CountPhoneChangesWithin(ctx context.Context, userId uuid.UUID, window time.Duration) (int, error) {
+	query := `SELECT count(*) FROM phone_change_codes WHERE user_id = $1 AND created_at > CURRENT_TIMESTAMP - make_interval(secs => $2)`
+	var count int
+
+	if err := r.db.QueryRowxContext(ctx, query, userId, window.Seconds()).Scan(&count); err != nil {
+		return -1, err
+	}
+	return count, nil
+}
+
+func (r *otpRepository) GetLatestActivePhoneChange(ctx context.Context, userId uuid.UUID) (*models.PhoneChangeCode, error) {
+	query := `SELECT change_id, user_id, new_phone_number, code_hash, attempts, expires_at, used_at, created_at FROM phone_change_codes
+		WHERE user_id = $1 AND used_at IS NULL AND expires_at > CURRENT_TIMESTAMP
+		ORDER BY created_at DESC LIMIT 1`
+	change := models.PhoneChangeCode{}
+
+	if err := r.db.QueryRowxContext(ctx, query, userId).Scan(
+		&change.ChangeId,
+		&change.UserId,
+		&change.NewPhoneNumber,
+		&change.CodeHash,
+		&change.Attempts,
+		&change.ExpiresAt,
+		&change.UsedAt,
+		&change.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &change, nil
+}
+
+func (r *otpRepository) IncrementPhoneChangeAttempts(ctx context.Context, changeId uuid.UUID, maxAttempts int) (bool, error) {
+	query := `UPDATE phone_change_codes SET attempts = attempts + 1 WHERE change_id = $1 AND attempts < $2`
+
+	res, err := r.db.ExecContext(ctx, query, changeId, maxAttempts)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// ApplyPhoneChange consumes the code and moves the user to the new phone number in one transaction.
+// It reports false if the code has already been consumed by a concurrent request.
+func (r *otpRepository) ApplyPhoneChange(ctx context.Context, change models.PhoneChangeCode) (bool, error) {
+	tx, err := r.db.BeginTxx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(
+		ctx,
+		`UPDATE phone_change_codes SET used_at = CURRENT_TIMESTAMP WHERE change_id = $1 AND used_at IS NULL`,
+		change.ChangeId,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	if affected, err := res.RowsAffected(); err != nil || affected == 0 {
+		return false, err
+	}
+
+	if _, err = tx.ExecContext(
+		ctx,
+		`UPDATE users SET phone_number = $1 WHERE user_id = $2`,
+		change.NewPhoneNumber,
+		change.UserId,
+	); err != nil {
+		if db_postgres.IsUniqueViolation(err, phoneNumberConstraint) {
+			return false, models.ErrPhoneTaken
+		}
+		return false, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
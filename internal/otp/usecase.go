@@ -0,0 +1,15 @@
+package otp
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"uzinfocom-todo/internal/models"
+	"uzinfocom-todo/pkg/http_errors"
+)
+
+type UseCase interface {
+	RequestCode(ctx context.Context, phone string) http_errors.RestErr
+	VerifyCode(ctx context.Context, phone string, code string) (*models.User, http_errors.RestErr)
+	RequestPhoneChange(ctx context.Context, userId uuid.UUID, newPhone string) http_errors.RestErr
+	ConfirmPhoneChange(ctx context.Context, userId uuid.UUID, code string) http_errors.RestErr
+}
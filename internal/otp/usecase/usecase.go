@@ -0,0 +1,273 @@
+package usecase
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"log"
+	"math/big"
+	"time"
+	"uzinfocom-todo/config"
+	"uzinfocom-todo/internal/models"
+	"uzinfocom-todo/internal/otp"
+	"uzinfocom-todo/internal/session"
+	"uzinfocom-todo/internal/user"
+	"uzinfocom-todo/pkg/http_errors"
+	"uzinfocom-todo/pkg/sms"
+)
+
+const (
+	defaultCodeLength         = 6
+	defaultCodeTTL            = 5 * time.Minute
+	defaultMaxAttempts        = 5
+	defaultMaxRequestsPerHour = 5
+)
+
+type otpUseCase struct {
+	otpRepo   otp.Repository
+	userRepo  user.Repository
+	sessionUC session.UseCase
+	sender    sms.Sender
+	cfg       *config.Config
+}
+
+func NewOtpUseCase(otpRepo otp.Repository, userRepo user.Repository, sessionUC session.UseCase, sender sms.Sender, cfg *config.Config) otp.UseCase {
+	return &otpUseCase{
+		otpRepo:   otpRepo,
+		userRepo:  userRepo,
+		sessionUC: sessionUC,
+		sender:    sender,
+		cfg:       cfg,
+	}
+}
+
+func (uc *otpUseCase) RequestCode(ctx context.Context, phone string) http_errors.RestErr {
+	if _, err := uc.userRepo.GetByPhoneNumber(ctx, phone); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			// do not reveal whether the phone number is registered
+			return nil
+		}
+		return http_errors.ParseErrors(err)
+	}
+
+	count, err := uc.otpRepo.CountCreatedWithin(ctx, phone, time.Hour)
+	if err != nil {
+		return http_errors.ParseErrors(err)
+	}
+
+	if count >= uc.maxRequestsPerHour() {
+		return http_errors.OtpRequestsLimitExceeded()
+	}
+
+	code, err := generateCode(uc.codeLength())
+	if err != nil {
+		return http_errors.ParseErrors(err)
+	}
+
+	otpCode := models.OtpCode{
+		PhoneNumber: phone,
+		CodeHash:    uc.hashCode(phone, code),
+	}
+
+	if err = uc.otpRepo.Create(ctx, otpCode, uc.codeTTL()); err != nil {
+		return http_errors.ParseErrors(err)
+	}
+
+	message := fmt.Sprintf("Your verification code is %s. It expires in %d minutes.", code, int(uc.codeTTL().Minutes()))
+
+	if err = uc.sender.Send(ctx, phone, message); err != nil {
+		log.Printf("otp: sending code to %s failed: %v", phone, err)
+		return http_errors.SmsDeliveryFailed()
+	}
+	return nil
+}
+
+func (uc *otpUseCase) VerifyCode(ctx context.Context, phone string, code string) (*models.User, http_errors.RestErr) {
+	otpCode, err := uc.otpRepo.GetLatestActive(ctx, phone)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, http_errors.InvalidOtp()
+		}
+		return nil, http_errors.ParseErrors(err)
+	}
+
+	allowed, err := uc.otpRepo.IncrementAttempts(ctx, otpCode.OtpId, uc.maxAttempts())
+	if err != nil {
+		return nil, http_errors.ParseErrors(err)
+	}
+
+	if !allowed {
+		return nil, http_errors.OtpAttemptsLimitExceeded()
+	}
+
+	if !hmac.Equal([]byte(otpCode.CodeHash), []byte(uc.hashCode(phone, code))) {
+		return nil, http_errors.InvalidOtp()
+	}
+
+	consumed, err := uc.otpRepo.MarkUsed(ctx, otpCode.OtpId)
+	if err != nil {
+		return nil, http_errors.ParseErrors(err)
+	}
+
+	if !consumed {
+		return nil, http_errors.InvalidOtp()
+	}
+
+	foundUser, err := uc.userRepo.GetByPhoneNumber(ctx, phone)
+	if err != nil {
+		return nil, http_errors.ParseErrors(err)
+	}
+
+	if foundUser.DisabledAt != nil {
+		return nil, http_errors.AccountDisabled()
+	}
+
+	foundUser.SanitizePassword()
+	return foundUser, nil
+}
+
+// RequestPhoneChange sends a code to the new phone number, the number is only changed once that code is confirmed
+func (uc *otpUseCase) RequestPhoneChange(ctx context.Context, userId uuid.UUID, newPhone string) http_errors.RestErr {
+	if restErr := models.ValidatePhoneNumber(newPhone); restErr != nil {
+		return restErr
+	}
+
+	foundUser, err := uc.userRepo.GetById(ctx, userId)
+	if err != nil {
+		return http_errors.ParseErrors(err)
+	}
+
+	if foundUser.PhoneNumber == newPhone {
+		return http_errors.SamePhoneNumber()
+	}
+
+	if _, err = uc.userRepo.GetByPhoneNumber(ctx, newPhone); err == nil {
+		return http_errors.PhoneTaken()
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		return http_errors.ParseErrors(err)
+	}
+
+	count, err := uc.otpRepo.CountPhoneChangesWithin(ctx, userId, time.Hour)
+	if err != nil {
+		return http_errors.ParseErrors(err)
+	}
+
+	if count >= uc.maxRequestsPerHour() {
+		return http_errors.OtpRequestsLimitExceeded()
+	}
+
+	code, err := generateCode(uc.codeLength())
+	if err != nil {
+		return http_errors.ParseErrors(err)
+	}
+
+	change := models.PhoneChangeCode{
+		UserId:         userId,
+		NewPhoneNumber: newPhone,
+		CodeHash:       uc.hashCode(newPhone, code),
+	}
+
+	if err = uc.otpRepo.CreatePhoneChange(ctx, change, uc.codeTTL()); err != nil {
+		return http_errors.ParseErrors(err)
+	}
+
+	message := fmt.Sprintf("Your phone number change code is %s. It expires in %d minutes.", code, int(uc.codeTTL().Minutes()))
+
+	if err = uc.sender.Send(ctx, newPhone, message); err != nil {
+		log.Printf("otp: sending phone change code to %s failed: %v", newPhone, err)
+		return http_errors.SmsDeliveryFailed()
+	}
+	return nil
+}
+
+func (uc *otpUseCase) ConfirmPhoneChange(ctx context.Context, userId uuid.UUID, code string) http_errors.RestErr {
+	change, err := uc.otpRepo.GetLatestActivePhoneChange(ctx, userId)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return http_errors.InvalidOtp()
+		}
+		return http_errors.ParseErrors(err)
+	}
+
+	allowed, err := uc.otpRepo.IncrementPhoneChangeAttempts(ctx, change.ChangeId, uc.maxAttempts())
+	if err != nil {
+		return http_errors.ParseErrors(err)
+	}
+
+	if !allowed {
+		return http_errors.OtpAttemptsLimitExceeded()
+	}
+
+	if !hmac.Equal([]byte(change.CodeHash), []byte(uc.hashCode(change.NewPhoneNumber, code))) {
+		return http_errors.InvalidOtp()
+	}
+
+	applied, err := uc.otpRepo.ApplyPhoneChange(ctx, *change)
+	if err != nil {
+		// the number may have been registered by someone else since the code was sent
+		if errors.Is(err, models.ErrPhoneTaken) {
+			return http_errors.PhoneTaken()
+		}
+		return http_errors.ParseErrors(err)
+	}
+
+	if !applied {
+		return http_errors.InvalidOtp()
+	}
+
+	uc.sessionUC.ForgetUser(userId)
+	return nil
+}
+
+// hashCode keys the hash with the server secret so leaked rows cannot be brute-forced offline
+func (uc *otpUseCase) hashCode(phone string, code string) string {
+	mac := hmac.New(sha256.New, []byte(uc.cfg.Server.OtpSecretKey))
+	mac.Write([]byte(phone + ":" + code))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (uc *otpUseCase) codeLength() int {
+	if uc.cfg.Otp.CodeLength <= 0 {
+		return defaultCodeLength
+	}
+	return uc.cfg.Otp.CodeLength
+}
+
+func (uc *otpUseCase) codeTTL() time.Duration {
+	if uc.cfg.Otp.CodeTTL <= 0 {
+		return defaultCodeTTL
+	}
+	return uc.cfg.Otp.CodeTTL
+}
+
+func (uc *otpUseCase) maxAttempts() int {
+	if uc.cfg.Otp.MaxAttempts <= 0 {
+		return defaultMaxAttempts
+	}
+	return uc.cfg.Otp.MaxAttempts
+}
+
+func (uc *otpUseCase) maxRequestsPerHour() int {
+	if uc.cfg.Otp.MaxRequestsPerHour <= 0 {
+		return defaultMaxRequestsPerHour
+	}
+	return uc.cfg.Otp.MaxRequestsPerHour
+}
+
+func generateCode(length int) (string, error) {
+	code := make([]byte, length)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", err
+		}
+		code[i] = byte('0' + n.Int64())
+	}
+	return string(code), nil
+}
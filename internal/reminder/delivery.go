@@ -0,0 +1,9 @@
+package reminder
+
+import "net/http"
+
+type Handler interface {
+	GetReminders() http.HandlerFunc
+	CreateReminder() http.HandlerFunc
+	DeleteReminder() http.HandlerFunc
+}
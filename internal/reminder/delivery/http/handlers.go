@@ -0,0 +1,146 @@
+package http
+
+import (
+	"encoding/json"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"net/http"
+	"uzinfocom-todo/config"
+	"uzinfocom-todo/internal/models"
+	"uzinfocom-todo/internal/models/request_objects"
+	"uzinfocom-todo/internal/models/response_objects"
+	"uzinfocom-todo/internal/reminder"
+	"uzinfocom-todo/pkg/http_errors"
+	"uzinfocom-todo/pkg/i18n"
+)
+
+type reminderHandler struct {
+	cfg        *config.Config
+	reminderUC reminder.UseCase
+}
+
+func NewReminderHandler(cfg *config.Config, reminderUC reminder.UseCase) reminder.Handler {
+	return &reminderHandler{
+		cfg:        cfg,
+		reminderUC: reminderUC,
+	}
+}
+
+func (h *reminderHandler) GetReminders() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var responseObject *response_objects.ResponseObject
+		userId := r.Context().Value("user").(models.User).UserId
+		taskId, err := uuid.Parse(chi.URLParam(r, "taskId"))
+
+		if err != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), http_errors.InvalidTaskId())
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(jsonResponse)
+			return
+		}
+
+		responseReminders, restErr := h.reminderUC.GetReminders(r.Context(), userId, taskId)
+
+		if restErr != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(restErr.Status())
+			w.Write(jsonResponse)
+			return
+		}
+
+		responseObject = response_objects.NewSuccessResponseObject(r.Context(), i18n.MessageRemindersFetched, responseReminders)
+		jsonResponse, _ := json.Marshal(responseObject)
+		w.WriteHeader(http.StatusOK)
+		w.Write(jsonResponse)
+	}
+}
+
+func (h *reminderHandler) CreateReminder() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var responseObject *response_objects.ResponseObject
+		var requestReminder request_objects.RequestReminder
+		userId := r.Context().Value("user").(models.User).UserId
+		taskId, err := uuid.Parse(chi.URLParam(r, "taskId"))
+
+		if err != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), http_errors.InvalidTaskId())
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(jsonResponse)
+			return
+		}
+
+		err = json.NewDecoder(r.Body).Decode(&requestReminder)
+
+		if err != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), http_errors.InvalidJson("reminder"))
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(jsonResponse)
+			return
+		}
+
+		defer r.Body.Close()
+
+		responseReminder, restErr := h.reminderUC.CreateReminder(r.Context(), userId, taskId, requestReminder)
+
+		if restErr != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(restErr.Status())
+			w.Write(jsonResponse)
+			return
+		}
+
+		responseObject = response_objects.NewSuccessResponseObject(r.Context(), i18n.MessageReminderCreated, responseReminder)
+		jsonResponse, _ := json.Marshal(responseObject)
+		w.WriteHeader(http.StatusCreated)
+		w.Write(jsonResponse)
+	}
+}
+
+func (h *reminderHandler) DeleteReminder() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var responseObject *response_objects.ResponseObject
+		userId := r.Context().Value("user").(models.User).UserId
+		taskId, err := uuid.Parse(chi.URLParam(r, "taskId"))
+
+		if err != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), http_errors.InvalidTaskId())
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(jsonResponse)
+			return
+		}
+
+		reminderId, err := uuid.Parse(chi.URLParam(r, "reminderId"))
+
+		if err != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), http_errors.InvalidReminderId())
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(jsonResponse)
+			return
+		}
+
+		restErr := h.reminderUC.DeleteReminder(r.Context(), userId, taskId, reminderId)
+
+		if restErr != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(restErr.Status())
+			w.Write(jsonResponse)
+			return
+		}
+
+		responseObject = response_objects.NewSuccessResponseObject(r.Context(), i18n.MessageReminderDeleted, nil)
+		jsonResponse, _ := json.Marshal(responseObject)
+		w.WriteHeader(http.StatusOK)
+		w.Write(jsonResponse)
+	}
+}
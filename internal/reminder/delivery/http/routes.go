@@ -0,0 +1,18 @@
+package http
+
+import (
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"uzinfocom-todo/internal/middleware"
+	"uzinfocom-todo/internal/models"
+	"uzinfocom-todo/internal/reminder"
+)
+
+func MapRoutes(router *chi.Mux, h reminder.Handler, authMiddleware func(http.Handler) http.Handler) {
+	router.Route("/todo/{taskId}/reminders", func(r chi.Router) {
+		r.Use(authMiddleware)
+		r.With(middleware.RequireScopes(models.ScopeTasksRead)).Get("/", h.GetReminders())
+		r.With(middleware.RequireScopes(models.ScopeTasksWrite)).Post("/", h.CreateReminder())
+		r.With(middleware.RequireScopes(models.ScopeTasksWrite)).Delete("/{reminderId}", h.DeleteReminder())
+	})
+}
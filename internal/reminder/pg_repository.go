@@ -0,0 +1,21 @@
+package reminder
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"time"
+	"uzinfocom-todo/internal/models"
+)
+
+type Repository interface {
+	InTx(ctx context.Context, fn func(repo Repository) error) error
+	TaskExists(ctx context.Context, userId uuid.UUID, taskId uuid.UUID) (bool, error)
+	TaskStart(ctx context.Context, taskId uuid.UUID) (time.Time, bool, error)
+	GetByTaskId(ctx context.Context, taskId uuid.UUID) ([]models.Reminder, error)
+	Create(ctx context.Context, reminder models.Reminder) (*models.Reminder, error)
+	Delete(ctx context.Context, taskId uuid.UUID, reminderId uuid.UUID) (bool, error)
+	ClaimDue(ctx context.Context, limit int, maxAttempts int, lease time.Duration) ([]models.DueReminder, error)
+	MarkSent(ctx context.Context, reminderId uuid.UUID) error
+	MarkFailed(ctx context.Context, reminderId uuid.UUID, reason string) error
+	Rearm(ctx context.Context, reminderId uuid.UUID, occurrenceStart time.Time) error
+}
@@ -0,0 +1,226 @@
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"time"
+	"uzinfocom-todo/internal/models"
+	"uzinfocom-todo/internal/reminder"
+	"uzinfocom-todo/pkg/db/db_postgres"
+)
+
+const reminderColumns = `reminder_id, task_id, user_id, minutes_before, remind_at, occurrence_start, sent_at, attempts, last_error, created_at`
+
+type reminderRepository struct {
+	db *sqlx.DB
+	q  db_postgres.Queryer
+}
+
+func NewReminderRepository(db *sqlx.DB) reminder.Repository {
+	return &reminderRepository{
+		db: db,
+		q:  db,
+	}
+}
+
+func reminderScanDest(r *models.Reminder) []interface{} {
+	return []interface{}{
+		&r.ReminderId,
+		&r.TaskId,
+		&r.UserId,
+		&r.MinutesBefore,
+		&r.RemindAt,
+		&r.OccurrenceStart,
+		&r.SentAt,
+		&r.Attempts,
+		&r.LastError,
+		&r.CreatedAt,
+	}
+}
+
+func (r *reminderRepository) InTx(ctx context.Context, fn func(repo reminder.Repository) error) error {
+	return db_postgres.InTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		return fn(&reminderRepository{db: r.db, q: tx})
+	})
+}
+
+func (r *reminderRepository) TaskExists(ctx context.Context, userId uuid.UUID, taskId uuid.UUID) (bool, error) {
+	return db_postgres.TaskExists(ctx, r.q, userId, taskId)
+}
+
+// TaskStart returns the start time of the task and whether it recurs
+func (r *reminderRepository) TaskStart(ctx context.Context, taskId uuid.UUID) (time.Time, bool, error) {
+	query := `SELECT start_time, recurrence_rule IS NOT NULL FROM tasks WHERE task_id = $1`
+	var start time.Time
+	var recurring bool
+
+	if err := r.q.QueryRowxContext(ctx, query, taskId).Scan(&start, &recurring); err != nil {
+		return time.Time{}, false, err
+	}
+	return start, recurring, nil
+}
+
+func (r *reminderRepository) GetByTaskId(ctx context.Context, taskId uuid.UUID) ([]models.Reminder, error) {
+	query := `SELECT ` + reminderColumns + ` FROM reminders WHERE task_id = $1 ORDER BY created_at`
+	reminders := []models.Reminder{}
+
+	rows, err := r.q.QueryxContext(ctx, query, taskId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		rem := models.Reminder{}
+		if err = rows.Scan(reminderScanDest(&rem)...); err != nil {
+			return nil, err
+		}
+		reminders = append(reminders, rem)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return reminders, nil
+}
+
+func (r *reminderRepository) Create(ctx context.Context, rem models.Reminder) (*models.Reminder, error) {
+	query := `INSERT INTO reminders (reminder_id, task_id, user_id, minutes_before, remind_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING ` + reminderColumns
+	created := models.Reminder{}
+
+	if err := r.q.QueryRowxContext(
+		ctx,
+		query,
+		uuid.New(),
+		rem.TaskId,
+		rem.UserId,
+		rem.MinutesBefore,
+		rem.RemindAt,
+	).Scan(reminderScanDest(&created)...); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+func (r *reminderRepository) Delete(ctx context.Context, taskId uuid.UUID, reminderId uuid.UUID) (bool, error) {
+	res, err := r.q.ExecContext(ctx, `DELETE FROM reminders WHERE reminder_id = $1 AND task_id = $2`, reminderId, taskId)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// ClaimDue claims due reminders for the duration of lease, rows claimed by another instance are skipped.
+// A relative reminder of a recurring task is claimed for the occurrence at occurrence_start, or the first one.
+func (r *reminderRepository) ClaimDue(ctx context.Context, limit int, maxAttempts int, lease time.Duration) ([]models.DueReminder, error) {
+	query := `WITH due AS (
+			SELECT r.reminder_id,
+				COALESCE(r.remind_at, occ.start_time - r.minutes_before * INTERVAL '1 minute') AS fire_at,
+				occ.title, occ.start_time, occ.end_time, occ.status, s.occurrence_start,
+				t.start_time AS series_start, t.recurrence_rule, t.timezone AS task_timezone,
+				u.name, u.phone_number, u.email, u.locale, u.timezone
+			FROM reminders r
+			JOIN tasks t ON t.task_id = r.task_id
+			JOIN users u ON u.user_id = r.user_id
+			CROSS JOIN LATERAL (
+				SELECT CASE WHEN t.recurrence_rule IS NULL THEN t.start_time
+					ELSE COALESCE(r.occurrence_start, t.start_time) END AS occurrence_start
+			) s
+			LEFT JOIN task_occurrences o ON o.task_id = t.task_id AND o.occurrence_start = s.occurrence_start
+			CROSS JOIN LATERAL (
+				SELECT COALESCE(o.title, t.title) AS title,
+					COALESCE(o.start_time, s.occurrence_start) AS start_time,
+					COALESCE(o.end_time, COALESCE(o.start_time, s.occurrence_start) + (t.end_time - t.start_time)) AS end_time,
+					COALESCE(o.status, t.status) AS status
+			) occ
+			WHERE r.sent_at IS NULL AND r.attempts < $2
+				AND (r.claimed_until IS NULL OR r.claimed_until < NOW())
+				AND t.deletedAt IS NULL
+				AND (occ.status IN ('todo', 'in_progress', 'blocked') OR (t.recurrence_rule IS NOT NULL AND r.minutes_before IS NOT NULL))
+				AND u.disabled_at IS NULL
+				AND COALESCE(r.remind_at, occ.start_time - r.minutes_before * INTERVAL '1 minute') <= NOW()
+			ORDER BY fire_at
+			LIMIT $1
+			FOR UPDATE OF r SKIP LOCKED
+		), claimed AS (
+			UPDATE reminders r SET claimed_until = NOW() + make_interval(secs => $3)
+			FROM due d
+			WHERE r.reminder_id = d.reminder_id
+			RETURNING r.reminder_id, r.task_id, r.user_id, r.minutes_before, r.remind_at, r.occurrence_start, r.sent_at, r.attempts,
+				r.last_error, r.created_at,
+				d.fire_at, d.title, d.start_time, d.end_time, d.status, d.occurrence_start AS occurrence,
+				d.series_start, d.recurrence_rule, d.task_timezone,
+				d.name, d.phone_number, d.email, d.locale, d.timezone
+		)
+		SELECT * FROM claimed ORDER BY fire_at`
+	due := []models.DueReminder{}
+
+	rows, err := r.q.QueryxContext(ctx, query, limit, maxAttempts, lease.Seconds())
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		d := models.DueReminder{}
+		dest := append(
+			reminderScanDest(&d.Reminder),
+			&d.FireAt,
+			&d.TaskTitle,
+			&d.TaskStart,
+			&d.TaskEnd,
+			&d.TaskStatus,
+			&d.Occurrence,
+			&d.SeriesStart,
+			&d.RecurrenceRule,
+			&d.TaskTimezone,
+			&d.Name,
+			&d.Phone,
+			&d.Email,
+			&d.Locale,
+			&d.Timezone,
+		)
+
+		if err = rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		due = append(due, d)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return due, nil
+}
+
+func (r *reminderRepository) MarkSent(ctx context.Context, reminderId uuid.UUID) error {
+	_, err := r.q.ExecContext(ctx, `UPDATE reminders SET sent_at = NOW(), attempts = attempts + 1, last_error = NULL, claimed_until = NULL
+		WHERE reminder_id = $1`, reminderId)
+	return err
+}
+
+func (r *reminderRepository) MarkFailed(ctx context.Context, reminderId uuid.UUID, reason string) error {
+	_, err := r.q.ExecContext(ctx, `UPDATE reminders SET attempts = attempts + 1, last_error = LEFT($2, 255), claimed_until = NULL
+		WHERE reminder_id = $1`, reminderId, reason)
+	return err
+}
+
+// Rearm makes the reminder of a recurring task pending for the occurrence starting at occurrenceStart, with
+// a fresh count of attempts
+func (r *reminderRepository) Rearm(ctx context.Context, reminderId uuid.UUID, occurrenceStart time.Time) error {
+	_, err := r.q.ExecContext(ctx, `UPDATE reminders SET occurrence_start = $2, sent_at = NULL, attempts = 0, last_error = NULL,
+		claimed_until = NULL WHERE reminder_id = $1`, reminderId, occurrenceStart)
+	return err
+}
@@ -0,0 +1,16 @@
+package reminder
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"uzinfocom-todo/internal/models/request_objects"
+	"uzinfocom-todo/internal/models/response_objects"
+	"uzinfocom-todo/pkg/http_errors"
+)
+
+type UseCase interface {
+	GetReminders(ctx context.Context, userId uuid.UUID, taskId uuid.UUID) (*[]response_objects.ResponseReminder, http_errors.RestErr)
+	CreateReminder(ctx context.Context, userId uuid.UUID, taskId uuid.UUID, requestReminder request_objects.RequestReminder) (*response_objects.ResponseReminder, http_errors.RestErr)
+	DeleteReminder(ctx context.Context, userId uuid.UUID, taskId uuid.UUID, reminderId uuid.UUID) http_errors.RestErr
+	SendDueReminders(ctx context.Context) (int, http_errors.RestErr)
+}
@@ -0,0 +1,308 @@
+package usecase
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"log"
+	"time"
+	"uzinfocom-todo/config"
+	"uzinfocom-todo/internal/models"
+	"uzinfocom-todo/internal/models/request_objects"
+	"uzinfocom-todo/internal/models/response_objects"
+	"uzinfocom-todo/internal/reminder"
+	"uzinfocom-todo/pkg/http_errors"
+	"uzinfocom-todo/pkg/notify"
+	"uzinfocom-todo/pkg/rrule"
+)
+
+const (
+	// maxMinutesBefore allows reminders up to four weeks ahead of the task
+	maxMinutesBefore = 4 * 7 * 24 * 60
+
+	maxRemindersPerTask = 10
+
+	defaultReminderBatchSize   = 100
+	defaultReminderMaxAttempts = 5
+
+	// defaultRecurrenceHorizon is how far ahead the next occurrence of a recurring task is looked for
+	defaultRecurrenceHorizon = 365 * 24 * time.Hour
+
+	// reminderClaimLease is how long a claimed reminder is left to its scheduler, it has to outlast a run
+	reminderClaimLease = 5 * time.Minute
+)
+
+type reminderUseCase struct {
+	reminderRepo reminder.Repository
+	notifier     notify.Notifier
+	cfg          *config.Config
+}
+
+func NewReminderUseCase(reminderRepo reminder.Repository, notifier notify.Notifier, cfg *config.Config) reminder.UseCase {
+	return &reminderUseCase{
+		reminderRepo: reminderRepo,
+		notifier:     notifier,
+		cfg:          cfg,
+	}
+}
+
+func (uc *reminderUseCase) GetReminders(ctx context.Context, userId uuid.UUID, taskId uuid.UUID) (*[]response_objects.ResponseReminder, http_errors.RestErr) {
+	if restErr := checkTask(ctx, uc.reminderRepo, userId, taskId); restErr != nil {
+		return nil, restErr
+	}
+
+	reminders, err := uc.reminderRepo.GetByTaskId(ctx, taskId)
+	if err != nil {
+		return nil, http_errors.ParseErrors(err)
+	}
+
+	responseReminders := make([]response_objects.ResponseReminder, 0, len(reminders))
+	for _, r := range reminders {
+		responseReminders = append(responseReminders, response_objects.NewResponseReminder(ctx, r))
+	}
+	return &responseReminders, nil
+}
+
+func (uc *reminderUseCase) CreateReminder(ctx context.Context, userId uuid.UUID, taskId uuid.UUID, requestReminder request_objects.RequestReminder) (*response_objects.ResponseReminder, http_errors.RestErr) {
+	rem := models.Reminder{TaskId: taskId, UserId: userId}
+
+	switch {
+	case requestReminder.MinutesBefore != nil && requestReminder.RemindAt != "":
+		return nil, http_errors.ReminderTimeConflict()
+	case requestReminder.MinutesBefore != nil:
+		if *requestReminder.MinutesBefore < 0 || *requestReminder.MinutesBefore > maxMinutesBefore {
+			return nil, http_errors.OutOfRange("minutes_before", 0, maxMinutesBefore)
+		}
+		rem.MinutesBefore = requestReminder.MinutesBefore
+	case requestReminder.RemindAt != "":
+		remindAt, err := models.ParseTime(requestReminder.RemindAt, models.LocationFromContext(ctx))
+		if err != nil {
+			return nil, http_errors.InvalidTime("remind_at")
+		}
+		if !remindAt.After(time.Now()) {
+			return nil, http_errors.ReminderInPast()
+		}
+		rem.RemindAt = &remindAt
+	default:
+		return nil, http_errors.ReminderTimeRequired()
+	}
+
+	var created *models.Reminder
+
+	err := uc.reminderRepo.InTx(ctx, func(txRepo reminder.Repository) error {
+		if restErr := checkTask(ctx, txRepo, userId, taskId); restErr != nil {
+			return restErr
+		}
+
+		if rem.MinutesBefore != nil {
+			// a recurring task is reminded of its next occurrence, so only a one-off task can be too late
+			start, recurring, err := txRepo.TaskStart(ctx, taskId)
+			if err != nil {
+				return err
+			}
+
+			if !recurring && !start.Add(-time.Duration(*rem.MinutesBefore)*time.Minute).After(time.Now()) {
+				return http_errors.ReminderInPast()
+			}
+		}
+
+		existing, err := txRepo.GetByTaskId(ctx, taskId)
+		if err != nil {
+			return err
+		}
+
+		if len(existing) >= maxRemindersPerTask {
+			return http_errors.RemindersLimit(maxRemindersPerTask)
+		}
+
+		created, err = txRepo.Create(ctx, rem)
+		return err
+	})
+
+	if restErr, ok := err.(http_errors.RestErr); ok {
+		return nil, restErr
+	}
+
+	if err != nil {
+		return nil, http_errors.ParseErrors(err)
+	}
+
+	responseReminder := response_objects.NewResponseReminder(ctx, *created)
+	return &responseReminder, nil
+}
+
+func (uc *reminderUseCase) DeleteReminder(ctx context.Context, userId uuid.UUID, taskId uuid.UUID, reminderId uuid.UUID) http_errors.RestErr {
+	if restErr := checkTask(ctx, uc.reminderRepo, userId, taskId); restErr != nil {
+		return restErr
+	}
+
+	deleted, err := uc.reminderRepo.Delete(ctx, taskId, reminderId)
+	if err != nil {
+		return http_errors.ParseErrors(err)
+	}
+
+	if !deleted {
+		return http_errors.ReminderNotFound()
+	}
+	return nil
+}
+
+// SendDueReminders delivers a batch of due reminders and returns how many were sent. A failed delivery is
+// retried until ReminderMaxAttempts, a reminder of a recurring task then moves on to the next occurrence.
+func (uc *reminderUseCase) SendDueReminders(ctx context.Context) (int, http_errors.RestErr) {
+	due, err := uc.reminderRepo.ClaimDue(ctx, uc.batchSize(), uc.maxAttempts(), reminderClaimLease)
+	if err != nil {
+		return 0, http_errors.ParseErrors(err)
+	}
+
+	sent := 0
+
+	for _, d := range due {
+		series := isSeriesReminder(d)
+
+		if series && !occurrencePending(d, time.Now()) {
+			// the occurrence is over, closed or no longer part of the series
+			if err = uc.rearm(ctx, d); err != nil {
+				return sent, http_errors.ParseErrors(err)
+			}
+			continue
+		}
+
+		if err = uc.notifier.Notify(ctx, newNotification(d)); err != nil {
+			log.Printf("reminder %s: %v", d.ReminderId, err)
+
+			if series && d.Attempts+1 >= uc.maxAttempts() {
+				// give up on this occurrence but keep reminding of the next ones
+				err = uc.rearm(ctx, d)
+			} else {
+				err = uc.reminderRepo.MarkFailed(ctx, d.ReminderId, err.Error())
+			}
+
+			if err != nil {
+				return sent, http_errors.ParseErrors(err)
+			}
+			continue
+		}
+
+		if series {
+			err = uc.rearm(ctx, d)
+		} else {
+			err = uc.reminderRepo.MarkSent(ctx, d.ReminderId)
+		}
+
+		if err != nil {
+			return sent, http_errors.ParseErrors(err)
+		}
+		sent++
+	}
+	return sent, nil
+}
+
+// rearm points the reminder of a recurring task at the next occurrence it can still fire for in time.
+// Once the series has no such occurrence within the recurrence horizon the reminder is left as sent.
+func (uc *reminderUseCase) rearm(ctx context.Context, d models.DueReminder) error {
+	next, ok := nextOccurrence(d, time.Now(), uc.recurrenceHorizon())
+	if !ok {
+		return uc.reminderRepo.MarkSent(ctx, d.ReminderId)
+	}
+	return uc.reminderRepo.Rearm(ctx, d.ReminderId, next)
+}
+
+func isSeriesReminder(d models.DueReminder) bool {
+	return d.RecurrenceRule != nil && d.MinutesBefore != nil
+}
+
+// occurrencePending reports whether the occurrence of d is still part of its series, open and not over at now
+func occurrencePending(d models.DueReminder, now time.Time) bool {
+	if !models.IsOpenTaskStatus(d.TaskStatus) || !d.TaskEnd.After(now) {
+		return false
+	}
+
+	rule, err := rrule.Parse(*d.RecurrenceRule)
+	if err != nil {
+		return false
+	}
+	return rule.Includes(d.SeriesStart.In(taskLocation(d)), d.Occurrence)
+}
+
+// nextOccurrence returns the first occurrence after the one of d whose reminder is not due before now
+func nextOccurrence(d models.DueReminder, now time.Time, horizon time.Duration) (time.Time, bool) {
+	rule, err := rrule.Parse(*d.RecurrenceRule)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	from := now.Add(time.Duration(*d.MinutesBefore) * time.Minute)
+	if !from.After(d.Occurrence) {
+		from = d.Occurrence.Add(time.Second)
+	}
+
+	occurrences := rule.Between(d.SeriesStart.In(taskLocation(d)), from, from.Add(horizon))
+	if len(occurrences) == 0 {
+		return time.Time{}, false
+	}
+	return occurrences[0], true
+}
+
+func taskLocation(d models.DueReminder) *time.Location {
+	loc, err := models.LoadTimezone(d.TaskTimezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+func newNotification(d models.DueReminder) notify.Notification {
+	loc, err := models.LoadTimezone(d.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+
+	notification := notify.Notification{
+		ReminderId:  d.ReminderId,
+		UserId:      d.UserId,
+		Name:        d.Name,
+		PhoneNumber: d.Phone,
+		TaskId:      d.TaskId,
+		TaskTitle:   d.TaskTitle,
+		StartTime:   d.TaskStart.In(loc),
+		RemindAt:    d.FireAt.In(loc),
+	}
+
+	if d.Email != nil {
+		notification.Email = *d.Email
+	}
+	return notification
+}
+
+func checkTask(ctx context.Context, repo reminder.Repository, userId uuid.UUID, taskId uuid.UUID) http_errors.RestErr {
+	exists, err := repo.TaskExists(ctx, userId, taskId)
+	if err != nil {
+		return http_errors.ParseErrors(err)
+	}
+
+	if !exists {
+		return http_errors.TaskNotFound()
+	}
+	return nil
+}
+
+func (uc *reminderUseCase) batchSize() int {
+	if uc.cfg.Server.ReminderBatchSize <= 0 {
+		return defaultReminderBatchSize
+	}
+	return uc.cfg.Server.ReminderBatchSize
+}
+
+func (uc *reminderUseCase) recurrenceHorizon() time.Duration {
+	if uc.cfg.Server.RecurrenceHorizon <= 0 {
+		return defaultRecurrenceHorizon
+	}
+	return uc.cfg.Server.RecurrenceHorizon
+}
+
+func (uc *reminderUseCase) maxAttempts() int {
+	if uc.cfg.Server.ReminderMaxAttempts <= 0 {
+		return defaultReminderMaxAttempts
+	}
+	return uc.cfg.Server.ReminderMaxAttempts
+}
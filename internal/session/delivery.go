@@ -0,0 +1,9 @@
+package session
+
+import "net/http"
+
+type Handler interface {
+	GetSessions() http.HandlerFunc
+	LogoutCurrent() http.HandlerFunc
+	LogoutAll() http.HandlerFunc
+}
@@ -0,0 +1,95 @@
+package http
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+	"net/http"
+	"uzinfocom-todo/config"
+	"uzinfocom-todo/internal/models"
+	"uzinfocom-todo/internal/models/response_objects"
+	"uzinfocom-todo/internal/session"
+	"uzinfocom-todo/pkg/i18n"
+)
+
+type sessionHandler struct {
+	cfg       *config.Config
+	sessionUC session.UseCase
+}
+
+func NewSessionHandler(cfg *config.Config, sessionUC session.UseCase) session.Handler {
+	return &sessionHandler{
+		cfg:       cfg,
+		sessionUC: sessionUC,
+	}
+}
+
+func (h *sessionHandler) GetSessions() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var responseObject *response_objects.ResponseObject
+		userId := r.Context().Value("user").(models.User).UserId
+		sessionId := r.Context().Value("session_id").(uuid.UUID)
+
+		responseSessions, restErr := h.sessionUC.GetSessions(r.Context(), userId, sessionId)
+
+		if restErr != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(restErr.Status())
+			w.Write(jsonResponse)
+			return
+		}
+
+		responseObject = response_objects.NewSuccessResponseObject(r.Context(), i18n.MessageSessionsFetched, responseSessions)
+		jsonResponse, _ := json.Marshal(responseObject)
+		w.WriteHeader(http.StatusOK)
+		w.Write(jsonResponse)
+	}
+}
+
+func (h *sessionHandler) LogoutCurrent() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var responseObject *response_objects.ResponseObject
+		userId := r.Context().Value("user").(models.User).UserId
+		sessionId := r.Context().Value("session_id").(uuid.UUID)
+
+		restErr := h.sessionUC.RevokeSession(r.Context(), userId, sessionId)
+
+		if restErr != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(restErr.Status())
+			w.Write(jsonResponse)
+			return
+		}
+
+		responseObject = response_objects.NewSuccessResponseObject(r.Context(), i18n.MessageLoggedOut, nil)
+		jsonResponse, _ := json.Marshal(responseObject)
+		w.WriteHeader(http.StatusOK)
+		w.Write(jsonResponse)
+	}
+}
+
+func (h *sessionHandler) LogoutAll() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var responseObject *response_objects.ResponseObject
+		userId := r.Context().Value("user").(models.User).UserId
+
+		restErr := h.sessionUC.RevokeAllSessions(r.Context(), userId)
+
+		if restErr != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(restErr.Status())
+			w.Write(jsonResponse)
+			return
+		}
+
+		responseObject = response_objects.NewSuccessResponseObject(r.Context(), i18n.MessageLoggedOutEverywhere, nil)
+		jsonResponse, _ := json.Marshal(responseObject)
+		w.WriteHeader(http.StatusOK)
+		w.Write(jsonResponse)
+	}
+}
@@ -0,0 +1,16 @@
+package http
+
+import (
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"uzinfocom-todo/internal/session"
+)
+
+func MapRoutes(router *chi.Mux, h session.Handler, jwtMiddleware func(http.Handler) http.Handler) {
+	router.Route("/api/sessions", func(r chi.Router) {
+		r.Use(jwtMiddleware)
+		r.Get("/", h.GetSessions())
+		r.Delete("/current", h.LogoutCurrent())
+		r.Delete("/", h.LogoutAll())
+	})
+}
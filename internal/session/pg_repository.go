@@ -0,0 +1,16 @@
+package session
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"uzinfocom-todo/internal/models"
+)
+
+type Repository interface {
+	Create(ctx context.Context, session models.Session) (*models.Session, error)
+	GetWithUser(ctx context.Context, sessionId uuid.UUID) (*models.Session, *models.User, error)
+	GetActiveByUserId(ctx context.Context, userId uuid.UUID) ([]models.Session, error)
+	Touch(ctx context.Context, sessionId uuid.UUID) error
+	Revoke(ctx context.Context, userId uuid.UUID, sessionId uuid.UUID) (bool, error)
+	RevokeAllByUserId(ctx context.Context, userId uuid.UUID) ([]uuid.UUID, error)
+}
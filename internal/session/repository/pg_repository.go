@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"uzinfocom-todo/internal/models"
+	"uzinfocom-todo/internal/session"
+)
+
+type sessionRepository struct {
+	db *sqlx.DB
+}
+
+func NewSessionRepository(db *sqlx.DB) session.Repository {
+	return &sessionRepository{
+		db: db,
+	}
+}
+
+func (r *sessionRepository) Create(ctx context.Context, s models.Session) (*models.Session, error) {
+	query := `INSERT INTO sessions (session_id, user_id, device, ip_address, user_agent) VALUES ($1, $2, $3, $4, $5)
+		RETURNING session_id, user_id, device, ip_address, user_agent, created_at, last_seen_at, revoked_at`
+	createdSession := models.Session{}
+
+	if err := r.db.QueryRowxContext(
+		ctx,
+		query,
+		uuid.New(),
+		s.UserId,
+		s.Device,
+		s.IpAddress,
+		s.UserAgent,
+	).Scan(
+		&createdSession.SessionId,
+		&createdSession.UserId,
+		&createdSession.Device,
+		&createdSession.IpAddress,
+		&createdSession.UserAgent,
+		&createdSession.CreatedAt,
+		&createdSession.LastSeenAt,
+		&createdSession.RevokedAt,
+	); err != nil {
+		return nil, err
+	}
+	return &createdSession, nil
+}
+
+func (r *sessionRepository) GetWithUser(ctx context.Context, sessionId uuid.UUID) (*models.Session, *models.User, error) {
+	query := `SELECT s.session_id, s.user_id, s.device, s.ip_address, s.user_agent, s.created_at, s.last_seen_at, s.revoked_at,
+		u.name, u.phone_number, u.role, u.disabled_at, u.timezone, u.locale
+		FROM sessions s JOIN users u ON u.user_id = s.user_id WHERE s.session_id = $1`
+	s := models.Session{}
+	u := models.User{}
+
+	if err := r.db.QueryRowxContext(ctx, query, sessionId).Scan(
+		&s.SessionId,
+		&s.UserId,
+		&s.Device,
+		&s.IpAddress,
+		&s.UserAgent,
+		&s.CreatedAt,
+		&s.LastSeenAt,
+		&s.RevokedAt,
+		&u.Name,
+		&u.PhoneNumber,
+		&u.Role,
+		&u.DisabledAt,
+		&u.Timezone,
+		&u.Locale,
+	); err != nil {
+		return nil, nil, err
+	}
+
+	u.UserId = s.UserId
+	return &s, &u, nil
+}
+
+func (r *sessionRepository) GetActiveByUserId(ctx context.Context, userId uuid.UUID) ([]models.Session, error) {
+	query := `SELECT session_id, user_id, device, ip_address, user_agent, created_at, last_seen_at, revoked_at
+		FROM sessions WHERE user_id = $1 AND revoked_at IS NULL ORDER BY last_seen_at DESC`
+	sessions := []models.Session{}
+
+	rows, err := r.db.QueryxContext(ctx, query, userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		s := models.Session{}
+		if err = rows.Scan(
+			&s.SessionId,
+			&s.UserId,
+			&s.Device,
+			&s.IpAddress,
+			&s.UserAgent,
+			&s.CreatedAt,
+			&s.LastSeenAt,
+			&s.RevokedAt,
+		); err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, s)
+	}
+
+	return sessions, rows.Err()
+}
+
+func (r *sessionRepository) Touch(ctx context.Context, sessionId uuid.UUID) error {
+	query := `UPDATE sessions SET last_seen_at = CURRENT_TIMESTAMP WHERE session_id = $1`
+
+	if _, err := r.db.ExecContext(ctx, query, sessionId); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *sessionRepository) Revoke(ctx context.Context, userId uuid.UUID, sessionId uuid.UUID) (bool, error) {
+	query := `UPDATE sessions SET revoked_at = CURRENT_TIMESTAMP WHERE session_id = $1 AND user_id = $2 AND revoked_at IS NULL`
+
+	res, err := r.db.ExecContext(ctx, query, sessionId, userId)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+func (r *sessionRepository) RevokeAllByUserId(ctx context.Context, userId uuid.UUID) ([]uuid.UUID, error) {
+	query := `UPDATE sessions SET revoked_at = CURRENT_TIMESTAMP WHERE user_id = $1 AND revoked_at IS NULL RETURNING session_id`
+	sessionIds := []uuid.UUID{}
+
+	rows, err := r.db.QueryxContext(ctx, query, userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var sessionId uuid.UUID
+		if err = rows.Scan(&sessionId); err != nil {
+			return nil, err
+		}
+		sessionIds = append(sessionIds, sessionId)
+	}
+
+	return sessionIds, rows.Err()
+}
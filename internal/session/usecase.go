@@ -0,0 +1,18 @@
+package session
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"uzinfocom-todo/internal/models"
+	"uzinfocom-todo/internal/models/response_objects"
+	"uzinfocom-todo/pkg/http_errors"
+)
+
+type UseCase interface {
+	CreateSession(ctx context.Context, session models.Session) (*models.Session, http_errors.RestErr)
+	GetActiveUser(ctx context.Context, sessionId uuid.UUID) (*models.User, bool, http_errors.RestErr)
+	ForgetUser(userId uuid.UUID)
+	GetSessions(ctx context.Context, userId uuid.UUID, currentSessionId uuid.UUID) (*[]response_objects.ResponseSession, http_errors.RestErr)
+	RevokeSession(ctx context.Context, userId uuid.UUID, sessionId uuid.UUID) http_errors.RestErr
+	RevokeAllSessions(ctx context.Context, userId uuid.UUID) http_errors.RestErr
+}
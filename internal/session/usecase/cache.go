@@ -0,0 +1,82 @@
+package usecase
+
+import (
+	"github.com/google/uuid"
+	"sync"
+	"time"
+	"uzinfocom-todo/internal/models"
+)
+
+const maxCacheEntries = 10000
+
+type cacheEntry struct {
+	active    bool
+	user      models.User
+	expiresAt time.Time
+}
+
+// sessionCache remembers recent session lookups, with the profile of their user, so the auth middleware does not hit
+// the database on every request. Revocations and profile changes made by this instance are applied immediately,
+// those made by other instances become visible after ttl.
+type sessionCache struct {
+	mu      sync.RWMutex
+	ttl     time.Duration
+	entries map[uuid.UUID]cacheEntry
+}
+
+func newSessionCache(ttl time.Duration) *sessionCache {
+	return &sessionCache{
+		ttl:     ttl,
+		entries: make(map[uuid.UUID]cacheEntry),
+	}
+}
+
+func (c *sessionCache) get(sessionId uuid.UUID) (cacheEntry, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.entries[sessionId]
+	if !ok || time.Now().After(entry.expiresAt) {
+		return cacheEntry{}, false
+	}
+	return entry, true
+}
+
+func (c *sessionCache) set(sessionId uuid.UUID, active bool, user models.User) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.entries) >= maxCacheEntries {
+		c.evictExpired()
+	}
+
+	if len(c.entries) >= maxCacheEntries {
+		c.entries = make(map[uuid.UUID]cacheEntry)
+	}
+
+	c.entries[sessionId] = cacheEntry{
+		active:    active,
+		user:      user,
+		expiresAt: time.Now().Add(c.ttl),
+	}
+}
+
+func (c *sessionCache) evictExpired() {
+	now := time.Now()
+	for sessionId, entry := range c.entries {
+		if now.After(entry.expiresAt) {
+			delete(c.entries, sessionId)
+		}
+	}
+}
+
+func (c *sessionCache) forgetUser(userId uuid.UUID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for sessionId, entry := range c.entries {
+		if entry.user.UserId == userId {
+			delete(c.entries, sessionId)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"log"
+	"time"
+	"uzinfocom-todo/config"
+	"uzinfocom-todo/internal/models"
+	"uzinfocom-todo/internal/models/response_objects"
+	"uzinfocom-todo/internal/session"
+	"uzinfocom-todo/pkg/http_errors"
+)
+
+const defaultCacheTTL = 30 * time.Second
+
+type sessionUseCase struct {
+	sessionRepo session.Repository
+	cfg         *config.Config
+	cache       *sessionCache
+}
+
+func NewSessionUseCase(sessionRepo session.Repository, cfg *config.Config) session.UseCase {
+	ttl := cfg.Server.SessionCacheTTL
+	if ttl <= 0 {
+		ttl = defaultCacheTTL
+	}
+
+	return &sessionUseCase{
+		sessionRepo: sessionRepo,
+		cfg:         cfg,
+		cache:       newSessionCache(ttl),
+	}
+}
+
+func (uc *sessionUseCase) CreateSession(ctx context.Context, s models.Session) (*models.Session, http_errors.RestErr) {
+	createdSession, err := uc.sessionRepo.Create(ctx, s)
+
+	if err != nil {
+		return nil, http_errors.ParseErrors(err)
+	}
+
+	return createdSession, nil
+}
+
+// GetActiveUser returns the user of the session and whether the session has not been revoked.
+// Cache misses also refresh the session's last-seen time.
+func (uc *sessionUseCase) GetActiveUser(ctx context.Context, sessionId uuid.UUID) (*models.User, bool, http_errors.RestErr) {
+	if entry, ok := uc.cache.get(sessionId); ok {
+		u := entry.user
+		return &u, entry.active, nil
+	}
+
+	s, u, err := uc.sessionRepo.GetWithUser(ctx, sessionId)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			uc.cache.set(sessionId, false, models.User{})
+			return nil, false, nil
+		}
+		return nil, false, http_errors.ParseErrors(err)
+	}
+
+	active := s.RevokedAt == nil && u.DisabledAt == nil
+
+	if active {
+		if err = uc.sessionRepo.Touch(ctx, sessionId); err != nil {
+			log.Printf("session: updating last seen of %s failed: %v", sessionId, err)
+		}
+	}
+
+	uc.cache.set(sessionId, active, *u)
+	return u, active, nil
+}
+
+// ForgetUser drops the cached sessions of the user, so the next request loads their changed profile
+func (uc *sessionUseCase) ForgetUser(userId uuid.UUID) {
+	uc.cache.forgetUser(userId)
+}
+
+func (uc *sessionUseCase) GetSessions(ctx context.Context, userId uuid.UUID, currentSessionId uuid.UUID) (*[]response_objects.ResponseSession, http_errors.RestErr) {
+	sessions, err := uc.sessionRepo.GetActiveByUserId(ctx, userId)
+
+	if err != nil {
+		return nil, http_errors.ParseErrors(err)
+	}
+
+	responseSessions := []response_objects.ResponseSession{}
+
+	for _, s := range sessions {
+		responseSessions = append(responseSessions, response_objects.ResponseSession{
+			SessionId:  s.SessionId,
+			Device:     s.Device,
+			IpAddress:  s.IpAddress,
+			UserAgent:  s.UserAgent,
+			CreatedAt:  s.CreatedAt.Format("02-01-2006 15:04"),
+			LastSeenAt: s.LastSeenAt.Format("02-01-2006 15:04"),
+			Current:    s.SessionId == currentSessionId,
+		})
+	}
+
+	return &responseSessions, nil
+}
+
+func (uc *sessionUseCase) RevokeSession(ctx context.Context, userId uuid.UUID, sessionId uuid.UUID) http_errors.RestErr {
+	revoked, err := uc.sessionRepo.Revoke(ctx, userId, sessionId)
+
+	if err != nil {
+		return http_errors.ParseErrors(err)
+	}
+
+	if !revoked {
+		return http_errors.SessionNotFound()
+	}
+
+	uc.cache.set(sessionId, false, models.User{UserId: userId})
+	return nil
+}
+
+func (uc *sessionUseCase) RevokeAllSessions(ctx context.Context, userId uuid.UUID) http_errors.RestErr {
+	sessionIds, err := uc.sessionRepo.RevokeAllByUserId(ctx, userId)
+
+	if err != nil {
+		return http_errors.ParseErrors(err)
+	}
+
+	for _, sessionId := range sessionIds {
+		uc.cache.set(sessionId, false, models.User{UserId: userId})
+	}
+	return nil
+}
@@ -0,0 +1,12 @@
+package tag
+
+import "net/http"
+
+type Handler interface {
+	CreateTag() http.HandlerFunc
+	GetTags() http.HandlerFunc
+	RenameTag() http.HandlerFunc
+	DeleteTag() http.HandlerFunc
+	AttachTag() http.HandlerFunc
+	DetachTag() http.HandlerFunc
+}
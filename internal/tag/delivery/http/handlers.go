@@ -0,0 +1,247 @@
+package http
+
+import (
+	"encoding/json"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"net/http"
+	"uzinfocom-todo/config"
+	"uzinfocom-todo/internal/models"
+	"uzinfocom-todo/internal/models/request_objects"
+	"uzinfocom-todo/internal/models/response_objects"
+	"uzinfocom-todo/internal/tag"
+	"uzinfocom-todo/pkg/http_errors"
+	"uzinfocom-todo/pkg/i18n"
+)
+
+type tagHandler struct {
+	cfg   *config.Config
+	tagUC tag.UseCase
+}
+
+func NewTagHandler(cfg *config.Config, tagUC tag.UseCase) tag.Handler {
+	return &tagHandler{
+		cfg:   cfg,
+		tagUC: tagUC,
+	}
+}
+
+func (h *tagHandler) CreateTag() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var responseObject *response_objects.ResponseObject
+		var requestTag request_objects.RequestTag
+		userId := r.Context().Value("user").(models.User).UserId
+
+		err := json.NewDecoder(r.Body).Decode(&requestTag)
+
+		if err != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), http_errors.InvalidJson("tag"))
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(jsonResponse)
+			return
+		}
+
+		defer r.Body.Close()
+
+		responseTag, restErr := h.tagUC.CreateTag(r.Context(), userId, requestTag)
+
+		if restErr != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(restErr.Status())
+			w.Write(jsonResponse)
+			return
+		}
+
+		responseObject = response_objects.NewSuccessResponseObject(r.Context(), i18n.MessageTagCreated, responseTag)
+		jsonResponse, _ := json.Marshal(responseObject)
+		w.WriteHeader(http.StatusCreated)
+		w.Write(jsonResponse)
+	}
+}
+
+func (h *tagHandler) GetTags() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var responseObject *response_objects.ResponseObject
+		userId := r.Context().Value("user").(models.User).UserId
+
+		responseTags, restErr := h.tagUC.GetTags(r.Context(), userId)
+
+		if restErr != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(restErr.Status())
+			w.Write(jsonResponse)
+			return
+		}
+
+		responseObject = response_objects.NewSuccessResponseObject(r.Context(), i18n.MessageTagsFetched, responseTags)
+		jsonResponse, _ := json.Marshal(responseObject)
+		w.WriteHeader(http.StatusOK)
+		w.Write(jsonResponse)
+	}
+}
+
+func (h *tagHandler) RenameTag() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var responseObject *response_objects.ResponseObject
+		var requestTag request_objects.RequestTag
+		userId := r.Context().Value("user").(models.User).UserId
+		tagId, err := uuid.Parse(chi.URLParam(r, "tagId"))
+
+		if err != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), http_errors.InvalidTagId())
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(jsonResponse)
+			return
+		}
+
+		err = json.NewDecoder(r.Body).Decode(&requestTag)
+
+		if err != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), http_errors.InvalidJson("tag"))
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(jsonResponse)
+			return
+		}
+
+		defer r.Body.Close()
+
+		responseTag, restErr := h.tagUC.RenameTag(r.Context(), userId, tagId, requestTag)
+
+		if restErr != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(restErr.Status())
+			w.Write(jsonResponse)
+			return
+		}
+
+		responseObject = response_objects.NewSuccessResponseObject(r.Context(), i18n.MessageTagRenamed, responseTag)
+		jsonResponse, _ := json.Marshal(responseObject)
+		w.WriteHeader(http.StatusOK)
+		w.Write(jsonResponse)
+	}
+}
+
+func (h *tagHandler) DeleteTag() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var responseObject *response_objects.ResponseObject
+		userId := r.Context().Value("user").(models.User).UserId
+		tagId, err := uuid.Parse(chi.URLParam(r, "tagId"))
+
+		if err != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), http_errors.InvalidTagId())
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(jsonResponse)
+			return
+		}
+
+		restErr := h.tagUC.DeleteTag(r.Context(), userId, tagId)
+
+		if restErr != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(restErr.Status())
+			w.Write(jsonResponse)
+			return
+		}
+
+		responseObject = response_objects.NewSuccessResponseObject(r.Context(), i18n.MessageTagDeleted, nil)
+		jsonResponse, _ := json.Marshal(responseObject)
+		w.WriteHeader(http.StatusOK)
+		w.Write(jsonResponse)
+	}
+}
+
+func (h *tagHandler) AttachTag() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var responseObject *response_objects.ResponseObject
+		userId := r.Context().Value("user").(models.User).UserId
+		taskId, err := uuid.Parse(chi.URLParam(r, "taskId"))
+
+		if err != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), http_errors.InvalidTaskId())
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(jsonResponse)
+			return
+		}
+
+		tagId, err := uuid.Parse(chi.URLParam(r, "tagId"))
+
+		if err != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), http_errors.InvalidTagId())
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(jsonResponse)
+			return
+		}
+
+		restErr := h.tagUC.AttachTag(r.Context(), userId, taskId, tagId)
+
+		if restErr != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(restErr.Status())
+			w.Write(jsonResponse)
+			return
+		}
+
+		responseObject = response_objects.NewSuccessResponseObject(r.Context(), i18n.MessageTagAttached, nil)
+		jsonResponse, _ := json.Marshal(responseObject)
+		w.WriteHeader(http.StatusOK)
+		w.Write(jsonResponse)
+	}
+}
+
+func (h *tagHandler) DetachTag() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var responseObject *response_objects.ResponseObject
+		userId := r.Context().Value("user").(models.User).UserId
+		taskId, err := uuid.Parse(chi.URLParam(r, "taskId"))
+
+		if err != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), http_errors.InvalidTaskId())
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(jsonResponse)
+			return
+		}
+
+		tagId, err := uuid.Parse(chi.URLParam(r, "tagId"))
+
+		if err != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), http_errors.InvalidTagId())
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(jsonResponse)
+			return
+		}
+
+		restErr := h.tagUC.DetachTag(r.Context(), userId, taskId, tagId)
+
+		if restErr != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(restErr.Status())
+			w.Write(jsonResponse)
+			return
+		}
+
+		responseObject = response_objects.NewSuccessResponseObject(r.Context(), i18n.MessageTagDetached, nil)
+		jsonResponse, _ := json.Marshal(responseObject)
+		w.WriteHeader(http.StatusOK)
+		w.Write(jsonResponse)
+	}
+}
@@ -0,0 +1,25 @@
+package http
+
+import (
+	"github.com/go-chi/chi/v5"
+	"net/http"
+	"uzinfocom-todo/internal/middleware"
+	"uzinfocom-todo/internal/models"
+	"uzinfocom-todo/internal/tag"
+)
+
+func MapRoutes(router *chi.Mux, h tag.Handler, authMiddleware func(http.Handler) http.Handler) {
+	router.Route("/tags", func(r chi.Router) {
+		r.Use(authMiddleware)
+		r.With(middleware.RequireScopes(models.ScopeTasksWrite)).Post("/", h.CreateTag())
+		r.With(middleware.RequireScopes(models.ScopeTasksRead)).Get("/", h.GetTags())
+		r.With(middleware.RequireScopes(models.ScopeTasksWrite)).Patch("/{tagId}", h.RenameTag())
+		r.With(middleware.RequireScopes(models.ScopeTasksWrite)).Delete("/{tagId}", h.DeleteTag())
+	})
+
+	router.Route("/todo/{taskId}/tags", func(r chi.Router) {
+		r.Use(authMiddleware)
+		r.With(middleware.RequireScopes(models.ScopeTasksWrite)).Put("/{tagId}", h.AttachTag())
+		r.With(middleware.RequireScopes(models.ScopeTasksWrite)).Delete("/{tagId}", h.DetachTag())
+	})
+}
@@ -0,0 +1,17 @@
+package tag
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"uzinfocom-todo/internal/models"
+)
+
+type Repository interface {
+	Create(ctx context.Context, tag models.Tag) (*models.Tag, error)
+	GetByUserId(ctx context.Context, userId uuid.UUID) ([]models.Tag, error)
+	GetById(ctx context.Context, userId uuid.UUID, tagId uuid.UUID) (*models.Tag, error)
+	Rename(ctx context.Context, userId uuid.UUID, tagId uuid.UUID, name string) (bool, error)
+	Delete(ctx context.Context, userId uuid.UUID, tagId uuid.UUID) (bool, error)
+	Attach(ctx context.Context, userId uuid.UUID, taskId uuid.UUID, tagId uuid.UUID) (bool, error)
+	Detach(ctx context.Context, userId uuid.UUID, taskId uuid.UUID, tagId uuid.UUID) (bool, error)
+}
@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"uzinfocom-todo/internal/models"
+	"uzinfocom-todo/internal/tag"
+	"uzinfocom-todo/pkg/db/db_postgres"
+)
+
+// tagNameConstraint keeps the names of the tags of a user unique regardless of case
+const tagNameConstraint = "tags_user_id_name_idx"
+
+type tagRepository struct {
+	db *sqlx.DB
+}
+
+func NewTagRepository(db *sqlx.DB) tag.Repository {
+	return &tagRepository{
+		db: db,
+	}
+}
+
+func (r *tagRepository) Create(ctx context.Context, t models.Tag) (*models.Tag, error) {
+	query := `INSERT INTO tags (tag_id, user_id, name) VALUES ($1, $2, $3) RETURNING tag_id, user_id, name, created_at`
+	created := models.Tag{}
+
+	if err := r.db.QueryRowxContext(ctx, query, uuid.New(), t.UserId, t.Name).Scan(
+		&created.TagId,
+		&created.UserId,
+		&created.Name,
+		&created.CreatedAt,
+	); err != nil {
+		if db_postgres.IsUniqueViolation(err, tagNameConstraint) {
+			return nil, models.ErrTagExists
+		}
+		return nil, err
+	}
+	return &created, nil
+}
+
+func (r *tagRepository) GetByUserId(ctx context.Context, userId uuid.UUID) ([]models.Tag, error) {
+	query := `SELECT tag_id, user_id, name, created_at FROM tags WHERE user_id = $1 ORDER BY lower(name)`
+	tags := []models.Tag{}
+
+	rows, err := r.db.QueryxContext(ctx, query, userId)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		t := models.Tag{}
+		if err = rows.Scan(&t.TagId, &t.UserId, &t.Name, &t.CreatedAt); err != nil {
+			return nil, err
+		}
+		tags = append(tags, t)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return tags, nil
+}
+
+func (r *tagRepository) GetById(ctx context.Context, userId uuid.UUID, tagId uuid.UUID) (*models.Tag, error) {
+	query := `SELECT tag_id, user_id, name, created_at FROM tags WHERE tag_id = $1 AND user_id = $2`
+	t := models.Tag{}
+
+	if err := r.db.QueryRowxContext(ctx, query, tagId, userId).Scan(&t.TagId, &t.UserId, &t.Name, &t.CreatedAt); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+func (r *tagRepository) Rename(ctx context.Context, userId uuid.UUID, tagId uuid.UUID, name string) (bool, error) {
+	query := `UPDATE tags SET name = $1 WHERE tag_id = $2 AND user_id = $3`
+
+	res, err := r.db.ExecContext(ctx, query, name, tagId, userId)
+	if err != nil {
+		if db_postgres.IsUniqueViolation(err, tagNameConstraint) {
+			return false, models.ErrTagExists
+		}
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+func (r *tagRepository) Delete(ctx context.Context, userId uuid.UUID, tagId uuid.UUID) (bool, error) {
+	query := `DELETE FROM tags WHERE tag_id = $1 AND user_id = $2`
+
+	res, err := r.db.ExecContext(ctx, query, tagId, userId)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// Attach tags the task, attaching a tag twice is a no-op. It reports whether the task exists,
+// the tag is expected to be checked by the caller.
+func (r *tagRepository) Attach(ctx context.Context, userId uuid.UUID, taskId uuid.UUID, tagId uuid.UUID) (bool, error) {
+	query := `WITH task AS (
+			SELECT task_id FROM tasks WHERE task_id = $1 AND user_id = $2 AND deletedAt IS NULL
+		), attached AS (
+			INSERT INTO task_tags (task_id, tag_id) SELECT task_id, $3 FROM task ON CONFLICT DO NOTHING
+		)
+		SELECT EXISTS (SELECT 1 FROM task)`
+	var found bool
+
+	if err := r.db.QueryRowxContext(ctx, query, taskId, userId, tagId).Scan(&found); err != nil {
+		return false, err
+	}
+	return found, nil
+}
+
+// Detach removes the tag from the task and reports whether the task exists
+func (r *tagRepository) Detach(ctx context.Context, userId uuid.UUID, taskId uuid.UUID, tagId uuid.UUID) (bool, error) {
+	query := `WITH task AS (
+			SELECT task_id FROM tasks WHERE task_id = $1 AND user_id = $2 AND deletedAt IS NULL
+		), detached AS (
+			DELETE FROM task_tags WHERE task_id IN (SELECT task_id FROM task) AND tag_id = $3
+		)
+		SELECT EXISTS (SELECT 1 FROM task)`
+	var found bool
+
+	if err := r.db.QueryRowxContext(ctx, query, taskId, userId, tagId).Scan(&found); err != nil {
+		return false, err
+	}
+	return found, nil
+}
@@ -0,0 +1,18 @@
+package tag
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"uzinfocom-todo/internal/models/request_objects"
+	"uzinfocom-todo/internal/models/response_objects"
+	"uzinfocom-todo/pkg/http_errors"
+)
+
+type UseCase interface {
+	CreateTag(ctx context.Context, userId uuid.UUID, requestTag request_objects.RequestTag) (*response_objects.ResponseTag, http_errors.RestErr)
+	GetTags(ctx context.Context, userId uuid.UUID) (*[]response_objects.ResponseTag, http_errors.RestErr)
+	RenameTag(ctx context.Context, userId uuid.UUID, tagId uuid.UUID, requestTag request_objects.RequestTag) (*response_objects.ResponseTag, http_errors.RestErr)
+	DeleteTag(ctx context.Context, userId uuid.UUID, tagId uuid.UUID) http_errors.RestErr
+	AttachTag(ctx context.Context, userId uuid.UUID, taskId uuid.UUID, tagId uuid.UUID) http_errors.RestErr
+	DetachTag(ctx context.Context, userId uuid.UUID, taskId uuid.UUID, tagId uuid.UUID) http_errors.RestErr
+}
@@ -0,0 +1,146 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"strings"
+	"unicode/utf8"
+	"uzinfocom-todo/config"
+	"uzinfocom-todo/internal/models"
+	"uzinfocom-todo/internal/models/request_objects"
+	"uzinfocom-todo/internal/models/response_objects"
+	"uzinfocom-todo/internal/tag"
+	"uzinfocom-todo/pkg/http_errors"
+)
+
+const maxNameLength = 32
+
+type tagUseCase struct {
+	tagRepo tag.Repository
+	cfg     *config.Config
+}
+
+func NewTagUseCase(tagRepo tag.Repository, cfg *config.Config) tag.UseCase {
+	return &tagUseCase{
+		tagRepo: tagRepo,
+		cfg:     cfg,
+	}
+}
+
+func (uc *tagUseCase) CreateTag(ctx context.Context, userId uuid.UUID, requestTag request_objects.RequestTag) (*response_objects.ResponseTag, http_errors.RestErr) {
+	name, restErr := validateName(requestTag.Name)
+	if restErr != nil {
+		return nil, restErr
+	}
+
+	created, err := uc.tagRepo.Create(ctx, models.Tag{UserId: userId, Name: name})
+	if err != nil {
+		return nil, parseTagErrors(err)
+	}
+
+	responseTag := response_objects.NewResponseTag(*created)
+	return &responseTag, nil
+}
+
+func (uc *tagUseCase) GetTags(ctx context.Context, userId uuid.UUID) (*[]response_objects.ResponseTag, http_errors.RestErr) {
+	tags, err := uc.tagRepo.GetByUserId(ctx, userId)
+	if err != nil {
+		return nil, http_errors.ParseErrors(err)
+	}
+
+	responseTags := make([]response_objects.ResponseTag, 0, len(tags))
+	for _, t := range tags {
+		responseTags = append(responseTags, response_objects.NewResponseTag(t))
+	}
+	return &responseTags, nil
+}
+
+func (uc *tagUseCase) RenameTag(ctx context.Context, userId uuid.UUID, tagId uuid.UUID, requestTag request_objects.RequestTag) (*response_objects.ResponseTag, http_errors.RestErr) {
+	name, restErr := validateName(requestTag.Name)
+	if restErr != nil {
+		return nil, restErr
+	}
+
+	renamed, err := uc.tagRepo.Rename(ctx, userId, tagId, name)
+	if err != nil {
+		return nil, parseTagErrors(err)
+	}
+
+	if !renamed {
+		return nil, http_errors.TagNotFound()
+	}
+
+	responseTag := response_objects.ResponseTag{TagId: tagId, Name: name}
+	return &responseTag, nil
+}
+
+func (uc *tagUseCase) DeleteTag(ctx context.Context, userId uuid.UUID, tagId uuid.UUID) http_errors.RestErr {
+	deleted, err := uc.tagRepo.Delete(ctx, userId, tagId)
+	if err != nil {
+		return http_errors.ParseErrors(err)
+	}
+
+	if !deleted {
+		return http_errors.TagNotFound()
+	}
+	return nil
+}
+
+func (uc *tagUseCase) AttachTag(ctx context.Context, userId uuid.UUID, taskId uuid.UUID, tagId uuid.UUID) http_errors.RestErr {
+	if _, err := uc.tagRepo.GetById(ctx, userId, tagId); err != nil {
+		return parseTagErrors(err)
+	}
+
+	found, err := uc.tagRepo.Attach(ctx, userId, taskId, tagId)
+	if err != nil {
+		return http_errors.ParseErrors(err)
+	}
+
+	if !found {
+		return http_errors.TaskNotFound()
+	}
+	return nil
+}
+
+func (uc *tagUseCase) DetachTag(ctx context.Context, userId uuid.UUID, taskId uuid.UUID, tagId uuid.UUID) http_errors.RestErr {
+	if _, err := uc.tagRepo.GetById(ctx, userId, tagId); err != nil {
+		return parseTagErrors(err)
+	}
+
+	found, err := uc.tagRepo.Detach(ctx, userId, taskId, tagId)
+	if err != nil {
+		return http_errors.ParseErrors(err)
+	}
+
+	if !found {
+		return http_errors.TaskNotFound()
+	}
+	return nil
+}
+
+// validateName trims the name, commas are rejected because the task listing accepts comma separated tags
+func validateName(name string) (string, http_errors.RestErr) {
+	name = strings.TrimSpace(name)
+
+	if name == "" || utf8.RuneCountInString(name) > maxNameLength {
+		return "", http_errors.InvalidLength("name", maxNameLength)
+	}
+
+	if strings.Contains(name, ",") {
+		return "", http_errors.TagNameComma()
+	}
+	return name, nil
+}
+
+func parseTagErrors(err error) http_errors.RestErr {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return http_errors.TagNotFound()
+	case errors.Is(err, models.ErrTagExists):
+		return http_errors.TagAlreadyExists()
+	default:
+		return http_errors.ParseErrors(err)
+	}
+}
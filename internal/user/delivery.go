@@ -5,8 +5,25 @@ import "net/http"
 type Handler interface {
 	Register() http.HandlerFunc
 	Login() http.HandlerFunc
+	ChangePassword() http.HandlerFunc
+	GetProfile() http.HandlerFunc
+	UpdateProfile() http.HandlerFunc
+	DeleteAccount() http.HandlerFunc
 	CreateTask() http.HandlerFunc
 	GetAllTasks() http.HandlerFunc
+	GetTask() http.HandlerFunc
+	GetListTasks() http.HandlerFunc
+	SearchTasks() http.HandlerFunc
 	DeleteTask() http.HandlerFunc
 	UpdateTask() http.HandlerFunc
+	UpdateOccurrence() http.HandlerFunc
+	ExecuteTaskBatch() http.HandlerFunc
+	RankTask() http.HandlerFunc
+	MoveTask() http.HandlerFunc
+	RestoreTask() http.HandlerFunc
+	EmptyTrash() http.HandlerFunc
+	AdminGetUsers() http.HandlerFunc
+	AdminDisableUser() http.HandlerFunc
+	AdminEnableUser() http.HandlerFunc
+	AdminGetUserTaskCounts() http.HandlerFunc
 }
@@ -5,24 +5,34 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 	"uzinfocom-todo/config"
+	"uzinfocom-todo/internal/auth"
+	"uzinfocom-todo/internal/mfa"
 	"uzinfocom-todo/internal/models"
 	"uzinfocom-todo/internal/models/request_objects"
 	"uzinfocom-todo/internal/models/response_objects"
 	"uzinfocom-todo/internal/user"
+	"uzinfocom-todo/pkg/http_errors"
+	"uzinfocom-todo/pkg/i18n"
 	"uzinfocom-todo/pkg/util"
 )
 
 type userHandler struct {
 	cfg    *config.Config
 	userUC user.UseCase
+	authUC auth.UseCase
+	mfaUC  mfa.UseCase
 }
 
-func NewUserHandler(cfg *config.Config, userUC user.UseCase) user.Handler {
+func NewUserHandler(cfg *config.Config, userUC user.UseCase, authUC auth.UseCase, mfaUC mfa.UseCase) user.Handler {
 	return &userHandler{
 		cfg:    cfg,
 		userUC: userUC,
+		authUC: authUC,
+		mfaUC:  mfaUC,
 	}
 }
 
@@ -35,7 +45,7 @@ func (h *userHandler) Register() http.HandlerFunc {
 		err := json.NewDecoder(r.Body).Decode(&u)
 
 		if err != nil {
-			responseObject = response_objects.NewResponseObject(false, "json format is incorrect for user", nil)
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), http_errors.InvalidJson("user"))
 			jsonResponse, _ := json.Marshal(responseObject)
 			w.WriteHeader(http.StatusBadRequest)
 			w.Write(jsonResponse)
@@ -44,18 +54,26 @@ func (h *userHandler) Register() http.HandlerFunc {
 
 		defer r.Body.Close()
 
+		if restErr := u.Validate(); restErr != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(restErr.Status())
+			w.Write(jsonResponse)
+			return
+		}
+
 		restErr := h.userUC.Create(r.Context(), u)
 
 		if restErr != nil {
-			responseObject = response_objects.NewResponseObject(false, restErr.Error(), nil)
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
 			jsonResponse, _ := json.Marshal(responseObject)
-			w.WriteHeader(http.StatusBadRequest)
+			w.WriteHeader(restErr.Status())
 			w.Write(jsonResponse)
 			return
 		}
 
 		w.WriteHeader(http.StatusCreated)
-		responseObject = response_objects.NewResponseObject(true, "user created", nil)
+		responseObject = response_objects.NewSuccessResponseObject(r.Context(), i18n.MessageUserCreated, nil)
 		jsonResponse, _ := json.Marshal(responseObject)
 		w.Write(jsonResponse)
 	}
@@ -69,7 +87,7 @@ func (h *userHandler) Login() http.HandlerFunc {
 		err := json.NewDecoder(r.Body).Decode(&user)
 
 		if err != nil {
-			responseObject = response_objects.NewResponseObject(false, "json format is incorrect for user object", nil)
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), http_errors.InvalidJson("user object"))
 			jsonResponse, _ := json.Marshal(responseObject)
 			w.WriteHeader(http.StatusBadRequest)
 			w.Write(jsonResponse)
@@ -78,40 +96,177 @@ func (h *userHandler) Login() http.HandlerFunc {
 
 		defer r.Body.Close()
 
-		u, restErr := h.userUC.GetByPhoneNumber(r.Context(), user.PhoneNumber)
+		u, restErr := h.userUC.Login(r.Context(), user)
 
 		if restErr != nil {
-			responseObject = response_objects.NewResponseObject(false, restErr.Error(), nil)
+			http_errors.SetRetryAfter(w, restErr)
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
 			jsonResponse, _ := json.Marshal(responseObject)
 			w.WriteHeader(restErr.Status())
 			w.Write(jsonResponse)
 			return
 		}
 
-		accessToken, err := util.GenerateJWTToken(u, h.cfg)
+		if u.TotpEnabled {
+			challenge, restErr := h.mfaUC.IssueChallenge(r.Context(), u)
+
+			if restErr != nil {
+				responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+				jsonResponse, _ := json.Marshal(responseObject)
+				w.WriteHeader(restErr.Status())
+				w.Write(jsonResponse)
+				return
+			}
+
+			responseObject = response_objects.NewSuccessResponseObject(r.Context(), i18n.MessageTwoFactorRequired, challenge)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(http.StatusOK)
+			w.Write(jsonResponse)
+			return
+		}
+
+		tokens, restErr := h.authUC.IssueTokens(r.Context(), u, util.SessionFromRequest(r))
+
+		if restErr != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(restErr.Status())
+			w.Write(jsonResponse)
+			return
+		}
+
+		responseObject = response_objects.NewSuccessResponseObject(r.Context(), i18n.MessageTokensIssued, tokens)
+		jsonResponse, _ := json.Marshal(responseObject)
+		w.WriteHeader(http.StatusOK)
+		w.Write(jsonResponse)
+	}
+}
+
+func (h *userHandler) ChangePassword() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var responseObject *response_objects.ResponseObject
+		var requestPassword request_objects.RequestChangePassword
+		userId := r.Context().Value("user").(models.User).UserId
+
+		err := json.NewDecoder(r.Body).Decode(&requestPassword)
 
 		if err != nil {
-			responseObject = response_objects.NewResponseObject(false, err.Error(), nil)
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), http_errors.InvalidJson("password change"))
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(jsonResponse)
+			return
+		}
+
+		defer r.Body.Close()
+
+		restErr := h.userUC.ChangePassword(r.Context(), userId, requestPassword.OldPassword, requestPassword.NewPassword)
+
+		if restErr != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(restErr.Status())
+			w.Write(jsonResponse)
+			return
+		}
+
+		responseObject = response_objects.NewSuccessResponseObject(r.Context(), i18n.MessagePasswordChanged, nil)
+		jsonResponse, _ := json.Marshal(responseObject)
+		w.WriteHeader(http.StatusOK)
+		w.Write(jsonResponse)
+	}
+}
+
+func (h *userHandler) GetProfile() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var responseObject *response_objects.ResponseObject
+		userId := r.Context().Value("user").(models.User).UserId
+
+		profile, restErr := h.userUC.GetProfile(r.Context(), userId)
+
+		if restErr != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
 			jsonResponse, _ := json.Marshal(responseObject)
-			w.WriteHeader(http.StatusInternalServerError)
+			w.WriteHeader(restErr.Status())
 			w.Write(jsonResponse)
 			return
 		}
 
-		refreshToken, err := util.GenerateRefreshToken(u, h.cfg)
+		responseObject = response_objects.NewSuccessResponseObject(r.Context(), i18n.MessageProfileFetched, profile)
+		jsonResponse, _ := json.Marshal(responseObject)
+		w.WriteHeader(http.StatusOK)
+		w.Write(jsonResponse)
+	}
+}
+
+func (h *userHandler) UpdateProfile() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var responseObject *response_objects.ResponseObject
+		var requestProfile request_objects.RequestUpdateProfile
+		userId := r.Context().Value("user").(models.User).UserId
+
+		err := json.NewDecoder(r.Body).Decode(&requestProfile)
 
 		if err != nil {
-			responseObject = response_objects.NewResponseObject(false, err.Error(), nil)
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), http_errors.InvalidJson("profile update"))
 			jsonResponse, _ := json.Marshal(responseObject)
-			w.WriteHeader(http.StatusInternalServerError)
+			w.WriteHeader(http.StatusBadRequest)
 			w.Write(jsonResponse)
 			return
 		}
 
-		responseObject = response_objects.NewResponseObject(true, "Access and Refresh Tokens generated successfully", map[string]string{
-			"access_token":  accessToken,
-			"refresh_token": refreshToken,
-		})
+		defer r.Body.Close()
+
+		profile, restErr := h.userUC.UpdateProfile(r.Context(), userId, requestProfile)
+
+		if restErr != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(restErr.Status())
+			w.Write(jsonResponse)
+			return
+		}
+
+		responseObject = response_objects.NewSuccessResponseObject(r.Context(), i18n.MessageProfileUpdated, profile)
+		jsonResponse, _ := json.Marshal(responseObject)
+		w.WriteHeader(http.StatusOK)
+		w.Write(jsonResponse)
+	}
+}
+
+func (h *userHandler) DeleteAccount() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var responseObject *response_objects.ResponseObject
+		var requestDelete request_objects.RequestDeleteAccount
+		userId := r.Context().Value("user").(models.User).UserId
+
+		err := json.NewDecoder(r.Body).Decode(&requestDelete)
+
+		if err != nil || requestDelete.Password == "" {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), http_errors.PasswordRequiredToDelete())
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(jsonResponse)
+			return
+		}
+
+		defer r.Body.Close()
+
+		restErr := h.userUC.DeleteAccount(r.Context(), userId, requestDelete.Password)
+
+		if restErr != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(restErr.Status())
+			w.Write(jsonResponse)
+			return
+		}
+
+		responseObject = response_objects.NewSuccessResponseObject(r.Context(), i18n.MessageAccountDeleted, nil)
 		jsonResponse, _ := json.Marshal(responseObject)
 		w.WriteHeader(http.StatusOK)
 		w.Write(jsonResponse)
@@ -122,18 +277,131 @@ func (h *userHandler) GetAllTasks() http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
 		var responseObject *response_objects.ResponseObject
+		filter, filterErr := parseTaskFilter(r)
 
-		responseTasks, restErr := h.userUC.GetAllTasks(r.Context())
+		if filterErr != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), filterErr)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(filterErr.Status())
+			w.Write(jsonResponse)
+			return
+		}
+
+		responseTasks, nextCursor, restErr := h.userUC.GetAllTasks(r.Context(), filter)
 
 		if restErr != nil {
-			responseObject = response_objects.NewResponseObject(false, restErr.Error(), nil)
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
 			jsonResponse, _ := json.Marshal(responseObject)
 			w.WriteHeader(restErr.Status())
 			w.Write(jsonResponse)
 			return
 		}
 
-		responseObject = response_objects.NewResponseObject(true, "Tasks are fetched", responseTasks)
+		responseObject = response_objects.NewSuccessPageResponseObject(r.Context(), i18n.MessageTasksFetched, responseTasks, nextCursor)
+		jsonResponse, _ := json.Marshal(responseObject)
+		w.WriteHeader(http.StatusOK)
+		w.Write(jsonResponse)
+	}
+}
+
+func (h *userHandler) GetTask() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var responseObject *response_objects.ResponseObject
+		taskId, err := uuid.Parse(chi.URLParam(r, "taskId"))
+
+		if err != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), http_errors.InvalidTaskId())
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(jsonResponse)
+			return
+		}
+
+		responseTask, restErr := h.userUC.GetTask(r.Context(), taskId)
+
+		if restErr != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(restErr.Status())
+			w.Write(jsonResponse)
+			return
+		}
+
+		responseObject = response_objects.NewSuccessResponseObject(r.Context(), i18n.MessageTaskFetched, responseTask)
+		jsonResponse, _ := json.Marshal(responseObject)
+		w.WriteHeader(http.StatusOK)
+		w.Write(jsonResponse)
+	}
+}
+
+func (h *userHandler) GetListTasks() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var responseObject *response_objects.ResponseObject
+		listId, err := uuid.Parse(chi.URLParam(r, "listId"))
+
+		if err != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), http_errors.InvalidListId())
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(jsonResponse)
+			return
+		}
+
+		filter, filterErr := parseTaskFilter(r)
+
+		if filterErr != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), filterErr)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(filterErr.Status())
+			w.Write(jsonResponse)
+			return
+		}
+
+		responseTasks, nextCursor, restErr := h.userUC.GetListTasks(r.Context(), listId, filter)
+
+		if restErr != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(restErr.Status())
+			w.Write(jsonResponse)
+			return
+		}
+
+		responseObject = response_objects.NewSuccessPageResponseObject(r.Context(), i18n.MessageTasksFetched, responseTasks, nextCursor)
+		jsonResponse, _ := json.Marshal(responseObject)
+		w.WriteHeader(http.StatusOK)
+		w.Write(jsonResponse)
+	}
+}
+
+func (h *userHandler) SearchTasks() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var responseObject *response_objects.ResponseObject
+		text := strings.TrimSpace(r.URL.Query().Get("q"))
+		limit, offset := parseLimitOffset(r)
+
+		if text == "" {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), http_errors.SearchQueryRequired())
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(jsonResponse)
+			return
+		}
+
+		results, restErr := h.userUC.SearchTasks(r.Context(), text, limit, offset)
+
+		if restErr != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(restErr.Status())
+			w.Write(jsonResponse)
+			return
+		}
+
+		responseObject = response_objects.NewSuccessResponseObject(r.Context(), i18n.MessageTasksFound, results)
 		jsonResponse, _ := json.Marshal(responseObject)
 		w.WriteHeader(http.StatusOK)
 		w.Write(jsonResponse)
@@ -149,7 +417,7 @@ func (h *userHandler) CreateTask() http.HandlerFunc {
 		err := json.NewDecoder(r.Body).Decode(&requestTask)
 
 		if err != nil {
-			responseObject = response_objects.NewResponseObject(false, err.Error(), nil)
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), http_errors.InvalidJson("task"))
 			jsonResponse, _ := json.Marshal(responseObject)
 			w.WriteHeader(http.StatusBadRequest)
 			w.Write(jsonResponse)
@@ -158,28 +426,51 @@ func (h *userHandler) CreateTask() http.HandlerFunc {
 
 		defer r.Body.Close()
 
-		startTime, _ := time.Parse("02-01-2006 15:04", requestTask.StartTime)
-		endTime, _ := time.Parse("02-01-2006 15:04", requestTask.EndTime)
+		loc := models.LocationFromContext(r.Context())
+
+		if restErr := requestTask.Validate(loc); restErr != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(restErr.Status())
+			w.Write(jsonResponse)
+			return
+		}
+
+		// both times were checked by Validate
+		startTime, _ := models.ParseTime(requestTask.StartTime, loc)
+		endTime, _ := models.ParseTime(requestTask.EndTime, loc)
+
+		if requestTask.Priority == "" {
+			requestTask.Priority = models.TaskPriorityMedium
+		}
 
 		task := models.Task{
-			Title:       requestTask.Title,
-			Description: requestTask.Description,
-			StartTime:   startTime,
-			EndTime:     endTime,
-			IsDone:      false,
+			Title:        requestTask.Title,
+			Description:  requestTask.Description,
+			StartTime:    startTime,
+			EndTime:      endTime,
+			Status:       models.TaskStatusTodo,
+			Priority:     requestTask.Priority,
+			ListId:       requestTask.ListId,
+			AutoComplete: requestTask.AutoComplete,
+			Timezone:     loc.String(),
+		}
+
+		if requestTask.RecurrenceRule != "" {
+			task.RecurrenceRule = &requestTask.RecurrenceRule
 		}
 
 		restErr := h.userUC.CreateTask(r.Context(), task)
 
 		if restErr != nil {
-			responseObject = response_objects.NewResponseObject(false, restErr.Error(), nil)
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
 			jsonResponse, _ := json.Marshal(responseObject)
 			w.WriteHeader(restErr.Status())
 			w.Write(jsonResponse)
 			return
 		}
 
-		responseObject = response_objects.NewResponseObject(true, "task created successfully", nil)
+		responseObject = response_objects.NewSuccessResponseObject(r.Context(), i18n.MessageTaskCreated, nil)
 		jsonResponse, _ := json.Marshal(responseObject)
 		w.WriteHeader(http.StatusCreated)
 		w.Write(jsonResponse)
@@ -191,18 +482,210 @@ func (h *userHandler) DeleteTask() http.HandlerFunc {
 		w.Header().Set("Content-Type", "application/json")
 		var responseObject *response_objects.ResponseObject
 		taskId, _ := uuid.Parse(chi.URLParam(r, "taskId"))
+		permanent, _ := strconv.ParseBool(r.URL.Query().Get("permanent"))
+		messageId := i18n.MessageTaskDeleted
+
+		var restErr http_errors.RestErr
+
+		if permanent {
+			restErr = h.userUC.PurgeTask(r.Context(), taskId)
+			messageId = i18n.MessageTaskPurged
+		} else {
+			restErr = h.userUC.DeleteTask(r.Context(), taskId)
+		}
 
-		restErr := h.userUC.DeleteTask(r.Context(), taskId)
+		if restErr != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(restErr.Status())
+			w.Write(jsonResponse)
+			return
+		}
+
+		responseObject = response_objects.NewSuccessResponseObject(r.Context(), messageId, nil)
+		jsonResponse, _ := json.Marshal(responseObject)
+		w.WriteHeader(http.StatusOK)
+		w.Write(jsonResponse)
+	}
+}
+
+func (h *userHandler) ExecuteTaskBatch() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var responseObject *response_objects.ResponseObject
+		var requestBatch request_objects.RequestTaskBatch
+
+		err := json.NewDecoder(r.Body).Decode(&requestBatch)
+
+		if err != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), http_errors.InvalidJson("task batch"))
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(jsonResponse)
+			return
+		}
+
+		defer r.Body.Close()
+
+		batch, restErr := h.userUC.ExecuteTaskBatch(r.Context(), requestBatch)
 
 		if restErr != nil {
-			responseObject = response_objects.NewResponseObject(false, restErr.Error(), nil)
+			var data interface{}
+			// batch carries the per-operation results when the batch was rolled back
+			if batch != nil {
+				data = batch
+			}
+
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+			responseObject.Data = data
 			jsonResponse, _ := json.Marshal(responseObject)
 			w.WriteHeader(restErr.Status())
 			w.Write(jsonResponse)
 			return
 		}
 
-		responseObject = response_objects.NewResponseObject(true, "task deleted successfully", nil)
+		responseObject = response_objects.NewSuccessResponseObject(r.Context(), i18n.MessageBatchExecuted, batch)
+		jsonResponse, _ := json.Marshal(responseObject)
+		w.WriteHeader(http.StatusOK)
+		w.Write(jsonResponse)
+	}
+}
+
+func (h *userHandler) RankTask() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var responseObject *response_objects.ResponseObject
+		var requestTaskRank request_objects.RequestTaskRank
+		taskId, err := uuid.Parse(chi.URLParam(r, "taskId"))
+
+		if err != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), http_errors.InvalidTaskId())
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(jsonResponse)
+			return
+		}
+
+		err = json.NewDecoder(r.Body).Decode(&requestTaskRank)
+
+		if err != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), http_errors.InvalidJson("task rank"))
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(jsonResponse)
+			return
+		}
+
+		defer r.Body.Close()
+
+		responseTask, restErr := h.userUC.RankTask(r.Context(), taskId, requestTaskRank.AfterId)
+
+		if restErr != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(restErr.Status())
+			w.Write(jsonResponse)
+			return
+		}
+
+		responseObject = response_objects.NewSuccessResponseObject(r.Context(), i18n.MessageTaskReordered, responseTask)
+		jsonResponse, _ := json.Marshal(responseObject)
+		w.WriteHeader(http.StatusOK)
+		w.Write(jsonResponse)
+	}
+}
+
+func (h *userHandler) MoveTask() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var responseObject *response_objects.ResponseObject
+		var requestMoveTask request_objects.RequestMoveTask
+		taskId, err := uuid.Parse(chi.URLParam(r, "taskId"))
+
+		if err != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), http_errors.InvalidTaskId())
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(jsonResponse)
+			return
+		}
+
+		err = json.NewDecoder(r.Body).Decode(&requestMoveTask)
+
+		if err != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), http_errors.InvalidJson("task move"))
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(jsonResponse)
+			return
+		}
+
+		defer r.Body.Close()
+
+		restErr := h.userUC.MoveTask(r.Context(), taskId, requestMoveTask.ListId)
+
+		if restErr != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(restErr.Status())
+			w.Write(jsonResponse)
+			return
+		}
+
+		responseObject = response_objects.NewSuccessResponseObject(r.Context(), i18n.MessageTaskMoved, nil)
+		jsonResponse, _ := json.Marshal(responseObject)
+		w.WriteHeader(http.StatusOK)
+		w.Write(jsonResponse)
+	}
+}
+
+func (h *userHandler) RestoreTask() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var responseObject *response_objects.ResponseObject
+		taskId, err := uuid.Parse(chi.URLParam(r, "taskId"))
+
+		if err != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), http_errors.InvalidTaskId())
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(jsonResponse)
+			return
+		}
+
+		restErr := h.userUC.RestoreTask(r.Context(), taskId)
+
+		if restErr != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(restErr.Status())
+			w.Write(jsonResponse)
+			return
+		}
+
+		responseObject = response_objects.NewSuccessResponseObject(r.Context(), i18n.MessageTaskRestored, nil)
+		jsonResponse, _ := json.Marshal(responseObject)
+		w.WriteHeader(http.StatusOK)
+		w.Write(jsonResponse)
+	}
+}
+
+func (h *userHandler) EmptyTrash() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var responseObject *response_objects.ResponseObject
+
+		purged, restErr := h.userUC.EmptyTrash(r.Context())
+
+		if restErr != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(restErr.Status())
+			w.Write(jsonResponse)
+			return
+		}
+
+		responseObject = response_objects.NewSuccessResponseObject(r.Context(), i18n.MessageTrashEmptied, purged)
 		jsonResponse, _ := json.Marshal(responseObject)
 		w.WriteHeader(http.StatusOK)
 		w.Write(jsonResponse)
@@ -216,11 +699,9 @@ func (h *userHandler) UpdateTask() http.HandlerFunc {
 		var requestTask request_objects.RequestTaskForUpdate
 		taskId, _ := uuid.Parse(chi.URLParam(r, "taskId"))
 		err := json.NewDecoder(r.Body).Decode(&requestTask)
-		var startTime time.Time
-		var endTime time.Time
 
 		if err != nil {
-			responseObject = response_objects.NewResponseObject(false, err.Error(), nil)
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), http_errors.InvalidJson("task"))
 			jsonResponse, _ := json.Marshal(responseObject)
 			w.WriteHeader(http.StatusBadRequest)
 			w.Write(jsonResponse)
@@ -229,10 +710,20 @@ func (h *userHandler) UpdateTask() http.HandlerFunc {
 
 		defer r.Body.Close()
 
+		loc := models.LocationFromContext(r.Context())
+
+		if restErr := requestTask.Validate(loc); restErr != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(restErr.Status())
+			w.Write(jsonResponse)
+			return
+		}
+
 		t, restErr := h.userUC.GetTaskById(r.Context(), taskId)
 
 		if restErr != nil {
-			responseObject = response_objects.NewResponseObject(false, restErr.Error(), nil)
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
 			jsonResponse, _ := json.Marshal(responseObject)
 			w.WriteHeader(restErr.Status())
 			w.Write(jsonResponse)
@@ -247,38 +738,222 @@ func (h *userHandler) UpdateTask() http.HandlerFunc {
 			t.Description = requestTask.Description
 		}
 
-		if requestTask.IsDone == true {
-			now := time.Now()
+		if requestTask.Status != "" {
+			t.Status = requestTask.Status
+		} else if requestTask.IsDone == true {
+			t.Status = models.TaskStatusDone
+		}
+
+		if requestTask.Priority != "" {
+			t.Priority = requestTask.Priority
+		}
+
+		if requestTask.AutoComplete != nil {
+			t.AutoComplete = *requestTask.AutoComplete
+		}
+
+		if requestTask.RecurrenceRule != nil {
+			t.RecurrenceRule = requestTask.RecurrenceRule
+			if *requestTask.RecurrenceRule == "" {
+				t.RecurrenceRule = nil
+			}
+		}
+
+		// Validate makes sure the times are either both given and valid or both left out
+		if requestTask.StartTime != "" {
+			t.StartTime, _ = models.ParseTime(requestTask.StartTime, loc)
+			t.EndTime, _ = models.ParseTime(requestTask.EndTime, loc)
+		}
 
-			if now.Before(t.StartTime) {
-				responseObject = response_objects.NewResponseObject(true, "to set up isDone to true you have to be in the interval of task times", nil)
+		restErr = h.userUC.UpdateTask(r.Context(), *t)
+
+		if restErr != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(restErr.Status())
+			w.Write(jsonResponse)
+			return
+		}
+
+		responseObject = response_objects.NewSuccessResponseObject(r.Context(), i18n.MessageTaskUpdated, nil)
+		jsonResponse, _ := json.Marshal(responseObject)
+		w.WriteHeader(http.StatusOK)
+		w.Write(jsonResponse)
+	}
+}
+
+// UpdateOccurrence edits or completes one occurrence of a recurring task, identified by its start in UTC as 20060102T150405Z
+func (h *userHandler) UpdateOccurrence() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var responseObject *response_objects.ResponseObject
+		var requestOccurrence request_objects.RequestTaskOccurrence
+		taskId, err := uuid.Parse(chi.URLParam(r, "taskId"))
+
+		if err != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), http_errors.InvalidTaskId())
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(jsonResponse)
+			return
+		}
+
+		occurrenceStart, err := time.Parse(models.OccurrenceIdFormat, chi.URLParam(r, "occurrenceId"))
+
+		if err != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), http_errors.InvalidOccurrenceId())
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(jsonResponse)
+			return
+		}
+
+		err = json.NewDecoder(r.Body).Decode(&requestOccurrence)
+
+		if err != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), http_errors.InvalidJson("task occurrence"))
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(jsonResponse)
+			return
+		}
+
+		defer r.Body.Close()
+
+		occurrence := models.TaskOccurrence{
+			OccurrenceStart: occurrenceStart,
+			Title:           requestOccurrence.Title,
+			Description:     requestOccurrence.Description,
+		}
+
+		if requestOccurrence.Status != "" {
+			occurrence.Status = &requestOccurrence.Status
+		} else if requestOccurrence.IsDone {
+			status := models.TaskStatusDone
+			occurrence.Status = &status
+		}
+
+		for _, field := range []struct {
+			name  string
+			value string
+			dest  **time.Time
+		}{
+			{"start_time", requestOccurrence.StartTime, &occurrence.StartTime},
+			{"end_time", requestOccurrence.EndTime, &occurrence.EndTime},
+		} {
+			if field.value == "" {
+				continue
+			}
+
+			t, err := models.ParseTime(field.value, models.LocationFromContext(r.Context()))
+
+			if err != nil {
+				responseObject = response_objects.NewErrorResponseObject(r.Context(), http_errors.InvalidTime(field.name))
 				jsonResponse, _ := json.Marshal(responseObject)
 				w.WriteHeader(http.StatusBadRequest)
 				w.Write(jsonResponse)
 				return
 			}
+			*field.dest = &t
+		}
+
+		responseTask, restErr := h.userUC.UpdateOccurrence(r.Context(), taskId, occurrence)
 
-			t.IsDone = true
+		if restErr != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(restErr.Status())
+			w.Write(jsonResponse)
+			return
 		}
 
-		if requestTask.StartTime != "" && requestTask.EndTime != "" {
-			startTime, _ = time.Parse("02-01-2006 15:04", requestTask.StartTime)
-			endTime, _ = time.Parse("02-01-2006 15:04", requestTask.EndTime)
-			t.StartTime = startTime
-			t.EndTime = endTime
+		responseObject = response_objects.NewSuccessResponseObject(r.Context(), i18n.MessageOccurrenceUpdated, responseTask)
+		jsonResponse, _ := json.Marshal(responseObject)
+		w.WriteHeader(http.StatusOK)
+		w.Write(jsonResponse)
+	}
+}
+
+func (h *userHandler) AdminGetUsers() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var responseObject *response_objects.ResponseObject
+		limit, offset := parseLimitOffset(r)
+
+		responseUsers, restErr := h.userUC.GetUsers(r.Context(), limit, offset)
+
+		if restErr != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(restErr.Status())
+			w.Write(jsonResponse)
+			return
 		}
 
-		restErr = h.userUC.UpdateTask(r.Context(), *t)
+		responseObject = response_objects.NewSuccessResponseObject(r.Context(), i18n.MessageUsersFetched, responseUsers)
+		jsonResponse, _ := json.Marshal(responseObject)
+		w.WriteHeader(http.StatusOK)
+		w.Write(jsonResponse)
+	}
+}
+
+func (h *userHandler) AdminDisableUser() http.HandlerFunc {
+	return h.adminSetDisabled(true, i18n.MessageUserDisabled)
+}
+
+func (h *userHandler) AdminEnableUser() http.HandlerFunc {
+	return h.adminSetDisabled(false, i18n.MessageUserEnabled)
+}
+
+func (h *userHandler) adminSetDisabled(disabled bool, messageId string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var responseObject *response_objects.ResponseObject
+		currentUserId := r.Context().Value("user").(models.User).UserId
+		userId, _ := uuid.Parse(chi.URLParam(r, "userId"))
+
+		if disabled && userId == currentUserId {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), http_errors.CannotDisableSelf())
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write(jsonResponse)
+			return
+		}
+
+		restErr := h.userUC.SetDisabled(r.Context(), userId, disabled)
+
+		if restErr != nil {
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
+			jsonResponse, _ := json.Marshal(responseObject)
+			w.WriteHeader(restErr.Status())
+			w.Write(jsonResponse)
+			return
+		}
+
+		responseObject = response_objects.NewSuccessResponseObject(r.Context(), messageId, nil)
+		jsonResponse, _ := json.Marshal(responseObject)
+		w.WriteHeader(http.StatusOK)
+		w.Write(jsonResponse)
+	}
+}
+
+func (h *userHandler) AdminGetUserTaskCounts() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		var responseObject *response_objects.ResponseObject
+		userId, _ := uuid.Parse(chi.URLParam(r, "userId"))
+
+		counts, restErr := h.userUC.GetTaskCounts(r.Context(), userId)
 
 		if restErr != nil {
-			responseObject = response_objects.NewResponseObject(false, restErr.Error(), nil)
+			responseObject = response_objects.NewErrorResponseObject(r.Context(), restErr)
 			jsonResponse, _ := json.Marshal(responseObject)
 			w.WriteHeader(restErr.Status())
 			w.Write(jsonResponse)
 			return
 		}
 
-		responseObject = response_objects.NewResponseObject(true, "task updated successfully", nil)
+		responseObject = response_objects.NewSuccessResponseObject(r.Context(), i18n.MessageTaskCountsFetched, counts)
 		jsonResponse, _ := json.Marshal(responseObject)
 		w.WriteHeader(http.StatusOK)
 		w.Write(jsonResponse)
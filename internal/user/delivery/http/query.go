@@ -0,0 +1,174 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"uzinfocom-todo/internal/models"
+	"uzinfocom-todo/pkg/http_errors"
+)
+
+const (
+	defaultLimit = 50
+	maxLimit     = 200
+
+	// maxExpandWindow bounds the from-to window in which recurring tasks are expanded
+	maxExpandWindow = 366 * 24 * time.Hour
+)
+
+func parseLimitOffset(r *http.Request) (int, int) {
+	limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+	if err != nil || limit <= 0 {
+		limit = defaultLimit
+	}
+
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+
+	offset, err := strconv.Atoi(r.URL.Query().Get("offset"))
+	if err != nil || offset < 0 {
+		offset = 0
+	}
+
+	return limit, offset
+}
+
+// parseTaskFilter reads the task listing query: status, tag, tag_mode, recurring, expand, from, to, q, sort, order,
+// limit and cursor
+func parseTaskFilter(r *http.Request) (models.TaskFilter, http_errors.RestErr) {
+	query := r.URL.Query()
+	filter := models.TaskFilter{
+		Status: query.Get("status"),
+		Query:  strings.TrimSpace(query.Get("q")),
+		Sort:   query.Get("sort"),
+		Order:  strings.ToLower(query.Get("order")),
+	}
+
+	if filter.Status == "" {
+		filter.Status = models.TaskStatusOpen
+	}
+
+	if !models.IsValidTaskStatus(filter.Status) {
+		return filter, http_errors.InvalidValue("status", models.TaskStatusOpen, models.TaskStatusTodo, models.TaskStatusInProgress,
+			models.TaskStatusBlocked, models.TaskStatusDone, models.TaskStatusCancelled, models.TaskStatusDeleted, models.TaskStatusAll)
+	}
+
+	if filter.Sort == "" {
+		filter.Sort = models.TaskSortStartTime
+	}
+
+	if !models.IsValidTaskSort(filter.Sort) {
+		return filter, http_errors.InvalidValue("sort", models.TaskSortStartTime, models.TaskSortEndTime, models.TaskSortTitle, models.TaskSortRank)
+	}
+
+	if filter.Order == "" {
+		filter.Order = models.SortAsc
+	}
+
+	if filter.Order != models.SortAsc && filter.Order != models.SortDesc {
+		return filter, http_errors.InvalidValue("order", models.SortAsc, models.SortDesc)
+	}
+
+	filter.Tags = parseTagNames(query["tag"])
+	filter.TagMode = strings.ToLower(query.Get("tag_mode"))
+
+	if filter.TagMode == "" {
+		filter.TagMode = models.TagModeAny
+	}
+
+	if filter.TagMode != models.TagModeAny && filter.TagMode != models.TagModeAll {
+		return filter, http_errors.InvalidValue("tag_mode", models.TagModeAny, models.TagModeAll)
+	}
+
+	var restErr http_errors.RestErr
+
+	if filter.From, restErr = parseQueryTime(r, "from"); restErr != nil {
+		return filter, restErr
+	}
+
+	if filter.To, restErr = parseQueryTime(r, "to"); restErr != nil {
+		return filter, restErr
+	}
+
+	if filter.From != nil && filter.To != nil && filter.To.Before(*filter.From) {
+		return filter, http_errors.InvalidPeriod()
+	}
+
+	filter.Limit, _ = parseLimitOffset(r)
+
+	if value := query.Get("recurring"); value != "" {
+		recurring, err := strconv.ParseBool(value)
+		if err != nil {
+			return filter, http_errors.InvalidValue("recurring", "true", "false")
+		}
+		filter.Recurring = &recurring
+	}
+
+	if value := query.Get("expand"); value != "" {
+		expand, err := strconv.ParseBool(value)
+		if err != nil {
+			return filter, http_errors.InvalidValue("expand", "true", "false")
+		}
+		filter.Expand = expand
+	}
+
+	if filter.Expand {
+		if filter.From == nil || filter.To == nil {
+			return filter, http_errors.ExpandRequiresPeriod()
+		}
+
+		if filter.To.Sub(*filter.From) > maxExpandWindow {
+			return filter, http_errors.ExpandWindowTooLong(int(maxExpandWindow / (24 * time.Hour)))
+		}
+
+		if query.Get("cursor") != "" {
+			return filter, http_errors.CursorWithExpand()
+		}
+	}
+
+	if value := query.Get("cursor"); value != "" {
+		cursor, err := models.DecodeTaskCursor(value)
+		if err != nil {
+			return filter, http_errors.InvalidCursor()
+		}
+
+		if cursor.Sort != filter.Sort || cursor.Order != filter.Order {
+			return filter, http_errors.CursorSortMismatch()
+		}
+		filter.Cursor = cursor
+	}
+
+	return filter, nil
+}
+
+func parseQueryTime(r *http.Request, name string) (*time.Time, http_errors.RestErr) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	t, err := models.ParseTime(value, models.LocationFromContext(r.Context()))
+	if err != nil {
+		return nil, http_errors.InvalidTime(name)
+	}
+	return &t, nil
+}
+
+func parseTagNames(values []string) []string {
+	seen := map[string]bool{}
+	names := []string{}
+
+	for _, value := range values {
+		for _, name := range strings.Split(value, ",") {
+			name = strings.ToLower(strings.TrimSpace(name))
+			if name == "" || seen[name] {
+				continue
+			}
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,79 @@
+package http
+
+import (
+	"github.com/google/uuid"
+	"net/http/httptest"
+	"testing"
+	"uzinfocom-todo/internal/models"
+)
+
+func TestParseTaskFilterDefaults(t *testing.T) {
+	filter, err := parseTaskFilter(httptest.NewRequest("GET", "/todo/", nil))
+	if err != nil {
+		t.Fatalf("parseTaskFilter: %v", err)
+	}
+
+	if filter.Status != models.TaskStatusOpen || filter.Sort != models.TaskSortStartTime || filter.Order != models.SortAsc {
+		t.Errorf("status, sort, order = %s, %s, %s, want open, start_time, asc", filter.Status, filter.Sort, filter.Order)
+	}
+
+	if filter.Limit != defaultLimit {
+		t.Errorf("limit = %d, want %d", filter.Limit, defaultLimit)
+	}
+
+	if filter.From != nil || filter.To != nil || filter.Cursor != nil {
+		t.Errorf("from, to and cursor must be unset, got %v, %v, %v", filter.From, filter.To, filter.Cursor)
+	}
+}
+
+func TestParseTaskFilter(t *testing.T) {
+	cursor := models.TaskCursor{Sort: models.TaskSortTitle, Order: models.SortDesc, Value: "standup", TaskId: uuid.New()}.Encode()
+
+	tests := []struct {
+		name    string
+		query   string
+		wantErr bool
+	}{
+		{"cursor of the same ordering", "?sort=title&order=desc&cursor=" + cursor, false},
+		{"unknown status", "?status=archived", true},
+		{"unknown sort", "?sort=priority", true},
+		{"unknown order", "?order=up", true},
+		{"invalid time", "?from=2024-03-01", true},
+		{"to before from", "?from=02-03-2024%2009:00&to=01-03-2024%2009:00", true},
+		{"invalid cursor", "?cursor=not-a-cursor", true},
+		{"cursor of another ordering", "?sort=title&cursor=" + cursor, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := parseTaskFilter(httptest.NewRequest("GET", "/todo/"+tt.query, nil))
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, want error %v", err, tt.wantErr)
+			}
+
+			if err == nil && (filter.Limit <= 0 || filter.Limit > maxLimit) {
+				t.Errorf("limit = %d, want it within 1..%d", filter.Limit, maxLimit)
+			}
+		})
+	}
+}
+
+func TestParseTaskFilterReadsQuery(t *testing.T) {
+	filter, err := parseTaskFilter(httptest.NewRequest("GET", "/todo/?from=01-03-2024%2009:00&to=31-03-2024%2018:00&q=%20standup%20&sort=end_time&order=DESC&limit=100000", nil))
+	if err != nil {
+		t.Fatalf("parseTaskFilter: %v", err)
+	}
+
+	if filter.Query != "standup" || filter.Sort != models.TaskSortEndTime || filter.Order != models.SortDesc || filter.Limit != maxLimit {
+		t.Errorf("got query %q, sort %s, order %s, limit %d", filter.Query, filter.Sort, filter.Order, filter.Limit)
+	}
+
+	if filter.From == nil || filter.From.Format("02-01-2006 15:04") != "01-03-2024 09:00" {
+		t.Errorf("from = %v, want 01-03-2024 09:00", filter.From)
+	}
+
+	if filter.To == nil || filter.To.Format("02-01-2006 15:04") != "31-03-2024 18:00" {
+		t.Errorf("to = %v, want 31-03-2024 18:00", filter.To)
+	}
+}
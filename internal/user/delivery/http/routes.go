@@ -2,22 +2,57 @@ package http
 
 import (
 	"github.com/go-chi/chi/v5"
-	"uzinfocom-todo/config"
+	"net/http"
 	"uzinfocom-todo/internal/middleware"
+	"uzinfocom-todo/internal/models"
 	"uzinfocom-todo/internal/user"
 )
 
-func MapRoutes(router *chi.Mux, h user.Handler, cfg *config.Config) {
+func MapRoutes(router *chi.Mux, h user.Handler, jwtMiddleware func(http.Handler) http.Handler, authMiddleware func(http.Handler) http.Handler, rateLimitMiddleware func(http.Handler) http.Handler) {
 	router.Route("/api", func(r chi.Router) {
-		r.Post("/register", h.Register())
-		r.Post("/login", h.Login())
+		r.With(rateLimitMiddleware).Post("/register", h.Register())
+		r.With(rateLimitMiddleware).Post("/login", h.Login())
+
+		r.Group(func(r chi.Router) {
+			r.Use(jwtMiddleware)
+			r.Put("/password", h.ChangePassword())
+		})
+	})
+
+	router.Route("/api/me", func(r chi.Router) {
+		r.Use(jwtMiddleware)
+		r.Get("/", h.GetProfile())
+		r.Patch("/", h.UpdateProfile())
+		r.Delete("/", h.DeleteAccount())
+	})
+
+	router.Route("/api/admin", func(r chi.Router) {
+		r.Use(jwtMiddleware)
+		r.Use(middleware.RequireScopes(models.ScopeAdminUsers))
+		r.Get("/users", h.AdminGetUsers())
+		r.Post("/users/{userId}/disable", h.AdminDisableUser())
+		r.Post("/users/{userId}/enable", h.AdminEnableUser())
+		r.Get("/users/{userId}/task-counts", h.AdminGetUserTaskCounts())
 	})
 
 	router.Route("/todo", func(r chi.Router) {
-		r.Use(middleware.AuthJwtMiddleware(cfg.Server.JwtSecretKey))
-		r.Post("/", h.CreateTask())
-		r.Get("/", h.GetAllTasks())
-		r.Delete("/{taskId}", h.DeleteTask())
-		r.Put("/{taskId}", h.UpdateTask())
+		r.Use(authMiddleware)
+		r.With(middleware.RequireScopes(models.ScopeTasksWrite)).Post("/", h.CreateTask())
+		r.With(middleware.RequireScopes(models.ScopeTasksWrite)).Post("/batch", h.ExecuteTaskBatch())
+		r.With(middleware.RequireScopes(models.ScopeTasksRead)).Get("/", h.GetAllTasks())
+		r.With(middleware.RequireScopes(models.ScopeTasksRead)).Get("/search", h.SearchTasks())
+		r.With(middleware.RequireScopes(models.ScopeTasksRead)).Get("/{taskId}", h.GetTask())
+		r.With(middleware.RequireScopes(models.ScopeTasksWrite)).Delete("/trash", h.EmptyTrash())
+		r.With(middleware.RequireScopes(models.ScopeTasksWrite)).Delete("/{taskId}", h.DeleteTask())
+		r.With(middleware.RequireScopes(models.ScopeTasksWrite)).Post("/{taskId}/move", h.MoveTask())
+		r.With(middleware.RequireScopes(models.ScopeTasksWrite)).Post("/{taskId}/rank", h.RankTask())
+		r.With(middleware.RequireScopes(models.ScopeTasksWrite)).Post("/{taskId}/restore", h.RestoreTask())
+		r.With(middleware.RequireScopes(models.ScopeTasksWrite)).Put("/{taskId}", h.UpdateTask())
+		r.With(middleware.RequireScopes(models.ScopeTasksWrite)).Put("/{taskId}/occurrences/{occurrenceId}", h.UpdateOccurrence())
+	})
+
+	router.Route("/lists/{listId}/tasks", func(r chi.Router) {
+		r.Use(authMiddleware)
+		r.With(middleware.RequireScopes(models.ScopeTasksRead)).Get("/", h.GetListTasks())
 	})
 }
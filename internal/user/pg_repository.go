@@ -4,17 +4,41 @@ import (
 	"context"
 	"database/sql"
 	"github.com/google/uuid"
+	"time"
 	"uzinfocom-todo/internal/models"
 	"uzinfocom-todo/internal/models/response_objects"
 )
 
 type Repository interface {
+	InTx(ctx context.Context, fn func(repo Repository) error) error
 	Create(ctx context.Context, user models.User) error
 	GetByPhoneNumber(ctx context.Context, phone string) (*models.User, error)
+	GetById(ctx context.Context, userId uuid.UUID) (*models.User, error)
+	UpdatePassword(ctx context.Context, userId uuid.UUID, password string) error
+	UpdateProfile(ctx context.Context, user models.User) error
+	DisownTasks(ctx context.Context, userId uuid.UUID) error
+	DeleteUser(ctx context.Context, userId uuid.UUID) (sql.Result, error)
+	GetLoginLock(ctx context.Context, userId uuid.UUID) (time.Duration, error)
+	RegisterFailedLogin(ctx context.Context, userId uuid.UUID, maxFailures int, lockout time.Duration, maxLockout time.Duration) (time.Duration, error)
+	ResetFailedLogins(ctx context.Context, userId uuid.UUID) error
+	GetUsers(ctx context.Context, limit int, offset int) (*[]response_objects.ResponseUser, error)
+	SetDisabled(ctx context.Context, userId uuid.UUID, disabled bool) (sql.Result, error)
+	GetTaskCounts(ctx context.Context, userId uuid.UUID) (*response_objects.ResponseTaskCounts, error)
 	CreateTask(ctx context.Context, task models.Task) error
-	GetAllTasks(ctx context.Context) (*[]response_objects.ResponseTask, error)
+	GetAllTasks(ctx context.Context, filter models.TaskFilter) (*[]response_objects.ResponseTask, string, error)
+	FindTasks(ctx context.Context, filter models.TaskFilter) ([]models.Task, string, error)
 	DeleteTask(ctx context.Context, taskId uuid.UUID) (sql.Result, error)
+	SearchTasks(ctx context.Context, text string, limit int, offset int) (*[]response_objects.ResponseTaskSearchResult, error)
 	CheckForDeleteTask(ctx context.Context, taskId uuid.UUID) (int, error)
 	GetTaskById(ctx context.Context, taskId uuid.UUID) (*models.Task, error)
 	UpdateTask(ctx context.Context, task models.Task) (sql.Result, error)
+	SaveOccurrence(ctx context.Context, occurrence models.TaskOccurrence) error
+	NextRank(ctx context.Context, status string, after *float64, taskId uuid.UUID) (*float64, error)
+	SetTaskRank(ctx context.Context, taskId uuid.UUID, rank float64) (sql.Result, error)
+	RebalanceRanks(ctx context.Context, status string) error
+	MoveTask(ctx context.Context, taskId uuid.UUID, listId uuid.UUID) (sql.Result, error)
+	RestoreTask(ctx context.Context, taskId uuid.UUID) (sql.Result, error)
+	PurgeTask(ctx context.Context, taskId uuid.UUID) (sql.Result, error)
+	EmptyTrash(ctx context.Context) (int64, error)
+	PurgeDeletedTasks(ctx context.Context, olderThan time.Duration) (int64, error)
 }
@@ -5,73 +5,238 @@ import (
 	"database/sql"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
+	"strconv"
+	"strings"
+	"time"
 	"uzinfocom-todo/internal/models"
 	"uzinfocom-todo/internal/models/response_objects"
 	"uzinfocom-todo/internal/user"
+	"uzinfocom-todo/pkg/db/db_postgres"
 )
 
+// phoneNumberConstraint keeps the phone numbers of users unique
+const phoneNumberConstraint = "users_phone_number_key"
+
+// taskColumns lists the columns scanned into models.Task by taskScanDest, in scan order
+const taskColumns = `task_id, title, description, start_time, end_time, status, priority, rank, deletedAt, user_id, list_id, auto_complete, recurrence_rule, timezone`
+
+func taskScanDest(t *models.Task) []interface{} {
+	return []interface{}{
+		&t.TaskID,
+		&t.Title,
+		&t.Description,
+		&t.StartTime,
+		&t.EndTime,
+		&t.Status,
+		&t.Priority,
+		&t.Rank,
+		&t.DeletedAt,
+		&t.UserId,
+		&t.ListId,
+		&t.AutoComplete,
+		&t.RecurrenceRule,
+		&t.Timezone,
+	}
+}
+
 type userRepository struct {
 	db *sqlx.DB
+	q  db_postgres.Queryer
+
+	// set on repositories handed out by InTx
+	tx         *sqlx.Tx
+	savepoints *int
 }
 
 func NewUserRepository(db *sqlx.DB) user.Repository {
 	return &userRepository{
 		db: db,
+		q:  db,
 	}
 }
 
+// InTx called on a repository that is already bound to a transaction runs fn inside a savepoint,
+// so a failing fn only undoes its own changes
+func (r *userRepository) InTx(ctx context.Context, fn func(repo user.Repository) error) error {
+	if r.tx != nil {
+		return r.inSavepoint(ctx, fn)
+	}
+
+	return db_postgres.InTx(ctx, r.db, func(tx *sqlx.Tx) error {
+		savepoints := 0
+		return fn(&userRepository{db: r.db, q: tx, tx: tx, savepoints: &savepoints})
+	})
+}
+
+func (r *userRepository) inSavepoint(ctx context.Context, fn func(repo user.Repository) error) error {
+	*r.savepoints++
+	name := "sp_" + strconv.Itoa(*r.savepoints)
+
+	if _, err := r.tx.ExecContext(ctx, `SAVEPOINT `+name); err != nil {
+		return err
+	}
+
+	if err := fn(r); err != nil {
+		if _, rollbackErr := r.tx.ExecContext(ctx, `ROLLBACK TO SAVEPOINT `+name); rollbackErr != nil {
+			return rollbackErr
+		}
+		return err
+	}
+
+	_, err := r.tx.ExecContext(ctx, `RELEASE SAVEPOINT `+name)
+	return err
+}
+
+// Create inserts the user together with its inbox list
 func (r *userRepository) Create(ctx context.Context, user models.User) error {
-	newUserUUID := uuid.New()
-	createdUser := models.User{}
-	createUserQuery := `INSERT INTO users (user_id, name, phone_number) VALUES ($1, $2, $3) RETURNING *`
+	createUserQuery := `WITH created AS (
+			INSERT INTO users (user_id, name, phone_number, password, role) VALUES ($1, $2, $3, $4, $5) RETURNING user_id
+		)
+		INSERT INTO lists (list_id, user_id, name, is_inbox) SELECT $6, user_id, $7, TRUE FROM created`
 
-	if err := r.db.QueryRowxContext(
+	if _, err := r.q.ExecContext(
 		ctx,
 		createUserQuery,
-		&newUserUUID,
+		uuid.New(),
 		&user.Name,
 		&user.PhoneNumber,
-	).Scan(&createdUser.UserId, &createdUser.Name, &createdUser.PhoneNumber); err != nil {
+		&user.Password,
+		&user.Role,
+		uuid.New(),
+		models.InboxListName,
+	); err != nil {
+		if db_postgres.IsUniqueViolation(err, phoneNumberConstraint) {
+			return models.ErrPhoneTaken
+		}
 		return err
 	}
 	return nil
 }
 
 func (r *userRepository) GetByPhoneNumber(ctx context.Context, phone string) (*models.User, error) {
-	query := `SELECT * FROM users WHERE phone_number = $1`
+	query := `SELECT user_id, name, phone_number, COALESCE(password, ''), role, disabled_at, totp_enabled_at IS NOT NULL, timezone, locale, COALESCE(email, '')
+		FROM users WHERE phone_number = $1`
 	user := models.User{}
 
-	if err := r.db.QueryRowxContext(ctx, query, phone).Scan(&user.UserId, &user.Name, &user.PhoneNumber); err != nil {
+	if err := r.q.QueryRowxContext(ctx, query, phone).Scan(
+		&user.UserId,
+		&user.Name,
+		&user.PhoneNumber,
+		&user.Password,
+		&user.Role,
+		&user.DisabledAt,
+		&user.TotpEnabled,
+		&user.Timezone,
+		&user.Locale,
+		&user.Email,
+	); err != nil {
 		return nil, err
 	}
 	return &user, nil
 }
 
-func (r *userRepository) GetTaskById(ctx context.Context, taskId uuid.UUID) (*models.Task, error) {
-	query := `SELECT * FROM tasks WHERE task_id = $1`
-	task := models.Task{}
+func (r *userRepository) GetById(ctx context.Context, userId uuid.UUID) (*models.User, error) {
+	query := `SELECT user_id, name, phone_number, COALESCE(password, ''), role, disabled_at, totp_enabled_at IS NOT NULL, timezone, locale, COALESCE(email, '')
+		FROM users WHERE user_id = $1`
+	user := models.User{}
 
-	if err := r.db.QueryRowxContext(ctx, query, taskId).Scan(
-		&task.TaskID,
-		&task.Title,
-		&task.Description,
-		&task.StartTime,
-		&task.EndTime,
-		&task.IsDone,
-		&task.DeletedAt,
-		&task.UserId,
+	if err := r.q.QueryRowxContext(ctx, query, userId).Scan(
+		&user.UserId,
+		&user.Name,
+		&user.PhoneNumber,
+		&user.Password,
+		&user.Role,
+		&user.DisabledAt,
+		&user.TotpEnabled,
+		&user.Timezone,
+		&user.Locale,
+		&user.Email,
 	); err != nil {
 		return nil, err
 	}
-	return &task, nil
+	return &user, nil
 }
 
-func (r *userRepository) GetAllTasks(ctx context.Context) (*[]response_objects.ResponseTask, error) {
-	userId := ctx.Value("user").(models.User).UserId
-	query := `SELECT * FROM tasks WHERE user_id = $1 AND isDone IS FALSE AND deletedAt IS NULL`
-	tasks := []response_objects.ResponseTask{}
+func (r *userRepository) UpdatePassword(ctx context.Context, userId uuid.UUID, password string) error {
+	query := `UPDATE users SET password = $1 WHERE user_id = $2`
+
+	if _, err := r.q.ExecContext(ctx, query, password, userId); err != nil {
+		return err
+	}
+	return nil
+}
 
-	rows, err := r.db.QueryxContext(ctx, query, userId)
+func (r *userRepository) UpdateProfile(ctx context.Context, user models.User) error {
+	query := `UPDATE users SET name = $1, timezone = $2, locale = $3, email = NULLIF($5, '') WHERE user_id = $4`
+
+	if _, err := r.q.ExecContext(ctx, query, user.Name, user.Timezone, user.Locale, user.UserId, user.Email); err != nil {
+		return err
+	}
+	return nil
+}
+
+// DisownTasks keeps the tasks of the user without an owner, so they survive the deletion of the account
+func (r *userRepository) DisownTasks(ctx context.Context, userId uuid.UUID) error {
+	query := `UPDATE tasks SET user_id = NULL WHERE user_id = $1`
+
+	if _, err := r.q.ExecContext(ctx, query, userId); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *userRepository) DeleteUser(ctx context.Context, userId uuid.UUID) (sql.Result, error) {
+	query := `DELETE FROM users WHERE user_id = $1`
+
+	return r.q.ExecContext(ctx, query, userId)
+}
+
+func (r *userRepository) GetLoginLock(ctx context.Context, userId uuid.UUID) (time.Duration, error) {
+	query := `SELECT GREATEST(EXTRACT(EPOCH FROM (locked_until - CURRENT_TIMESTAMP)), 0)::float8 FROM users WHERE user_id = $1`
+	var seconds float64
+
+	if err := r.q.QueryRowxContext(ctx, query, userId).Scan(&seconds); err != nil {
+		return 0, err
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+// RegisterFailedLogin counts a failed login and, once maxFailures is reached, locks the account.
+// Every further failure doubles the lock up to maxLockout. Returns how long the account stays locked.
+func (r *userRepository) RegisterFailedLogin(ctx context.Context, userId uuid.UUID, maxFailures int, lockout time.Duration, maxLockout time.Duration) (time.Duration, error) {
+	query := `UPDATE users SET
+			failed_login_attempts = failed_login_attempts + 1,
+			locked_until = CASE WHEN failed_login_attempts + 1 >= $2
+				THEN CURRENT_TIMESTAMP + LEAST(
+					make_interval(secs => $3 * power(2, LEAST(failed_login_attempts + 1 - $2, 30))),
+					make_interval(secs => $4)
+				)
+				ELSE locked_until END
+		WHERE user_id = $1
+		RETURNING GREATEST(EXTRACT(EPOCH FROM (locked_until - CURRENT_TIMESTAMP)), 0)::float8`
+	var seconds float64
+
+	if err := r.q.QueryRowxContext(ctx, query, userId, maxFailures, lockout.Seconds(), maxLockout.Seconds()).Scan(&seconds); err != nil {
+		return 0, err
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+func (r *userRepository) ResetFailedLogins(ctx context.Context, userId uuid.UUID) error {
+	query := `UPDATE users SET failed_login_attempts = 0, locked_until = NULL
+		WHERE user_id = $1 AND (failed_login_attempts > 0 OR locked_until IS NOT NULL)`
+
+	if _, err := r.q.ExecContext(ctx, query, userId); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *userRepository) GetUsers(ctx context.Context, limit int, offset int) (*[]response_objects.ResponseUser, error) {
+	query := `SELECT user_id, name, phone_number, role, disabled_at FROM users ORDER BY name, user_id LIMIT $1 OFFSET $2`
+	users := []response_objects.ResponseUser{}
+
+	rows, err := r.q.QueryxContext(ctx, query, limit, offset)
 
 	if err != nil {
 		return nil, err
@@ -80,44 +245,489 @@ func (r *userRepository) GetAllTasks(ctx context.Context) (*[]response_objects.R
 	defer rows.Close()
 
 	for rows.Next() {
-		t := models.Task{}
+		u := models.User{}
 		if err = rows.Scan(
-			&t.TaskID,
-			&t.Title,
-			&t.Description,
-			&t.StartTime,
-			&t.EndTime,
-			&t.IsDone,
-			&t.DeletedAt,
-			&t.UserId,
+			&u.UserId,
+			&u.Name,
+			&u.PhoneNumber,
+			&u.Role,
+			&u.DisabledAt,
 		); err != nil {
 			return nil, err
 		}
 
-		task := response_objects.ResponseTask{
-			TaskID:      t.TaskID,
-			Title:       t.Title,
-			Description: t.Description,
-			StartTime:   t.StartTime.Format("02-01-2006 15:04"),
-			EndTime:     t.EndTime.Format("02-01-2006 15:04"),
-			IsDone:      t.IsDone,
-			DeletedAt:   t.DeletedAt,
+		users = append(users, response_objects.ResponseUser{
+			UserId:      u.UserId,
+			Name:        u.Name,
+			PhoneNumber: u.PhoneNumber,
+			Role:        u.Role,
+			Disabled:    u.DisabledAt != nil,
+		})
+	}
+
+	return &users, rows.Err()
+}
+
+func (r *userRepository) SetDisabled(ctx context.Context, userId uuid.UUID, disabled bool) (sql.Result, error) {
+	query := `UPDATE users SET disabled_at = CASE WHEN $1 THEN COALESCE(disabled_at, CURRENT_TIMESTAMP) END WHERE user_id = $2`
+
+	res, err := r.q.ExecContext(ctx, query, disabled, userId)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *userRepository) GetTaskCounts(ctx context.Context, userId uuid.UUID) (*response_objects.ResponseTaskCounts, error) {
+	query := `SELECT
+			count(*) FILTER (WHERE status IN ('todo', 'in_progress', 'blocked') AND deletedAt IS NULL),
+			count(*) FILTER (WHERE status = 'done' AND deletedAt IS NULL),
+			count(*) FILTER (WHERE deletedAt IS NOT NULL),
+			count(*)
+		FROM tasks WHERE user_id = $1`
+	counts := response_objects.ResponseTaskCounts{UserId: userId}
+
+	if err := r.q.QueryRowxContext(ctx, query, userId).Scan(
+		&counts.Open,
+		&counts.Done,
+		&counts.Deleted,
+		&counts.Total,
+	); err != nil {
+		return nil, err
+	}
+	return &counts, nil
+}
+
+func (r *userRepository) GetTaskById(ctx context.Context, taskId uuid.UUID) (*models.Task, error) {
+	userId := ctx.Value("user").(models.User).UserId
+	query := `SELECT ` + taskColumns + ` FROM tasks WHERE task_id = $1 AND user_id = $2`
+	task := models.Task{}
+
+	if err := r.q.QueryRowxContext(ctx, query, taskId, userId).Scan(taskScanDest(&task)...); err != nil {
+		return nil, err
+	}
+
+	tasks := []models.Task{task}
+	if err := r.loadTaskDetails(ctx, tasks); err != nil {
+		return nil, err
+	}
+	return &tasks[0], nil
+}
+
+func (r *userRepository) loadTaskDetails(ctx context.Context, tasks []models.Task) error {
+	if err := r.loadTaskTags(ctx, tasks); err != nil {
+		return err
+	}
+
+	if err := r.loadChecklistProgress(ctx, tasks); err != nil {
+		return err
+	}
+	return r.loadTaskOverrides(ctx, tasks)
+}
+
+func (r *userRepository) loadTaskOverrides(ctx context.Context, tasks []models.Task) error {
+	ids := []string{}
+	index := make(map[uuid.UUID]int)
+	for i := range tasks {
+		if tasks[i].RecurrenceRule != nil {
+			ids = append(ids, tasks[i].TaskID.String())
+			index[tasks[i].TaskID] = i
+		}
+	}
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	query := `SELECT task_id, occurrence_start, title, description, start_time, end_time, status
+		FROM task_occurrences
+		WHERE task_id = ANY(string_to_array($1, ',')::uuid[])
+		ORDER BY occurrence_start`
+
+	rows, err := r.q.QueryxContext(ctx, query, strings.Join(ids, ","))
+
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		o := models.TaskOccurrence{}
+		if err = rows.Scan(
+			&o.TaskId,
+			&o.OccurrenceStart,
+			&o.Title,
+			&o.Description,
+			&o.StartTime,
+			&o.EndTime,
+			&o.Status,
+		); err != nil {
+			return err
+		}
+
+		i := index[o.TaskId]
+		tasks[i].Overrides = append(tasks[i].Overrides, o)
+	}
+
+	return rows.Err()
+}
+
+func (r *userRepository) SaveOccurrence(ctx context.Context, occurrence models.TaskOccurrence) error {
+	query := `INSERT INTO task_occurrences (task_id, occurrence_start, title, description, start_time, end_time, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (task_id, occurrence_start) DO UPDATE SET
+			title = EXCLUDED.title,
+			description = EXCLUDED.description,
+			start_time = EXCLUDED.start_time,
+			end_time = EXCLUDED.end_time,
+			status = EXCLUDED.status`
+
+	_, err := r.q.ExecContext(
+		ctx,
+		query,
+		occurrence.TaskId,
+		occurrence.OccurrenceStart,
+		occurrence.Title,
+		occurrence.Description,
+		occurrence.StartTime,
+		occurrence.EndTime,
+		occurrence.Status,
+	)
+	return err
+}
+
+func (r *userRepository) loadChecklistProgress(ctx context.Context, tasks []models.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(tasks))
+	index := make(map[uuid.UUID]int, len(tasks))
+	for i := range tasks {
+		ids = append(ids, tasks[i].TaskID.String())
+		index[tasks[i].TaskID] = i
+	}
+
+	query := `SELECT task_id, COUNT(*), COUNT(*) FILTER (WHERE is_done)
+		FROM checklist_items
+		WHERE task_id = ANY(string_to_array($1, ',')::uuid[]) AND deleted_at IS NULL
+		GROUP BY task_id`
+
+	rows, err := r.q.QueryxContext(ctx, query, strings.Join(ids, ","))
+
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var taskId uuid.UUID
+		var total, done int
+		if err = rows.Scan(&taskId, &total, &done); err != nil {
+			return err
+		}
+
+		i := index[taskId]
+		tasks[i].ChecklistTotal = total
+		tasks[i].ChecklistDone = done
+	}
+
+	return rows.Err()
+}
+
+func (r *userRepository) loadTaskTags(ctx context.Context, tasks []models.Task) error {
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(tasks))
+	index := make(map[uuid.UUID]int, len(tasks))
+	for i := range tasks {
+		ids = append(ids, tasks[i].TaskID.String())
+		index[tasks[i].TaskID] = i
+		tasks[i].Tags = []models.Tag{}
+	}
+
+	query := `SELECT tt.task_id, g.tag_id, g.user_id, g.name, g.created_at
+		FROM task_tags tt JOIN tags g ON g.tag_id = tt.tag_id
+		WHERE tt.task_id = ANY(string_to_array($1, ',')::uuid[])
+		ORDER BY lower(g.name)`
+
+	rows, err := r.q.QueryxContext(ctx, query, strings.Join(ids, ","))
+
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var taskId uuid.UUID
+		tag := models.Tag{}
+		if err = rows.Scan(&taskId, &tag.TagId, &tag.UserId, &tag.Name, &tag.CreatedAt); err != nil {
+			return err
+		}
+
+		i := index[taskId]
+		tasks[i].Tags = append(tasks[i].Tags, tag)
+	}
+
+	return rows.Err()
+}
+
+var taskStatusFilters = map[string]string{
+	models.TaskStatusOpen:       ` AND status IN ('todo', 'in_progress', 'blocked') AND deletedAt IS NULL`,
+	models.TaskStatusTodo:       ` AND status = 'todo' AND deletedAt IS NULL`,
+	models.TaskStatusInProgress: ` AND status = 'in_progress' AND deletedAt IS NULL`,
+	models.TaskStatusBlocked:    ` AND status = 'blocked' AND deletedAt IS NULL`,
+	models.TaskStatusDone:       ` AND status = 'done' AND deletedAt IS NULL`,
+	models.TaskStatusCancelled:  ` AND status = 'cancelled' AND deletedAt IS NULL`,
+	models.TaskStatusDeleted:    ` AND deletedAt IS NOT NULL`,
+	models.TaskStatusAll:        ``,
+}
+
+var taskSortColumns = map[string]string{
+	models.TaskSortStartTime: "start_time",
+	models.TaskSortEndTime:   "end_time",
+	models.TaskSortTitle:     "title",
+	models.TaskSortRank:      "rank",
+}
+
+func (r *userRepository) GetAllTasks(ctx context.Context, filter models.TaskFilter) (*[]response_objects.ResponseTask, string, error) {
+	tasks, nextCursor, err := r.FindTasks(ctx, filter)
+	if err != nil {
+		return nil, "", err
+	}
+
+	responseTasks := make([]response_objects.ResponseTask, 0, len(tasks))
+	for _, t := range tasks {
+		responseTasks = append(responseTasks, response_objects.NewResponseTask(ctx, t))
+	}
+
+	return &responseTasks, nextCursor, nil
+}
+
+// FindTasks lists the current user's tasks matching filter ordered by filter.Sort and then task_id,
+// which keeps the order stable for keyset pagination. The returned cursor is empty on the last page.
+func (r *userRepository) FindTasks(ctx context.Context, filter models.TaskFilter) ([]models.Task, string, error) {
+	userId := ctx.Value("user").(models.User).UserId
+	args := []interface{}{userId}
+	query := `SELECT ` + taskColumns + ` FROM tasks WHERE user_id = $1` + taskStatusFilters[filter.Status]
+
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return "$" + strconv.Itoa(len(args))
+	}
+
+	if filter.ListId != nil {
+		query += ` AND list_id = ` + arg(*filter.ListId)
+	}
+
+	if filter.Recurring != nil {
+		if *filter.Recurring {
+			query += ` AND recurrence_rule IS NOT NULL`
+		} else {
+			query += ` AND recurrence_rule IS NULL`
+		}
+	}
+
+	if len(filter.Tags) > 0 {
+		names := make([]string, 0, len(filter.Tags))
+		for _, name := range filter.Tags {
+			names = append(names, arg(name))
 		}
 
-		tasks = append(tasks, task)
+		matching := `SELECT COUNT(DISTINCT g.tag_id) FROM task_tags tt JOIN tags g ON g.tag_id = tt.tag_id
+			WHERE tt.task_id = tasks.task_id AND lower(g.name) IN (` + strings.Join(names, ", ") + `)`
+
+		if filter.TagMode == models.TagModeAll {
+			query += ` AND (` + matching + `) = ` + strconv.Itoa(len(filter.Tags))
+		} else {
+			query += ` AND (` + matching + `) > 0`
+		}
 	}
 
-	return &tasks, nil
+	if filter.From != nil {
+		query += ` AND end_time >= ` + arg(*filter.From)
+	}
 
+	if filter.To != nil {
+		query += ` AND start_time <= ` + arg(*filter.To)
+	}
+
+	if filter.Query != "" {
+		pattern := arg("%" + escapeLike(filter.Query) + "%")
+		query += ` AND (title ILIKE ` + pattern + ` OR description ILIKE ` + pattern + `)`
+	}
+
+	sortColumn, ok := taskSortColumns[filter.Sort]
+	if !ok {
+		sortColumn = taskSortColumns[models.TaskSortStartTime]
+	}
+
+	direction, comparison := "ASC", ">"
+	if filter.Order == models.SortDesc {
+		direction, comparison = "DESC", "<"
+	}
+
+	if filter.Cursor != nil {
+		value := arg(filter.Cursor.Value)
+		switch sortColumn {
+		case "title":
+		case "rank":
+			value += "::float8"
+		default:
+			// the cursor carries its offset, so it compares as the same instant whatever the session time zone
+			value += "::timestamptz"
+		}
+		query += ` AND (` + sortColumn + `, task_id) ` + comparison + ` (` + value + `, ` + arg(filter.Cursor.TaskId) + `)`
+	}
+
+	query += ` ORDER BY ` + sortColumn + ` ` + direction + `, task_id ` + direction
+
+	if filter.Limit > 0 {
+		// one extra row tells whether there is a next page
+		query += ` LIMIT ` + arg(filter.Limit+1)
+	}
+
+	tasks := []models.Task{}
+
+	rows, err := r.q.QueryxContext(ctx, query, args...)
+
+	if err != nil {
+		return nil, "", err
+	}
+
+	defer rows.Close()
+
+	var nextCursor string
+
+	for rows.Next() {
+		if filter.Limit > 0 && len(tasks) == filter.Limit {
+			nextCursor = models.NewTaskCursor(tasks[len(tasks)-1], filter.Sort, filter.Order).Encode()
+			break
+		}
+
+		t := models.Task{}
+		if err = rows.Scan(taskScanDest(&t)...); err != nil {
+			return nil, "", err
+		}
+
+		tasks = append(tasks, t)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, "", err
+	}
+
+	rows.Close()
+
+	if err = r.loadTaskDetails(ctx, tasks); err != nil {
+		return nil, "", err
+	}
+
+	return tasks, nextCursor, nil
 }
 
+// SearchTasks parses the query with both the english and russian configurations so either language matches.
+// Highlights are html-escaped apart from the <b> marks.
+func (r *userRepository) SearchTasks(ctx context.Context, text string, limit int, offset int) (*[]response_objects.ResponseTaskSearchResult, error) {
+	userId := ctx.Value("user").(models.User).UserId
+	query := `WITH q AS (
+			SELECT websearch_to_tsquery('english', $2) AS en, websearch_to_tsquery('russian', $2) AS ru
+		)
+		SELECT ` + prefixColumns("t.", taskColumns) + `,
+			ts_rank_cd(t.search_vector, q.en || q.ru) AS rank,
+			CASE WHEN to_tsvector('russian', t.title) @@ q.ru
+				THEN ts_headline('russian', ` + escapeHtmlSql("t.title") + `, q.ru, $5)
+				ELSE ts_headline('english', ` + escapeHtmlSql("t.title") + `, q.en, $5) END,
+			CASE WHEN to_tsvector('russian', coalesce(t.description, '')) @@ q.ru
+				THEN ts_headline('russian', ` + escapeHtmlSql("coalesce(t.description, '')") + `, q.ru, $5)
+				ELSE ts_headline('english', ` + escapeHtmlSql("coalesce(t.description, '')") + `, q.en, $5) END
+		FROM tasks t, q
+		WHERE t.user_id = $1 AND t.deletedAt IS NULL AND t.search_vector @@ (q.en || q.ru)
+		ORDER BY rank DESC, t.task_id
+		LIMIT $3 OFFSET $4`
+	results := []response_objects.ResponseTaskSearchResult{}
+	tasks := []models.Task{}
+
+	rows, err := r.q.QueryxContext(ctx, query, userId, text, limit, offset, headlineOptions)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		t := models.Task{}
+		result := response_objects.ResponseTaskSearchResult{}
+		if err = rows.Scan(append(
+			taskScanDest(&t),
+			&result.Rank,
+			&result.TitleHighlight,
+			&result.Snippet,
+		)...); err != nil {
+			return nil, err
+		}
+
+		tasks = append(tasks, t)
+		results = append(results, result)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	rows.Close()
+
+	if err = r.loadTaskDetails(ctx, tasks); err != nil {
+		return nil, err
+	}
+
+	for i := range results {
+		results[i].ResponseTask = response_objects.NewResponseTask(ctx, tasks[i])
+	}
+
+	return &results, nil
+}
+
+const headlineOptions = `StartSel=<b>, StopSel=</b>, MaxWords=35, MinWords=15, MaxFragments=2, FragmentDelimiter=" ... "`
+
+func escapeHtmlSql(expr string) string {
+	return `replace(replace(replace(` + expr + `, '&', '&amp;'), '<', '&lt;'), '>', '&gt;')`
+}
+
+func prefixColumns(prefix string, columns string) string {
+	parts := strings.Split(columns, ", ")
+	for i := range parts {
+		parts[i] = prefix + parts[i]
+	}
+	return strings.Join(parts, ", ")
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// CreateTask puts the task into the user's inbox unless it names a list, and at the bottom of its status column
 func (r *userRepository) CreateTask(ctx context.Context, task models.Task) error {
 	userId := ctx.Value("user").(models.User).UserId
-	taskId := uuid.New()
-	createdTask := models.Task{}
-	query := `INSERT INTO tasks (task_id, title, description, start_time, end_time, isDone, user_id) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING *`
-	var intf interface{}
-	if err := r.db.QueryRowxContext(
+	taskId := task.TaskID
+
+	if taskId == uuid.Nil {
+		taskId = uuid.New()
+	}
+
+	query := `INSERT INTO tasks (task_id, title, description, start_time, end_time, status, priority, rank, user_id, list_id, auto_complete, recurrence_rule, timezone)
+		VALUES ($1, $2, $3, $4, $5, $6, $7,
+			(SELECT COALESCE(MAX(rank), 0) + $11 FROM tasks WHERE user_id = $8 AND status = $6 AND deletedAt IS NULL),
+			$8, COALESCE($9, (SELECT list_id FROM lists WHERE user_id = $8 AND is_inbox)), $10, $12,
+			COALESCE(NULLIF($13, ''), (SELECT timezone FROM users WHERE user_id = $8)))`
+
+	if _, err := r.q.ExecContext(
 		ctx,
 		query,
 		&taskId,
@@ -125,24 +735,29 @@ func (r *userRepository) CreateTask(ctx context.Context, task models.Task) error
 		&task.Description,
 		&task.StartTime,
 		&task.EndTime,
-		&task.IsDone,
+		&task.Status,
+		&task.Priority,
 		&userId,
-	).Scan(&createdTask.TaskID,
-		&createdTask.Title,
-		&createdTask.Description,
-		&createdTask.StartTime,
-		&createdTask.EndTime,
-		&createdTask.IsDone,
-		&intf,
-		&createdTask.UserId); err != nil {
+		task.ListId,
+		&task.AutoComplete,
+		models.TaskRankStep,
+		task.RecurrenceRule,
+		task.Timezone,
+	); err != nil {
 		return err
 	}
 	return nil
 }
 
+// DeleteTask moves the task to the trash together with its checklist items
 func (r *userRepository) DeleteTask(ctx context.Context, taskId uuid.UUID) (sql.Result, error) {
-	query := `UPDATE tasks SET deletedAt = CURRENT_TIMESTAMP WHERE task_id = $1`
-	res, err := r.db.ExecContext(ctx, query, taskId)
+	userId := ctx.Value("user").(models.User).UserId
+	query := `WITH items AS (
+			UPDATE checklist_items SET deleted_at = CURRENT_TIMESTAMP
+			WHERE task_id = (SELECT task_id FROM tasks WHERE task_id = $1 AND user_id = $2) AND deleted_at IS NULL
+		)
+		UPDATE tasks SET deletedAt = CURRENT_TIMESTAMP WHERE task_id = $1 AND user_id = $2`
+	res, err := r.q.ExecContext(ctx, query, taskId, userId)
 	if err != nil {
 		return nil, err
 	}
@@ -150,25 +765,39 @@ func (r *userRepository) DeleteTask(ctx context.Context, taskId uuid.UUID) (sql.
 }
 
 func (r *userRepository) CheckForDeleteTask(ctx context.Context, taskId uuid.UUID) (int, error) {
-	query := `SELECT count(*) from tasks where task_id = $1 and  deletedat is null`
+	userId := ctx.Value("user").(models.User).UserId
+	query := `SELECT count(*) from tasks where task_id = $1 and user_id = $2 and deletedat is null`
 	var count int
 
-	if err := r.db.QueryRowxContext(ctx, query, taskId).Scan(&count); err != nil {
+	if err := r.q.QueryRowxContext(ctx, query, taskId, userId).Scan(&count); err != nil {
 		return -1, err
 	}
 
 	return count, nil
 }
 
+// UpdateTask saves the task, a task that changes status goes to the bottom of its new status column
 func (r *userRepository) UpdateTask(ctx context.Context, task models.Task) (sql.Result, error) {
-	res, err := r.db.ExecContext(
+	userId := ctx.Value("user").(models.User).UserId
+	res, err := r.q.ExecContext(
 		ctx,
-		`UPDATE tasks SET title=$1, description=$2, start_time=$3, end_time=$4, isDone=$5`,
+		`UPDATE tasks SET title=$1, description=$2, start_time=$3, end_time=$4, status=$5, priority=$6, list_id=$8, auto_complete=$9, recurrence_rule=$13,
+			rank = CASE WHEN status = $5 THEN $7
+				ELSE (SELECT COALESCE(MAX(rank), 0) + $12 FROM tasks WHERE user_id = $11 AND status = $5 AND deletedAt IS NULL) END
+			WHERE task_id=$10 AND user_id=$11`,
 		&task.Title,
 		&task.Description,
 		&task.StartTime,
 		&task.EndTime,
-		&task.IsDone,
+		&task.Status,
+		&task.Priority,
+		&task.Rank,
+		task.ListId,
+		&task.AutoComplete,
+		&task.TaskID,
+		&userId,
+		models.TaskRankStep,
+		task.RecurrenceRule,
 	)
 
 	if err != nil {
@@ -177,3 +806,104 @@ func (r *userRepository) UpdateTask(ctx context.Context, task models.Task) (sql.
 
 	return res, nil
 }
+
+// NextRank returns the rank of the first task of the status column placed after the given rank,
+// or the first task of the column when after is nil. The task being moved is skipped, nil means there is none.
+func (r *userRepository) NextRank(ctx context.Context, status string, after *float64, taskId uuid.UUID) (*float64, error) {
+	userId := ctx.Value("user").(models.User).UserId
+	query := `SELECT MIN(rank) FROM tasks
+		WHERE user_id = $1 AND status = $2 AND deletedAt IS NULL AND task_id <> $3 AND ($4::float8 IS NULL OR rank > $4)`
+	var rank *float64
+
+	if err := r.q.QueryRowxContext(ctx, query, userId, status, taskId, after).Scan(&rank); err != nil {
+		return nil, err
+	}
+	return rank, nil
+}
+
+func (r *userRepository) SetTaskRank(ctx context.Context, taskId uuid.UUID, rank float64) (sql.Result, error) {
+	userId := ctx.Value("user").(models.User).UserId
+	query := `UPDATE tasks SET rank = $1 WHERE task_id = $2 AND user_id = $3 AND deletedAt IS NULL`
+
+	res, err := r.q.ExecContext(ctx, query, rank, taskId, userId)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *userRepository) RebalanceRanks(ctx context.Context, status string) error {
+	userId := ctx.Value("user").(models.User).UserId
+	query := `UPDATE tasks SET rank = ranked.position
+		FROM (
+			SELECT task_id, row_number() OVER (ORDER BY rank, task_id) * $3 AS position
+			FROM tasks WHERE user_id = $1 AND status = $2 AND deletedAt IS NULL
+		) ranked
+		WHERE ranked.task_id = tasks.task_id`
+
+	_, err := r.q.ExecContext(ctx, query, userId, status, models.TaskRankStep)
+	return err
+}
+
+func (r *userRepository) MoveTask(ctx context.Context, taskId uuid.UUID, listId uuid.UUID) (sql.Result, error) {
+	userId := ctx.Value("user").(models.User).UserId
+	query := `UPDATE tasks SET list_id = $1 WHERE task_id = $2 AND user_id = $3 AND deletedAt IS NULL`
+
+	res, err := r.q.ExecContext(ctx, query, listId, taskId, userId)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// RestoreTask takes the task out of the trash together with the checklist items that were deleted with it,
+// items deleted on their own before stay deleted
+func (r *userRepository) RestoreTask(ctx context.Context, taskId uuid.UUID) (sql.Result, error) {
+	userId := ctx.Value("user").(models.User).UserId
+	query := `WITH deleted AS (
+			SELECT task_id, deletedAt FROM tasks WHERE task_id = $1 AND user_id = $2 AND deletedAt IS NOT NULL
+		), items AS (
+			UPDATE checklist_items c SET deleted_at = NULL
+			FROM deleted d
+			WHERE c.task_id = d.task_id AND c.deleted_at = d.deletedAt
+		)
+		UPDATE tasks SET deletedAt = NULL WHERE task_id = $1 AND user_id = $2 AND deletedAt IS NOT NULL`
+
+	res, err := r.q.ExecContext(ctx, query, taskId, userId)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *userRepository) PurgeTask(ctx context.Context, taskId uuid.UUID) (sql.Result, error) {
+	userId := ctx.Value("user").(models.User).UserId
+	query := `DELETE FROM tasks WHERE task_id = $1 AND user_id = $2`
+
+	res, err := r.q.ExecContext(ctx, query, taskId, userId)
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (r *userRepository) EmptyTrash(ctx context.Context) (int64, error) {
+	userId := ctx.Value("user").(models.User).UserId
+	query := `DELETE FROM tasks WHERE user_id = $1 AND deletedAt IS NOT NULL`
+
+	res, err := r.q.ExecContext(ctx, query, userId)
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
+
+func (r *userRepository) PurgeDeletedTasks(ctx context.Context, olderThan time.Duration) (int64, error) {
+	query := `DELETE FROM tasks WHERE deletedAt < CURRENT_TIMESTAMP - make_interval(secs => $1)`
+
+	res, err := r.q.ExecContext(ctx, query, olderThan.Seconds())
+	if err != nil {
+		return 0, err
+	}
+	return res.RowsAffected()
+}
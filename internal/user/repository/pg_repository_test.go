@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/google/uuid"
+	_ "github.com/jackc/pgx/stdlib"
+	"github.com/jmoiron/sqlx"
+	"os"
+	"testing"
+	"time"
+	"uzinfocom-todo/internal/models"
+)
+
+// testDatabaseEnv names a disposable database the repository tests migrate and write to,
+// the tests are skipped when it is not set
+const testDatabaseEnv = "TODO_TEST_DATABASE_DSN"
+
+func newTestDB(t *testing.T) *sqlx.DB {
+	t.Helper()
+
+	dsn := os.Getenv(testDatabaseEnv)
+	if dsn == "" {
+		t.Skipf("%s is not set", testDatabaseEnv)
+	}
+
+	// the session time zone differs from the one of the process on purpose
+	db, err := sqlx.Connect("pgx", dsn+" timezone=America/New_York")
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	driver, err := postgres.WithInstance(db.DB, &postgres.Config{})
+	if err != nil {
+		t.Fatalf("migrate driver: %v", err)
+	}
+
+	m, err := migrate.NewWithDatabaseInstance("file://../../../migrations", "postgres", driver)
+	if err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	if err = m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		t.Fatalf("migrate up: %v", err)
+	}
+	return db
+}
+
+func TestFindTasksCursorAcrossPages(t *testing.T) {
+	db := newTestDB(t)
+
+	local := time.Local
+	tashkent, err := time.LoadLocation("Asia/Tashkent")
+	if err != nil {
+		t.Skip("time zone database is not available")
+	}
+	time.Local = tashkent
+	t.Cleanup(func() { time.Local = local })
+
+	repo := NewUserRepository(db)
+	u := models.User{
+		Name:        "cursor test",
+		PhoneNumber: "+99890" + uuid.NewString()[:7],
+		Password:    "x",
+		Role:        models.RoleUser,
+	}
+
+	if err = repo.Create(context.Background(), u); err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+
+	created, err := repo.GetByPhoneNumber(context.Background(), u.PhoneNumber)
+	if err != nil {
+		t.Fatalf("get user: %v", err)
+	}
+	t.Cleanup(func() {
+		db.Exec(`DELETE FROM tasks WHERE user_id = $1`, created.UserId)
+		db.Exec(`DELETE FROM lists WHERE user_id = $1`, created.UserId)
+		db.Exec(`DELETE FROM users WHERE user_id = $1`, created.UserId)
+	})
+
+	ctx := context.WithValue(context.Background(), "user", *created)
+	start := time.Date(2024, 3, 10, 9, 0, 0, 0, time.UTC)
+	const taskCount = 7
+
+	for i := 0; i < taskCount; i++ {
+		taskStart := start.Add(time.Duration(i) * time.Hour)
+		if err = repo.CreateTask(ctx, models.Task{
+			Title:     "task",
+			StartTime: taskStart,
+			EndTime:   taskStart.Add(30 * time.Minute),
+			Status:    models.TaskStatusTodo,
+			Priority:  models.TaskPriorityMedium,
+			Timezone:  "UTC",
+		}); err != nil {
+			t.Fatalf("create task: %v", err)
+		}
+	}
+
+	for _, order := range []string{models.SortAsc, models.SortDesc} {
+		t.Run(order, func(t *testing.T) {
+			filter := models.TaskFilter{
+				Status: models.TaskStatusAll,
+				Sort:   models.TaskSortStartTime,
+				Order:  order,
+				Limit:  2,
+			}
+
+			seen := make(map[uuid.UUID]bool)
+			var previous time.Time
+
+			for page := 0; page <= taskCount; page++ {
+				tasks, nextCursor, err := repo.FindTasks(ctx, filter)
+				if err != nil {
+					t.Fatalf("page %d: %v", page, err)
+				}
+
+				for _, task := range tasks {
+					if seen[task.TaskID] {
+						t.Fatalf("task %s is listed twice", task.TaskID)
+					}
+					seen[task.TaskID] = true
+
+					if !previous.IsZero() && (order == models.SortAsc) != task.StartTime.After(previous) {
+						t.Fatalf("task at %s comes after %s in %s order", task.StartTime, previous, order)
+					}
+					previous = task.StartTime
+				}
+
+				if nextCursor == "" {
+					break
+				}
+
+				if filter.Cursor, err = models.DecodeTaskCursor(nextCursor); err != nil {
+					t.Fatalf("decode cursor: %v", err)
+				}
+			}
+
+			if len(seen) != taskCount {
+				t.Errorf("listed %d tasks across pages, want %d", len(seen), taskCount)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/google/uuid"
 	"uzinfocom-todo/internal/models"
+	"uzinfocom-todo/internal/models/request_objects"
 	"uzinfocom-todo/internal/models/response_objects"
 	"uzinfocom-todo/pkg/http_errors"
 )
@@ -11,9 +12,28 @@ import (
 type UseCase interface {
 	Create(ctx context.Context, user models.User) http_errors.RestErr
 	GetByPhoneNumber(ctx context.Context, phone string) (*models.User, http_errors.RestErr)
-	GetAllTasks(ctx context.Context) (*[]response_objects.ResponseTask, http_errors.RestErr)
+	Login(ctx context.Context, user models.User) (*models.User, http_errors.RestErr)
+	ChangePassword(ctx context.Context, userId uuid.UUID, oldPassword string, newPassword string) http_errors.RestErr
+	GetProfile(ctx context.Context, userId uuid.UUID) (*response_objects.ResponseProfile, http_errors.RestErr)
+	UpdateProfile(ctx context.Context, userId uuid.UUID, request request_objects.RequestUpdateProfile) (*response_objects.ResponseProfile, http_errors.RestErr)
+	DeleteAccount(ctx context.Context, userId uuid.UUID, password string) http_errors.RestErr
+	GetUsers(ctx context.Context, limit int, offset int) (*[]response_objects.ResponseUser, http_errors.RestErr)
+	SetDisabled(ctx context.Context, userId uuid.UUID, disabled bool) http_errors.RestErr
+	GetTaskCounts(ctx context.Context, userId uuid.UUID) (*response_objects.ResponseTaskCounts, http_errors.RestErr)
+	GetAllTasks(ctx context.Context, filter models.TaskFilter) (*[]response_objects.ResponseTask, string, http_errors.RestErr)
+	GetTask(ctx context.Context, taskId uuid.UUID) (*response_objects.ResponseTask, http_errors.RestErr)
+	GetListTasks(ctx context.Context, listId uuid.UUID, filter models.TaskFilter) (*[]response_objects.ResponseTask, string, http_errors.RestErr)
+	SearchTasks(ctx context.Context, text string, limit int, offset int) (*[]response_objects.ResponseTaskSearchResult, http_errors.RestErr)
 	CreateTask(ctx context.Context, task models.Task) http_errors.RestErr
 	DeleteTask(ctx context.Context, taskId uuid.UUID) http_errors.RestErr
 	GetTaskById(ctx context.Context, taskId uuid.UUID) (*models.Task, http_errors.RestErr)
 	UpdateTask(ctx context.Context, task models.Task) http_errors.RestErr
+	UpdateOccurrence(ctx context.Context, taskId uuid.UUID, occurrence models.TaskOccurrence) (*response_objects.ResponseTask, http_errors.RestErr)
+	ExecuteTaskBatch(ctx context.Context, request request_objects.RequestTaskBatch) (*response_objects.ResponseTaskBatch, http_errors.RestErr)
+	RankTask(ctx context.Context, taskId uuid.UUID, afterId *uuid.UUID) (*response_objects.ResponseTask, http_errors.RestErr)
+	MoveTask(ctx context.Context, taskId uuid.UUID, listId *uuid.UUID) http_errors.RestErr
+	RestoreTask(ctx context.Context, taskId uuid.UUID) http_errors.RestErr
+	PurgeTask(ctx context.Context, taskId uuid.UUID) http_errors.RestErr
+	EmptyTrash(ctx context.Context) (*response_objects.ResponsePurged, http_errors.RestErr)
+	PurgeExpiredTrash(ctx context.Context) (int64, http_errors.RestErr)
 }
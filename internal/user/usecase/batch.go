@@ -0,0 +1,414 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"net/http"
+	"time"
+	"uzinfocom-todo/internal/models"
+	"uzinfocom-todo/internal/models/request_objects"
+	"uzinfocom-todo/internal/models/response_objects"
+	"uzinfocom-todo/internal/user"
+	"uzinfocom-todo/pkg/http_errors"
+)
+
+var errBatchRolledBack = errors.New("batch rolled back")
+
+// batchPlan simulates a batch in memory before anything is written, so the overlap check sees
+// the tasks as they will be once the whole batch is applied rather than one operation at a time
+type batchPlan struct {
+	operations []request_objects.RequestTaskOperation
+	// ids of the tasks created by the batch, fixed up front so every simulation uses the same ones
+	createdIds map[int]uuid.UUID
+	// open tasks of the user before the batch
+	open map[uuid.UUID]models.Task
+	// tasks referenced by the operations as they are stored, nil when they do not exist
+	loaded map[uuid.UUID]*models.Task
+	// time zone wall clock times of the operations are read in
+	loc *time.Location
+	// how far ahead the occurrences of recurring tasks are checked for overlaps
+	horizon time.Duration
+}
+
+// batchStep is the outcome of simulating one operation: the task it writes or the reason it is rejected
+type batchStep struct {
+	task    models.Task
+	restErr http_errors.RestErr
+}
+
+// ExecuteTaskBatch applies create, update, complete and delete operations in one transaction.
+// In all_or_nothing mode any failing operation rolls the whole batch back, in best_effort mode
+// failing operations are skipped and the rest is committed.
+func (uc *userUseCase) ExecuteTaskBatch(ctx context.Context, request request_objects.RequestTaskBatch) (*response_objects.ResponseTaskBatch, http_errors.RestErr) {
+	mode := request.Mode
+	if mode == "" {
+		mode = models.BatchModeAllOrNothing
+	}
+
+	if mode != models.BatchModeAllOrNothing && mode != models.BatchModeBestEffort {
+		return nil, http_errors.InvalidValue("mode", models.BatchModeAllOrNothing, models.BatchModeBestEffort)
+	}
+
+	if len(request.Operations) == 0 || len(request.Operations) > models.MaxBatchOperations {
+		return nil, http_errors.InvalidBatchSize(models.MaxBatchOperations)
+	}
+
+	batch := &response_objects.ResponseTaskBatch{Mode: mode}
+	var rollbackErr http_errors.RestErr
+
+	err := uc.userRepo.InTx(ctx, func(txRepo user.Repository) error {
+		plan, err := newBatchPlan(ctx, txRepo, request.Operations, uc.recurrenceHorizon())
+		if err != nil {
+			return err
+		}
+
+		steps, rejected := plan.resolve(mode == models.BatchModeBestEffort)
+
+		if mode == models.BatchModeAllOrNothing && len(rejected) > 0 {
+			batch.Results, rollbackErr = plan.rollbackResults(ctx, rejected)
+			return errBatchRolledBack
+		}
+
+		batch.Results = make([]response_objects.ResponseTaskOperation, len(request.Operations))
+
+		for i, operation := range request.Operations {
+			result := response_objects.ResponseTaskOperation{Index: i, Op: operation.Op}
+
+			if restErr, ok := rejected[i]; ok {
+				result.SetError(ctx, restErr)
+				batch.Results[i] = result
+				continue
+			}
+
+			task := steps[i].task
+			result.TaskId = &task.TaskID
+
+			if mode == models.BatchModeBestEffort {
+				err = txRepo.InTx(ctx, func(spRepo user.Repository) error {
+					return applyBatchOperation(ctx, spRepo, operation.Op, task)
+				})
+			} else {
+				err = applyBatchOperation(ctx, txRepo, operation.Op, task)
+			}
+
+			if err != nil {
+				restErr := http_errors.ParseErrors(err)
+
+				if mode == models.BatchModeAllOrNothing {
+					batch.Results, rollbackErr = plan.rollbackResults(ctx, map[int]http_errors.RestErr{i: restErr})
+					return errBatchRolledBack
+				}
+
+				result.SetError(ctx, restErr)
+				batch.Results[i] = result
+				continue
+			}
+
+			result.Success = true
+			result.Status = http.StatusOK
+			if operation.Op == models.TaskOpCreate {
+				result.Status = http.StatusCreated
+			}
+			batch.Results[i] = result
+		}
+		return nil
+	})
+
+	if rollbackErr != nil {
+		return batch, rollbackErr
+	}
+
+	if err != nil {
+		return nil, http_errors.ParseErrors(err)
+	}
+
+	batch.Committed = true
+	return batch, nil
+}
+
+func applyBatchOperation(ctx context.Context, repo user.Repository, op string, task models.Task) error {
+	var err error
+
+	switch op {
+	case models.TaskOpCreate:
+		err = repo.CreateTask(ctx, task)
+	case models.TaskOpDelete:
+		_, err = repo.DeleteTask(ctx, task.TaskID)
+	default:
+		_, err = repo.UpdateTask(ctx, task)
+	}
+	return err
+}
+
+func newBatchPlan(ctx context.Context, repo user.Repository, operations []request_objects.RequestTaskOperation, horizon time.Duration) (*batchPlan, error) {
+	plan := &batchPlan{
+		operations: operations,
+		createdIds: make(map[int]uuid.UUID),
+		open:       make(map[uuid.UUID]models.Task),
+		loaded:     make(map[uuid.UUID]*models.Task),
+		loc:        models.LocationFromContext(ctx),
+		horizon:    horizon,
+	}
+
+	openTasks, _, err := repo.FindTasks(ctx, models.TaskFilter{Status: models.TaskStatusOpen})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, openTask := range openTasks {
+		plan.open[openTask.TaskID] = openTask
+	}
+
+	for i, operation := range operations {
+		if operation.Op == models.TaskOpCreate {
+			plan.createdIds[i] = uuid.New()
+			continue
+		}
+
+		taskId, err := uuid.Parse(operation.TaskId)
+		if err != nil {
+			continue
+		}
+
+		if _, ok := plan.loaded[taskId]; ok {
+			continue
+		}
+
+		t, err := repo.GetTaskById(ctx, taskId)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		plan.loaded[taskId] = t
+	}
+
+	return plan, nil
+}
+
+// resolve simulates the batch and returns every operation's step together with the rejected operations.
+// With skipRejected it keeps dropping rejected operations and simulating again until the remaining ones
+// no longer overlap, otherwise a single simulation decides.
+func (p *batchPlan) resolve(skipRejected bool) ([]batchStep, map[int]http_errors.RestErr) {
+	rejected := make(map[int]http_errors.RestErr)
+
+	for {
+		steps, state := p.simulate(rejected)
+		p.checkOverlaps(steps, state, rejected)
+
+		newlyRejected := 0
+		for i, step := range steps {
+			if _, ok := rejected[i]; !ok && step.restErr != nil {
+				rejected[i] = step.restErr
+				newlyRejected++
+			}
+		}
+
+		if newlyRejected == 0 || !skipRejected {
+			return steps, rejected
+		}
+	}
+}
+
+func (p *batchPlan) simulate(rejected map[int]http_errors.RestErr) ([]batchStep, map[uuid.UUID]*models.Task) {
+	steps := make([]batchStep, len(p.operations))
+	state := make(map[uuid.UUID]*models.Task, len(p.loaded))
+
+	for taskId, t := range p.loaded {
+		if t != nil {
+			stored := *t
+			state[taskId] = &stored
+		}
+	}
+
+	for i, operation := range p.operations {
+		if _, ok := rejected[i]; ok {
+			continue
+		}
+
+		task, restErr := p.simulateOperation(i, operation, state)
+		if restErr != nil {
+			steps[i].restErr = restErr
+			continue
+		}
+
+		steps[i].task = task
+		state[task.TaskID] = &task
+	}
+
+	return steps, state
+}
+
+func (p *batchPlan) simulateOperation(i int, operation request_objects.RequestTaskOperation, state map[uuid.UUID]*models.Task) (models.Task, http_errors.RestErr) {
+	if operation.Op == models.TaskOpCreate {
+		request := request_objects.RequestTask{
+			Title:       operation.Title,
+			Description: operation.Description,
+			StartTime:   operation.StartTime,
+			EndTime:     operation.EndTime,
+		}
+
+		if restErr := request.Validate(p.loc); restErr != nil {
+			return models.Task{}, restErr
+		}
+
+		startTime, endTime, restErr := parseBatchTimes(operation, p.loc)
+		if restErr != nil {
+			return models.Task{}, restErr
+		}
+
+		return models.Task{
+			TaskID:      p.createdIds[i],
+			Title:       operation.Title,
+			Description: operation.Description,
+			StartTime:   startTime,
+			EndTime:     endTime,
+			Status:      models.TaskStatusTodo,
+			Priority:    models.TaskPriorityMedium,
+			Timezone:    p.loc.String(),
+		}, nil
+	}
+
+	if operation.Op != models.TaskOpUpdate && operation.Op != models.TaskOpDelete && operation.Op != models.TaskOpComplete {
+		return models.Task{}, http_errors.InvalidValue("op", models.TaskOpCreate, models.TaskOpUpdate, models.TaskOpDelete, models.TaskOpComplete)
+	}
+
+	taskId, err := uuid.Parse(operation.TaskId)
+	if err != nil {
+		return models.Task{}, http_errors.InvalidTaskId()
+	}
+
+	current, ok := state[taskId]
+
+	if operation.Op == models.TaskOpDelete {
+		if !ok || current.DeletedAt != nil {
+			return models.Task{}, http_errors.ObjectNotFoundToDelete()
+		}
+
+		task := *current
+		task.DeletedAt = time.Now()
+		return task, nil
+	}
+
+	if !ok || current.DeletedAt != nil {
+		return models.Task{}, http_errors.ObjectNotFoundToUpdate()
+	}
+
+	task := *current
+
+	if operation.Op == models.TaskOpUpdate {
+		request := request_objects.RequestTaskForUpdate{
+			Title:       operation.Title,
+			Description: operation.Description,
+			StartTime:   operation.StartTime,
+			EndTime:     operation.EndTime,
+		}
+
+		if restErr := request.Validate(p.loc); restErr != nil {
+			return models.Task{}, restErr
+		}
+
+		if operation.Title != "" {
+			task.Title = operation.Title
+		}
+
+		if operation.Description != "" {
+			task.Description = operation.Description
+		}
+
+		if operation.StartTime != "" && operation.EndTime != "" {
+			startTime, endTime, restErr := parseBatchTimes(operation, p.loc)
+			if restErr != nil {
+				return models.Task{}, restErr
+			}
+			task.StartTime = startTime
+			task.EndTime = endTime
+		}
+	}
+
+	if operation.Op == models.TaskOpComplete || operation.IsDone {
+		task.Status = models.TaskStatusDone
+		if restErr := validateTransition(current.Status, task); restErr != nil {
+			return models.Task{}, restErr
+		}
+	}
+
+	return task, nil
+}
+
+// checkOverlaps rejects create and update operations whose task, as it is once the batch is applied,
+// overlaps another open task. Recurring tasks on both sides are checked with their occurrences up to the horizon.
+func (p *batchPlan) checkOverlaps(steps []batchStep, state map[uuid.UUID]*models.Task, rejected map[int]http_errors.RestErr) {
+	open := make(map[uuid.UUID]models.Task, len(p.open))
+	for taskId, t := range p.open {
+		open[taskId] = t
+	}
+
+	for taskId, t := range state {
+		if !t.IsOpen() || t.DeletedAt != nil {
+			delete(open, taskId)
+		} else {
+			open[taskId] = *t
+		}
+	}
+
+	for i, step := range steps {
+		op := p.operations[i].Op
+		if _, ok := rejected[i]; ok || step.restErr != nil || (op != models.TaskOpCreate && op != models.TaskOpUpdate) {
+			continue
+		}
+
+		final, ok := open[step.task.TaskID]
+		if !ok {
+			continue
+		}
+
+		slots := overlapSlots(final, p.horizon)
+		from, to, ok := openRange(slots)
+		if !ok {
+			continue
+		}
+
+		for otherId, other := range open {
+			if otherId != final.TaskID && slotsOverlap(slots, expandTask(other, from, to)) {
+				steps[i].restErr = http_errors.TaskExistsBetweenGivenTime()
+				break
+			}
+		}
+	}
+}
+
+func (p *batchPlan) rollbackResults(ctx context.Context, failed map[int]http_errors.RestErr) ([]response_objects.ResponseTaskOperation, http_errors.RestErr) {
+	results := make([]response_objects.ResponseTaskOperation, len(p.operations))
+	status := 0
+
+	for i, operation := range p.operations {
+		results[i] = response_objects.ResponseTaskOperation{Index: i, Op: operation.Op}
+
+		if restErr, ok := failed[i]; ok {
+			results[i].SetError(ctx, restErr)
+			if status == 0 {
+				status = restErr.Status()
+			}
+			continue
+		}
+
+		results[i].SetError(ctx, http_errors.BatchOperationNotApplied())
+	}
+
+	return results, http_errors.BatchRolledBack(status)
+}
+
+func parseBatchTimes(operation request_objects.RequestTaskOperation, loc *time.Location) (time.Time, time.Time, http_errors.RestErr) {
+	startTime, err := models.ParseTime(operation.StartTime, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, http_errors.InvalidTime("start_time")
+	}
+
+	endTime, err := models.ParseTime(operation.EndTime, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, http_errors.InvalidTime("end_time")
+	}
+
+	return startTime, endTime, nil
+}
@@ -0,0 +1,245 @@
+package usecase
+
+import (
+	"github.com/google/uuid"
+	"testing"
+	"time"
+	"uzinfocom-todo/internal/models"
+	"uzinfocom-todo/internal/models/request_objects"
+	"uzinfocom-todo/pkg/http_errors"
+)
+
+// newTestPlan builds a plan over the given open tasks the way newBatchPlan would load it
+func newTestPlan(open []models.Task, operations ...request_objects.RequestTaskOperation) *batchPlan {
+	plan := &batchPlan{
+		operations: operations,
+		createdIds: make(map[int]uuid.UUID),
+		open:       make(map[uuid.UUID]models.Task),
+		loaded:     make(map[uuid.UUID]*models.Task),
+		loc:        time.UTC,
+		horizon:    7 * 24 * time.Hour,
+	}
+
+	for _, t := range open {
+		plan.open[t.TaskID] = t
+	}
+
+	for i, operation := range operations {
+		if operation.Op == models.TaskOpCreate {
+			plan.createdIds[i] = uuid.New()
+			continue
+		}
+
+		taskId, err := uuid.Parse(operation.TaskId)
+		if err != nil {
+			continue
+		}
+
+		if t, ok := plan.open[taskId]; ok {
+			plan.loaded[taskId] = &t
+		} else {
+			plan.loaded[taskId] = nil
+		}
+	}
+	return plan
+}
+
+func testTask(start string, end string) models.Task {
+	startTime, _ := time.Parse("02-01-2006 15:04", start)
+	endTime, _ := time.Parse("02-01-2006 15:04", end)
+	return models.Task{TaskID: uuid.New(), Title: "task", StartTime: startTime, EndTime: endTime, Status: models.TaskStatusTodo}
+}
+
+func sameError(got http_errors.RestErr, want http_errors.RestErr) bool {
+	if got == nil || want == nil {
+		return got == nil && want == nil
+	}
+	return got.Status() == want.Status() && got.Error() == want.Error()
+}
+
+func TestBatchPlanSimulate(t *testing.T) {
+	existing := testTask("10-03-2030 10:00", "10-03-2030 11:00")
+	missing := uuid.NewString()
+
+	plan := newTestPlan([]models.Task{existing},
+		request_objects.RequestTaskOperation{Op: models.TaskOpCreate, Title: "new", StartTime: "11-03-2030 09:00", EndTime: "11-03-2030 10:00"},
+		request_objects.RequestTaskOperation{Op: models.TaskOpUpdate, TaskId: existing.TaskID.String(), Title: "moved", StartTime: "12-03-2030 09:00", EndTime: "12-03-2030 10:00"},
+		request_objects.RequestTaskOperation{Op: models.TaskOpDelete, TaskId: existing.TaskID.String()},
+		request_objects.RequestTaskOperation{Op: models.TaskOpDelete, TaskId: missing},
+		request_objects.RequestTaskOperation{Op: "archive", TaskId: existing.TaskID.String()},
+		request_objects.RequestTaskOperation{Op: models.TaskOpCreate, Title: "bad", StartTime: "2030-03-11", EndTime: "11-03-2030 10:00"},
+	)
+
+	steps, state := plan.simulate(map[int]http_errors.RestErr{})
+
+	if created := steps[0].task; created.TaskID != plan.createdIds[0] || created.Title != "new" {
+		t.Errorf("create step = %+v, want the task with the planned id", created)
+	}
+
+	if updated := steps[1].task; updated.Title != "moved" || updated.StartTime.Day() != 12 {
+		t.Errorf("update step = %+v, want the moved task", updated)
+	}
+
+	// the delete sees the task as the update left it
+	if deleted := steps[2].task; deleted.Title != "moved" || deleted.DeletedAt == nil {
+		t.Errorf("delete step = %+v, want the moved task marked deleted", deleted)
+	}
+
+	if !sameError(steps[3].restErr, http_errors.ObjectNotFoundToDelete()) {
+		t.Errorf("delete of a missing task: %v", steps[3].restErr)
+	}
+
+	for _, i := range []int{4, 5} {
+		if steps[i].restErr == nil {
+			t.Errorf("operation %d was not rejected", i)
+		}
+	}
+
+	if final := state[existing.TaskID]; final == nil || final.DeletedAt == nil {
+		t.Errorf("state of the existing task = %+v, want it deleted", final)
+	}
+}
+
+func TestBatchPlanSimulateSkipsRejected(t *testing.T) {
+	existing := testTask("10-03-2030 10:00", "10-03-2030 11:00")
+
+	plan := newTestPlan([]models.Task{existing},
+		request_objects.RequestTaskOperation{Op: models.TaskOpUpdate, TaskId: existing.TaskID.String(), Title: "renamed"},
+	)
+
+	steps, state := plan.simulate(map[int]http_errors.RestErr{0: http_errors.TaskExistsBetweenGivenTime()})
+
+	if steps[0].task.TaskID != uuid.Nil || steps[0].restErr != nil {
+		t.Errorf("rejected operation was simulated: %+v", steps[0])
+	}
+
+	if state[existing.TaskID].Title != "task" {
+		t.Errorf("title = %s, want the stored one", state[existing.TaskID].Title)
+	}
+}
+
+func TestBatchPlanCheckOverlaps(t *testing.T) {
+	tenToEleven := testTask("10-03-2030 10:00", "10-03-2030 11:00")
+
+	tests := []struct {
+		name       string
+		operations func(existing models.Task) []request_objects.RequestTaskOperation
+		want       []http_errors.RestErr
+	}{
+		{
+			name: "create overlaps an existing task",
+			operations: func(existing models.Task) []request_objects.RequestTaskOperation {
+				return []request_objects.RequestTaskOperation{
+					{Op: models.TaskOpCreate, Title: "new", StartTime: "10-03-2030 10:30", EndTime: "10-03-2030 11:30"},
+				}
+			},
+			want: []http_errors.RestErr{http_errors.TaskExistsBetweenGivenTime()},
+		},
+		{
+			name: "creates overlap each other",
+			operations: func(existing models.Task) []request_objects.RequestTaskOperation {
+				return []request_objects.RequestTaskOperation{
+					{Op: models.TaskOpCreate, Title: "first", StartTime: "11-03-2030 10:00", EndTime: "11-03-2030 11:00"},
+					{Op: models.TaskOpCreate, Title: "second", StartTime: "11-03-2030 10:30", EndTime: "11-03-2030 11:30"},
+				}
+			},
+			want: []http_errors.RestErr{http_errors.TaskExistsBetweenGivenTime(), http_errors.TaskExistsBetweenGivenTime()},
+		},
+		{
+			name: "create takes the slot a later update frees",
+			operations: func(existing models.Task) []request_objects.RequestTaskOperation {
+				return []request_objects.RequestTaskOperation{
+					{Op: models.TaskOpCreate, Title: "new", StartTime: "10-03-2030 10:00", EndTime: "10-03-2030 11:00"},
+					{Op: models.TaskOpUpdate, TaskId: existing.TaskID.String(), StartTime: "11-03-2030 10:00", EndTime: "11-03-2030 11:00"},
+				}
+			},
+			want: []http_errors.RestErr{nil, nil},
+		},
+		{
+			name: "create takes the slot of a deleted task",
+			operations: func(existing models.Task) []request_objects.RequestTaskOperation {
+				return []request_objects.RequestTaskOperation{
+					{Op: models.TaskOpDelete, TaskId: existing.TaskID.String()},
+					{Op: models.TaskOpCreate, Title: "new", StartTime: "10-03-2030 10:00", EndTime: "10-03-2030 11:00"},
+				}
+			},
+			want: []http_errors.RestErr{nil, nil},
+		},
+		{
+			name: "update keeps its own slot",
+			operations: func(existing models.Task) []request_objects.RequestTaskOperation {
+				return []request_objects.RequestTaskOperation{
+					{Op: models.TaskOpUpdate, TaskId: existing.TaskID.String(), StartTime: "10-03-2030 10:30", EndTime: "10-03-2030 11:30"},
+				}
+			},
+			want: []http_errors.RestErr{nil},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := newTestPlan([]models.Task{tenToEleven}, tt.operations(tenToEleven)...)
+			rejected := map[int]http_errors.RestErr{}
+
+			steps, state := plan.simulate(rejected)
+			plan.checkOverlaps(steps, state, rejected)
+
+			for i, want := range tt.want {
+				if !sameError(steps[i].restErr, want) {
+					t.Errorf("operation %d: got %v, want %v", i, steps[i].restErr, want)
+				}
+			}
+		})
+	}
+}
+
+func TestBatchPlanCheckOverlapsRecurring(t *testing.T) {
+	daily := dailyTask(time.Date(2030, time.March, 10, 10, 0, 0, 0, time.UTC), time.Hour)
+
+	tests := []struct {
+		name      string
+		operation request_objects.RequestTaskOperation
+		want      http_errors.RestErr
+	}{
+		{"later occurrence", request_objects.RequestTaskOperation{Op: models.TaskOpCreate, Title: "new", StartTime: "13-03-2030 10:30", EndTime: "13-03-2030 11:30"}, http_errors.TaskExistsBetweenGivenTime()},
+		{"between occurrences", request_objects.RequestTaskOperation{Op: models.TaskOpCreate, Title: "new", StartTime: "13-03-2030 12:00", EndTime: "13-03-2030 13:00"}, nil},
+		{"before the series", request_objects.RequestTaskOperation{Op: models.TaskOpCreate, Title: "new", StartTime: "09-03-2030 10:00", EndTime: "09-03-2030 11:00"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, rejected := newTestPlan([]models.Task{daily}, tt.operation).resolve(false)
+
+			if !sameError(rejected[0], tt.want) {
+				t.Errorf("got %v, want %v", rejected[0], tt.want)
+			}
+		})
+	}
+}
+
+func TestBatchPlanResolve(t *testing.T) {
+	blocking := testTask("10-03-2030 10:00", "10-03-2030 11:00")
+	moved := testTask("10-03-2030 14:00", "10-03-2030 15:00")
+
+	// the update of moved overlaps blocking, the create only fits while moved is out of its old slot
+	operations := []request_objects.RequestTaskOperation{
+		{Op: models.TaskOpUpdate, TaskId: moved.TaskID.String(), StartTime: "10-03-2030 10:30", EndTime: "10-03-2030 11:30"},
+		{Op: models.TaskOpCreate, Title: "new", StartTime: "10-03-2030 14:00", EndTime: "10-03-2030 15:00"},
+	}
+
+	t.Run("all or nothing", func(t *testing.T) {
+		_, rejected := newTestPlan([]models.Task{blocking, moved}, operations...).resolve(false)
+
+		if len(rejected) != 1 || !sameError(rejected[0], http_errors.TaskExistsBetweenGivenTime()) {
+			t.Errorf("rejected = %v, want only the update", rejected)
+		}
+	})
+
+	t.Run("best effort", func(t *testing.T) {
+		_, rejected := newTestPlan([]models.Task{blocking, moved}, operations...).resolve(true)
+
+		if len(rejected) != 2 || !sameError(rejected[1], http_errors.TaskExistsBetweenGivenTime()) {
+			t.Errorf("rejected = %v, want the create too once the update is skipped", rejected)
+		}
+	})
+}
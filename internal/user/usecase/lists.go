@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"uzinfocom-todo/internal/models"
+	"uzinfocom-todo/internal/models/response_objects"
+	"uzinfocom-todo/pkg/http_errors"
+)
+
+func (uc *userUseCase) GetListTasks(ctx context.Context, listId uuid.UUID, filter models.TaskFilter) (*[]response_objects.ResponseTask, string, http_errors.RestErr) {
+	if _, restErr := uc.getList(ctx, listId); restErr != nil {
+		return nil, "", restErr
+	}
+
+	filter.ListId = &listId
+	return uc.GetAllTasks(ctx, filter)
+}
+
+// MoveTask puts the task into the given list, a nil list moves it back to the inbox
+func (uc *userUseCase) MoveTask(ctx context.Context, taskId uuid.UUID, listId *uuid.UUID) http_errors.RestErr {
+	var target *models.List
+	var restErr http_errors.RestErr
+
+	if listId == nil {
+		userId := ctx.Value("user").(models.User).UserId
+		var err error
+		if target, err = uc.listRepo.GetInbox(ctx, userId); err != nil {
+			return http_errors.ParseErrors(err)
+		}
+	} else if target, restErr = uc.getWritableList(ctx, *listId); restErr != nil {
+		return restErr
+	}
+
+	res, err := uc.userRepo.MoveTask(ctx, taskId, target.ListId)
+	if err != nil {
+		return http_errors.ParseErrors(err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return http_errors.ParseErrors(err)
+	}
+
+	if affected == 0 {
+		return http_errors.ObjectNotFoundToUpdate()
+	}
+	return nil
+}
+
+func (uc *userUseCase) getList(ctx context.Context, listId uuid.UUID) (*models.List, http_errors.RestErr) {
+	userId := ctx.Value("user").(models.User).UserId
+
+	l, err := uc.listRepo.GetById(ctx, userId, listId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, http_errors.ListNotFound()
+	}
+
+	if err != nil {
+		return nil, http_errors.ParseErrors(err)
+	}
+	return l, nil
+}
+
+func (uc *userUseCase) getWritableList(ctx context.Context, listId uuid.UUID) (*models.List, http_errors.RestErr) {
+	l, restErr := uc.getList(ctx, listId)
+	if restErr != nil {
+		return nil, restErr
+	}
+
+	if l.Archived {
+		return nil, http_errors.ListArchived()
+	}
+	return l, nil
+}
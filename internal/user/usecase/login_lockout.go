@@ -0,0 +1,22 @@
+package usecase
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"uzinfocom-todo/pkg/http_errors"
+	"uzinfocom-todo/pkg/util"
+)
+
+// registerFailedLogin records a wrong password and tells the client whether the account got locked by it
+func (uc *userUseCase) registerFailedLogin(ctx context.Context, userId uuid.UUID) http_errors.RestErr {
+	locked, err := uc.userRepo.RegisterFailedLogin(ctx, userId, util.LoginMaxFailures(uc.cfg), util.LoginLockout(uc.cfg), util.LoginMaxLockout(uc.cfg))
+
+	if err != nil {
+		return http_errors.ParseErrors(err)
+	}
+
+	if locked > 0 {
+		return http_errors.AccountLocked(locked)
+	}
+	return http_errors.InvalidCredentials()
+}
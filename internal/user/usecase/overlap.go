@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"context"
+	"time"
+	"uzinfocom-todo/internal/models"
+	"uzinfocom-todo/pkg/http_errors"
+)
+
+// checkOverlap rejects the task if its time range touches any other open task of the current user.
+// A recurring task is checked with all of its occurrences up to the recurrence horizon, and the
+// occurrences of other recurring tasks are taken into account too.
+func (uc *userUseCase) checkOverlap(ctx context.Context, task models.Task) http_errors.RestErr {
+	slots := overlapSlots(task, uc.recurrenceHorizon())
+
+	from, to, ok := openRange(slots)
+	if !ok {
+		return nil
+	}
+
+	// recurring tasks start before their later occurrences, so only the end of the range narrows the query
+	tasks, _, err := uc.userRepo.FindTasks(ctx, models.TaskFilter{Status: models.TaskStatusOpen, To: &to})
+
+	if err != nil {
+		return http_errors.ParseErrors(err)
+	}
+
+	for _, t := range tasks {
+		// the task must not collide with its own current times
+		if t.TaskID == task.TaskID && task.Occurrence == nil {
+			continue
+		}
+
+		for _, other := range expandTask(t, from, to) {
+			if !other.IsOpen() {
+				continue
+			}
+
+			if other.TaskID == task.TaskID && other.Occurrence != nil && other.Occurrence.Equal(*task.Occurrence) {
+				continue
+			}
+
+			for _, slot := range slots {
+				if slot.IsOpen() && overlaps(slot.StartTime, slot.EndTime, other.StartTime, other.EndTime) {
+					return http_errors.TaskExistsBetweenGivenTime()
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// overlapSlots returns the time ranges the task occupies, for a recurring task those of its occurrences
+// up to horizon past now or past its start, whichever is later
+func overlapSlots(task models.Task, horizon time.Duration) []models.Task {
+	if task.RecurrenceRule == nil || task.Occurrence != nil {
+		return []models.Task{task}
+	}
+
+	horizonStart := time.Now()
+	if task.StartTime.After(horizonStart) {
+		horizonStart = task.StartTime
+	}
+	return expandTask(task, task.StartTime, horizonStart.Add(horizon))
+}
+
+func openRange(slots []models.Task) (from time.Time, to time.Time, ok bool) {
+	for _, slot := range slots {
+		if !slot.IsOpen() {
+			continue
+		}
+
+		if !ok || slot.StartTime.Before(from) {
+			from = slot.StartTime
+		}
+
+		if !ok || slot.EndTime.After(to) {
+			to = slot.EndTime
+		}
+		ok = true
+	}
+	return from, to, ok
+}
+
+func slotsOverlap(slots []models.Task, others []models.Task) bool {
+	for _, slot := range slots {
+		if !slot.IsOpen() {
+			continue
+		}
+
+		for _, other := range others {
+			if other.IsOpen() && overlaps(slot.StartTime, slot.EndTime, other.StartTime, other.EndTime) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// overlaps reports whether two time ranges intersect, ranges that only touch at an end count as overlapping
+func overlaps(checkTime1 time.Time, checkTime2 time.Time, startTime time.Time, endTime time.Time) bool {
+	return (checkTime1.After(startTime) && checkTime1.Before(endTime)) ||
+		(checkTime2.After(startTime) && checkTime2.Before(endTime)) ||
+		checkTime1.Equal(startTime) || checkTime1.Equal(endTime) ||
+		checkTime2.Equal(startTime) || checkTime2.Equal(endTime) ||
+		(checkTime1.Before(startTime) && checkTime2.After(endTime))
+}
@@ -0,0 +1,110 @@
+package usecase
+
+import (
+	"github.com/google/uuid"
+	"testing"
+	"time"
+	"uzinfocom-todo/internal/models"
+)
+
+func TestOverlaps(t *testing.T) {
+	at := func(hour int, min int) time.Time {
+		return time.Date(2024, time.March, 10, hour, min, 0, 0, time.UTC)
+	}
+
+	// the existing task runs from 10:00 to 11:00
+	tests := []struct {
+		name  string
+		start time.Time
+		end   time.Time
+		want  bool
+	}{
+		{"before", at(8, 0), at(9, 0), false},
+		{"after", at(12, 0), at(13, 0), false},
+		{"ends at the start", at(9, 0), at(10, 0), true},
+		{"starts at the end", at(11, 0), at(12, 0), true},
+		{"starts inside", at(10, 30), at(12, 0), true},
+		{"ends inside", at(9, 0), at(10, 30), true},
+		{"inside", at(10, 15), at(10, 45), true},
+		{"around", at(9, 0), at(12, 0), true},
+		{"same range", at(10, 0), at(11, 0), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := overlaps(tt.start, tt.end, at(10, 0), at(11, 0)); got != tt.want {
+				t.Errorf("overlaps = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func dailyTask(start time.Time, length time.Duration) models.Task {
+	rule := "FREQ=DAILY"
+	return models.Task{TaskID: uuid.New(), StartTime: start, EndTime: start.Add(length), Status: models.TaskStatusTodo,
+		RecurrenceRule: &rule, Timezone: "UTC"}
+}
+
+func TestOverlapSlots(t *testing.T) {
+	start := time.Date(2030, time.March, 10, 10, 0, 0, 0, time.UTC)
+
+	oneOff := models.Task{TaskID: uuid.New(), StartTime: start, EndTime: start.Add(time.Hour), Status: models.TaskStatusTodo}
+	if slots := overlapSlots(oneOff, 48*time.Hour); len(slots) != 1 || !slots[0].StartTime.Equal(start) {
+		t.Errorf("one-off task: got %d slots, want the task itself", len(slots))
+	}
+
+	// the horizon counts from the start of a series that has not started yet
+	slots := overlapSlots(dailyTask(start, time.Hour), 48*time.Hour)
+	if len(slots) != 3 {
+		t.Fatalf("daily task: got %d slots, want 3", len(slots))
+	}
+
+	for i, slot := range slots {
+		if want := start.AddDate(0, 0, i); !slot.StartTime.Equal(want) {
+			t.Errorf("slot %d starts at %v, want %v", i, slot.StartTime, want)
+		}
+	}
+}
+
+func TestOpenRangeAndSlotsOverlap(t *testing.T) {
+	at := func(day int, hour int) time.Time {
+		return time.Date(2030, time.March, day, hour, 0, 0, 0, time.UTC)
+	}
+	slot := func(day int, from int, to int, status string) models.Task {
+		return models.Task{StartTime: at(day, from), EndTime: at(day, to), Status: status}
+	}
+
+	slots := []models.Task{
+		slot(10, 10, 11, models.TaskStatusTodo),
+		slot(9, 8, 9, models.TaskStatusDone),
+		slot(12, 10, 11, models.TaskStatusInProgress),
+	}
+
+	from, to, ok := openRange(slots)
+	if !ok || !from.Equal(at(10, 10)) || !to.Equal(at(12, 11)) {
+		t.Errorf("openRange = %v, %v, %v, want the open slots from the 10th to the 12th", from, to, ok)
+	}
+
+	if _, _, ok = openRange([]models.Task{slot(9, 8, 9, models.TaskStatusCancelled)}); ok {
+		t.Error("openRange of closed slots must not be ok")
+	}
+
+	tests := []struct {
+		name   string
+		others []models.Task
+		want   bool
+	}{
+		{"overlaps a later slot", []models.Task{slot(12, 9, 10, models.TaskStatusTodo)}, true},
+		{"between the slots", []models.Task{slot(11, 10, 11, models.TaskStatusTodo)}, false},
+		{"only a closed slot is hit", []models.Task{slot(9, 8, 9, models.TaskStatusTodo)}, false},
+		{"the other task is closed", []models.Task{slot(10, 10, 11, models.TaskStatusDone)}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := slotsOverlap(slots, tt.others); got != tt.want {
+				t.Errorf("slotsOverlap = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"golang.org/x/crypto/bcrypt"
+	"unicode"
+	"unicode/utf8"
+	"uzinfocom-todo/pkg/http_errors"
+)
+
+const (
+	defaultPasswordMinLength = 8
+	// bcrypt ignores everything after the 72nd byte
+	maxBcryptPasswordLength = 72
+)
+
+func (uc *userUseCase) validatePassword(password string) http_errors.RestErr {
+	minLength := uc.cfg.Server.PasswordMinLength
+	if minLength <= 0 {
+		minLength = defaultPasswordMinLength
+	}
+
+	maxLength := uc.cfg.Server.PasswordMaxLength
+	if maxLength <= 0 || maxLength > maxBcryptPasswordLength {
+		maxLength = maxBcryptPasswordLength
+	}
+
+	if utf8.RuneCountInString(password) < minLength {
+		return http_errors.PasswordTooShort(minLength)
+	}
+
+	// the maximum protects bcrypt, so it counts bytes
+	if len(password) > maxLength {
+		return http_errors.PasswordTooLong(maxLength)
+	}
+
+	var hasDigit, hasLetter, hasSpecial bool
+	for _, c := range password {
+		switch {
+		case unicode.IsDigit(c):
+			hasDigit = true
+		case unicode.IsLetter(c):
+			hasLetter = true
+		default:
+			hasSpecial = true
+		}
+	}
+
+	if uc.cfg.Server.PasswordRequireDigit && !hasDigit {
+		return http_errors.PasswordDigitRequired()
+	}
+
+	if uc.cfg.Server.PasswordRequireLetter && !hasLetter {
+		return http_errors.PasswordLetterRequired()
+	}
+
+	if uc.cfg.Server.PasswordRequireSpecial && !hasSpecial {
+		return http_errors.PasswordSpecialRequired()
+	}
+
+	return nil
+}
+
+func (uc *userUseCase) passwordHashCost() int {
+	if uc.cfg.Server.PasswordHashCost < bcrypt.MinCost {
+		return bcrypt.DefaultCost
+	}
+	return uc.cfg.Server.PasswordHashCost
+}
+
+// compareDummyPassword spends as long as a real password check, so logins of unknown phone
+// numbers and of accounts without a password do not answer faster than wrong passwords
+func (uc *userUseCase) compareDummyPassword(password string) {
+	uc.dummyPasswordHashOnce.Do(func() {
+		uc.dummyPasswordHash, _ = bcrypt.GenerateFromPassword([]byte("dummy password"), uc.passwordHashCost())
+	})
+	_ = bcrypt.CompareHashAndPassword(uc.dummyPasswordHash, []byte(password))
+}
@@ -0,0 +1,149 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"net/mail"
+	"strings"
+	"unicode/utf8"
+	"uzinfocom-todo/config"
+	"uzinfocom-todo/internal/models"
+	"uzinfocom-todo/internal/models/request_objects"
+	"uzinfocom-todo/internal/models/response_objects"
+	"uzinfocom-todo/internal/user"
+	"uzinfocom-todo/pkg/http_errors"
+)
+
+const (
+	maxNameLength  = 64
+	maxEmailLength = 255
+)
+
+func (uc *userUseCase) GetProfile(ctx context.Context, userId uuid.UUID) (*response_objects.ResponseProfile, http_errors.RestErr) {
+	foundUser, err := uc.userRepo.GetById(ctx, userId)
+
+	if err != nil {
+		return nil, http_errors.ParseErrors(err)
+	}
+	return newResponseProfile(foundUser), nil
+}
+
+func (uc *userUseCase) UpdateProfile(ctx context.Context, userId uuid.UUID, request request_objects.RequestUpdateProfile) (*response_objects.ResponseProfile, http_errors.RestErr) {
+	foundUser, err := uc.userRepo.GetById(ctx, userId)
+
+	if err != nil {
+		return nil, http_errors.ParseErrors(err)
+	}
+
+	if request.Name != nil {
+		name := strings.TrimSpace(*request.Name)
+		if name == "" || utf8.RuneCountInString(name) > maxNameLength {
+			return nil, http_errors.InvalidLength("name", maxNameLength)
+		}
+		foundUser.Name = name
+	}
+
+	if request.Timezone != nil {
+		if _, err = models.LoadTimezone(*request.Timezone); err != nil {
+			return nil, http_errors.InvalidTimezone()
+		}
+		foundUser.Timezone = *request.Timezone
+	}
+
+	if request.Locale != nil {
+		if !models.IsSupportedLocale(*request.Locale) {
+			return nil, http_errors.InvalidValue("locale", models.SupportedLocales...)
+		}
+		foundUser.Locale = *request.Locale
+	}
+
+	if request.Email != nil {
+		email := strings.TrimSpace(*request.Email)
+		if email != "" {
+			// the address is used as an SMTP recipient, so display names and the like are not accepted
+			if address, err := mail.ParseAddress(email); err != nil || address.Address != email || len(email) > maxEmailLength {
+				return nil, http_errors.InvalidEmail()
+			}
+		}
+		foundUser.Email = email
+	}
+
+	if err = uc.userRepo.UpdateProfile(ctx, *foundUser); err != nil {
+		return nil, http_errors.ParseErrors(err)
+	}
+
+	uc.sessionUC.ForgetUser(userId)
+	return newResponseProfile(foundUser), nil
+}
+
+// DeleteAccount removes the account after checking its password. What happens to its tasks
+// depends on the AccountDeletionPolicy setting, they are deleted unless it is "anonymise".
+func (uc *userUseCase) DeleteAccount(ctx context.Context, userId uuid.UUID, password string) http_errors.RestErr {
+	foundUser, err := uc.userRepo.GetById(ctx, userId)
+
+	if err != nil {
+		return http_errors.ParseErrors(err)
+	}
+
+	if err = foundUser.ComparePasswords(password); err != nil {
+		return http_errors.InvalidCredentials()
+	}
+
+	var anonymise bool
+
+	switch uc.cfg.Server.AccountDeletionPolicy {
+	case "", config.AccountDeletionDelete:
+		anonymise = false
+	case config.AccountDeletionAnonymise:
+		anonymise = true
+	default:
+		return http_errors.ParseErrors(errors.New("unknown account deletion policy: " + uc.cfg.Server.AccountDeletionPolicy))
+	}
+
+	// revoke first so the session cache stops accepting the user's tokens right away
+	if restErr := uc.sessionUC.RevokeAllSessions(ctx, userId); restErr != nil {
+		return restErr
+	}
+
+	var deleted bool
+
+	err = uc.userRepo.InTx(ctx, func(txRepo user.Repository) error {
+		if anonymise {
+			if err := txRepo.DisownTasks(ctx, userId); err != nil {
+				return err
+			}
+		}
+
+		res, err := txRepo.DeleteUser(ctx, userId)
+		if err != nil {
+			return err
+		}
+
+		affected, err := res.RowsAffected()
+		deleted = affected > 0
+		return err
+	})
+
+	if err != nil {
+		return http_errors.ParseErrors(err)
+	}
+
+	if !deleted {
+		return http_errors.UserNotFound()
+	}
+	return nil
+}
+
+func newResponseProfile(u *models.User) *response_objects.ResponseProfile {
+	return &response_objects.ResponseProfile{
+		UserId:           u.UserId,
+		Name:             u.Name,
+		PhoneNumber:      u.PhoneNumber,
+		Role:             u.Role,
+		Timezone:         u.Timezone,
+		Locale:           u.Locale,
+		Email:            u.Email,
+		TwoFactorEnabled: u.TotpEnabled,
+	}
+}
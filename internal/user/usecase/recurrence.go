@@ -0,0 +1,315 @@
+package usecase
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"sort"
+	"strings"
+	"time"
+	"uzinfocom-todo/internal/models"
+	"uzinfocom-todo/internal/models/response_objects"
+	"uzinfocom-todo/pkg/http_errors"
+	"uzinfocom-todo/pkg/rrule"
+)
+
+// defaultRecurrenceHorizon is how far ahead the occurrences of a recurring task are checked for overlaps
+const defaultRecurrenceHorizon = 365 * 24 * time.Hour
+
+// UpdateOccurrence edits or completes a single occurrence of a recurring task, the rest of the series
+// keeps its values. occurrence.OccurrenceStart identifies the occurrence, its nil fields are left as they are.
+func (uc *userUseCase) UpdateOccurrence(ctx context.Context, taskId uuid.UUID, occurrence models.TaskOccurrence) (*response_objects.ResponseTask, http_errors.RestErr) {
+	task, restErr := getActiveTask(ctx, uc.userRepo, taskId)
+	if restErr != nil {
+		return nil, restErr
+	}
+
+	if task.RecurrenceRule == nil {
+		return nil, http_errors.TaskNotRecurring()
+	}
+
+	rule, err := rrule.Parse(*task.RecurrenceRule)
+	if err != nil {
+		return nil, http_errors.ParseErrors(err)
+	}
+
+	if !rule.Includes(task.StartTime.In(task.Location()), occurrence.OccurrenceStart) {
+		return nil, http_errors.OccurrenceNotFound()
+	}
+
+	occurrence.TaskId = taskId
+
+	var existing *models.TaskOccurrence
+	for i := range task.Overrides {
+		if task.Overrides[i].OccurrenceStart.Equal(occurrence.OccurrenceStart) {
+			existing = &task.Overrides[i]
+		}
+	}
+
+	current := applyOccurrence(*task, occurrence.OccurrenceStart, existing)
+	merged := mergeOccurrence(existing, occurrence)
+	updated := applyOccurrence(*task, occurrence.OccurrenceStart, &merged)
+
+	if !updated.EndTime.After(updated.StartTime) {
+		return nil, http_errors.EndBeforeStart()
+	}
+
+	if restErr = validateTransition(current.Status, updated); restErr != nil {
+		return nil, restErr
+	}
+
+	if updated.IsOpen() {
+		if restErr = uc.checkOverlap(ctx, updated); restErr != nil {
+			return nil, restErr
+		}
+	}
+
+	if err = uc.userRepo.SaveOccurrence(ctx, merged); err != nil {
+		return nil, http_errors.ParseErrors(err)
+	}
+
+	responseTask := response_objects.NewResponseTask(ctx, updated)
+	return &responseTask, nil
+}
+
+func normalizeRecurrence(task *models.Task) http_errors.RestErr {
+	if task.RecurrenceRule == nil {
+		return nil
+	}
+
+	rule, err := rrule.Parse(*task.RecurrenceRule)
+	if err != nil {
+		return http_errors.InvalidRecurrenceRule(strings.TrimPrefix(err.Error(), rrule.ErrInvalidRule.Error()+": "))
+	}
+
+	normalized := rule.String()
+	task.RecurrenceRule = &normalized
+	return nil
+}
+
+// expandTaskListing lists one-off tasks together with the occurrences of recurring tasks that fall
+// within filter.From and filter.To. The result is not paginated, filter.Limit only caps its length.
+func (uc *userUseCase) expandTaskListing(ctx context.Context, filter models.TaskFilter) (*[]response_objects.ResponseTask, http_errors.RestErr) {
+	oneOff, recurring := false, true
+
+	singleFilter := filter
+	singleFilter.Recurring = &oneOff
+	singleFilter.Limit = 0
+	singleFilter.Cursor = nil
+
+	tasks, _, err := uc.userRepo.FindTasks(ctx, singleFilter)
+	if err != nil {
+		return nil, http_errors.ParseErrors(err)
+	}
+
+	// a series that started before the window can still have occurrences in it, and the status
+	// of an occurrence can differ from the one of its series
+	seriesFilter := filter
+	seriesFilter.Recurring = &recurring
+	seriesFilter.From = nil
+	seriesFilter.Limit = 0
+	seriesFilter.Cursor = nil
+
+	keepDeleted := filter.Status == models.TaskStatusDeleted || filter.Status == models.TaskStatusAll
+	if !keepDeleted {
+		seriesFilter.Status = models.TaskStatusAll
+	}
+
+	series, _, err := uc.userRepo.FindTasks(ctx, seriesFilter)
+	if err != nil {
+		return nil, http_errors.ParseErrors(err)
+	}
+
+	for _, s := range series {
+		if s.DeletedAt != nil && !keepDeleted {
+			continue
+		}
+
+		for _, occurrence := range expandTask(s, *filter.From, *filter.To) {
+			if matchesStatusFilter(filter.Status, occurrence) {
+				tasks = append(tasks, occurrence)
+			}
+		}
+	}
+
+	sortTasks(tasks, filter.Sort, filter.Order)
+
+	if filter.Limit > 0 && len(tasks) > filter.Limit {
+		tasks = tasks[:filter.Limit]
+	}
+
+	responseTasks := make([]response_objects.ResponseTask, 0, len(tasks))
+	for _, t := range tasks {
+		responseTasks = append(responseTasks, response_objects.NewResponseTask(ctx, t))
+	}
+	return &responseTasks, nil
+}
+
+// expandTask returns the occurrences of a recurring task that touch [from, to] with their overrides
+// applied. Overrides of starts the rule no longer produces are ignored. A one-off task or a single
+// occurrence is returned as it is.
+func expandTask(task models.Task, from time.Time, to time.Time) []models.Task {
+	if task.RecurrenceRule == nil || task.Occurrence != nil {
+		return []models.Task{task}
+	}
+
+	rule, err := rrule.Parse(*task.RecurrenceRule)
+	if err != nil {
+		return []models.Task{task}
+	}
+
+	// occurrences keep the wall clock time of the first one in the zone of the task, across DST changes too
+	dtstart := task.StartTime.In(task.Location())
+
+	overrides := make(map[int64]*models.TaskOccurrence)
+	for i := range task.Overrides {
+		overrides[task.Overrides[i].OccurrenceStart.Unix()] = &task.Overrides[i]
+	}
+
+	occurrences := []models.Task{}
+	seen := make(map[int64]bool)
+
+	for _, start := range rule.Between(dtstart, from.Add(-task.EndTime.Sub(task.StartTime)), to) {
+		seen[start.Unix()] = true
+
+		occurrence := applyOccurrence(task, start, overrides[start.Unix()])
+		if inWindow(occurrence, from, to) {
+			occurrences = append(occurrences, occurrence)
+		}
+	}
+
+	// an override can move an occurrence from outside the window into it
+	for i := range task.Overrides {
+		override := &task.Overrides[i]
+		if seen[override.OccurrenceStart.Unix()] || override.StartTime == nil {
+			continue
+		}
+
+		if !rule.Includes(dtstart, override.OccurrenceStart) {
+			continue
+		}
+
+		occurrence := applyOccurrence(task, override.OccurrenceStart, override)
+		if inWindow(occurrence, from, to) {
+			occurrences = append(occurrences, occurrence)
+		}
+	}
+	return occurrences
+}
+
+func applyOccurrence(task models.Task, start time.Time, override *models.TaskOccurrence) models.Task {
+	occurrence := task
+	occurrence.Occurrence = &start
+	occurrence.StartTime = start
+	occurrence.EndTime = start.Add(task.EndTime.Sub(task.StartTime))
+	occurrence.Overrides = nil
+
+	if override == nil {
+		return occurrence
+	}
+
+	if override.Title != nil {
+		occurrence.Title = *override.Title
+	}
+
+	if override.Description != nil {
+		occurrence.Description = *override.Description
+	}
+
+	// moving only the start keeps the length of the occurrence
+	if override.StartTime != nil {
+		occurrence.StartTime = *override.StartTime
+		occurrence.EndTime = occurrence.StartTime.Add(task.EndTime.Sub(task.StartTime))
+	}
+
+	if override.EndTime != nil {
+		occurrence.EndTime = *override.EndTime
+	}
+
+	if override.Status != nil {
+		occurrence.Status = *override.Status
+	}
+	return occurrence
+}
+
+func mergeOccurrence(existing *models.TaskOccurrence, update models.TaskOccurrence) models.TaskOccurrence {
+	if existing == nil {
+		return update
+	}
+
+	merged := *existing
+
+	if update.Title != nil {
+		merged.Title = update.Title
+	}
+
+	if update.Description != nil {
+		merged.Description = update.Description
+	}
+
+	if update.StartTime != nil {
+		merged.StartTime = update.StartTime
+	}
+
+	if update.EndTime != nil {
+		merged.EndTime = update.EndTime
+	}
+
+	if update.Status != nil {
+		merged.Status = update.Status
+	}
+	return merged
+}
+
+func inWindow(task models.Task, from time.Time, to time.Time) bool {
+	return !task.EndTime.Before(from) && !task.StartTime.After(to)
+}
+
+func matchesStatusFilter(status string, task models.Task) bool {
+	switch status {
+	case models.TaskStatusOpen:
+		return task.IsOpen()
+	case models.TaskStatusAll, models.TaskStatusDeleted:
+		return true
+	}
+	return task.Status == status
+}
+
+func sortTasks(tasks []models.Task, sortBy string, order string) {
+	sort.SliceStable(tasks, func(i, j int) bool {
+		a, b := tasks[i], tasks[j]
+		if order == models.SortDesc {
+			a, b = b, a
+		}
+
+		switch sortBy {
+		case models.TaskSortEndTime:
+			if !a.EndTime.Equal(b.EndTime) {
+				return a.EndTime.Before(b.EndTime)
+			}
+		case models.TaskSortTitle:
+			if a.Title != b.Title {
+				return a.Title < b.Title
+			}
+		case models.TaskSortRank:
+			if a.Rank != b.Rank {
+				return a.Rank < b.Rank
+			}
+		default:
+			if !a.StartTime.Equal(b.StartTime) {
+				return a.StartTime.Before(b.StartTime)
+			}
+		}
+
+		if a.TaskID != b.TaskID {
+			return strings.Compare(a.TaskID.String(), b.TaskID.String()) < 0
+		}
+		return a.StartTime.Before(b.StartTime)
+	})
+}
+
+func (uc *userUseCase) recurrenceHorizon() time.Duration {
+	if uc.cfg.Server.RecurrenceHorizon <= 0 {
+		return defaultRecurrenceHorizon
+	}
+	return uc.cfg.Server.RecurrenceHorizon
+}
@@ -0,0 +1,84 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"time"
+	"uzinfocom-todo/internal/models/response_objects"
+	"uzinfocom-todo/pkg/http_errors"
+)
+
+const defaultTrashRetention = 30 * 24 * time.Hour
+
+// RestoreTask takes a task out of the trash. An open task may only come back if its time is still free.
+func (uc *userUseCase) RestoreTask(ctx context.Context, taskId uuid.UUID) http_errors.RestErr {
+	t, err := uc.userRepo.GetTaskById(ctx, taskId)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return http_errors.TaskNotInTrash()
+		}
+		return http_errors.ParseErrors(err)
+	}
+
+	if t.DeletedAt == nil {
+		return http_errors.TaskNotInTrash()
+	}
+
+	if t.IsOpen() {
+		if restErr := uc.checkOverlap(ctx, *t); restErr != nil {
+			return restErr
+		}
+	}
+
+	res, err := uc.userRepo.RestoreTask(ctx, taskId)
+
+	if err != nil {
+		return http_errors.ParseErrors(err)
+	}
+
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return http_errors.TaskNotInTrash()
+	}
+	return nil
+}
+
+func (uc *userUseCase) PurgeTask(ctx context.Context, taskId uuid.UUID) http_errors.RestErr {
+	res, err := uc.userRepo.PurgeTask(ctx, taskId)
+
+	if err != nil {
+		return http_errors.ParseErrors(err)
+	}
+
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return http_errors.ObjectNotFoundToDelete()
+	}
+	return nil
+}
+
+func (uc *userUseCase) EmptyTrash(ctx context.Context) (*response_objects.ResponsePurged, http_errors.RestErr) {
+	deleted, err := uc.userRepo.EmptyTrash(ctx)
+
+	if err != nil {
+		return nil, http_errors.ParseErrors(err)
+	}
+	return &response_objects.ResponsePurged{Deleted: deleted}, nil
+}
+
+func (uc *userUseCase) PurgeExpiredTrash(ctx context.Context) (int64, http_errors.RestErr) {
+	deleted, err := uc.userRepo.PurgeDeletedTasks(ctx, uc.trashRetention())
+
+	if err != nil {
+		return 0, http_errors.ParseErrors(err)
+	}
+	return deleted, nil
+}
+
+func (uc *userUseCase) trashRetention() time.Duration {
+	if uc.cfg.Server.TrashRetention <= 0 {
+		return defaultTrashRetention
+	}
+	return uc.cfg.Server.TrashRetention
+}
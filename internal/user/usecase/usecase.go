@@ -2,36 +2,178 @@ package usecase
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"github.com/google/uuid"
-	"time"
+	"sync"
 	"uzinfocom-todo/config"
+	"uzinfocom-todo/internal/list"
 	"uzinfocom-todo/internal/models"
 	"uzinfocom-todo/internal/models/response_objects"
+	"uzinfocom-todo/internal/session"
 	"uzinfocom-todo/internal/user"
 	"uzinfocom-todo/pkg/http_errors"
 )
 
 type userUseCase struct {
-	userRepo user.Repository
-	cfg      *config.Config
+	userRepo  user.Repository
+	listRepo  list.Repository
+	sessionUC session.UseCase
+	cfg       *config.Config
+
+	dummyPasswordHash     []byte
+	dummyPasswordHashOnce sync.Once
 }
 
-func NewUserUseCase(userRepo user.Repository, cfg *config.Config) user.UseCase {
+func NewUserUseCase(userRepo user.Repository, listRepo list.Repository, sessionUC session.UseCase, cfg *config.Config) user.UseCase {
 	return &userUseCase{
-		userRepo: userRepo,
-		cfg:      cfg,
+		userRepo:  userRepo,
+		listRepo:  listRepo,
+		sessionUC: sessionUC,
+		cfg:       cfg,
 	}
 }
 
 func (uc *userUseCase) Create(ctx context.Context, user models.User) http_errors.RestErr {
+	if restErr := uc.validatePassword(user.Password); restErr != nil {
+		return restErr
+	}
+
+	if err := user.HashPassword(uc.passwordHashCost()); err != nil {
+		return http_errors.ParseErrors(err)
+	}
+
+	user.Role = models.RoleUser
+
+	for _, phone := range uc.cfg.Server.AdminPhoneNumbers {
+		if phone == user.PhoneNumber {
+			user.Role = models.RoleAdmin
+		}
+	}
+
 	err := uc.userRepo.Create(ctx, user)
 
+	if err != nil {
+		if errors.Is(err, models.ErrPhoneTaken) {
+			return http_errors.PhoneTaken()
+		}
+		return http_errors.ParseErrors(err)
+	}
+	return nil
+}
+
+func (uc *userUseCase) Login(ctx context.Context, user models.User) (*models.User, http_errors.RestErr) {
+	foundUser, err := uc.userRepo.GetByPhoneNumber(ctx, user.PhoneNumber)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			uc.compareDummyPassword(user.Password)
+			return nil, http_errors.InvalidCredentials()
+		}
+		return nil, http_errors.ParseErrors(err)
+	}
+
+	locked, err := uc.userRepo.GetLoginLock(ctx, foundUser.UserId)
+
+	if err != nil {
+		return nil, http_errors.ParseErrors(err)
+	}
+
+	if locked > 0 {
+		return nil, http_errors.AccountLocked(locked)
+	}
+
+	if foundUser.Password == "" {
+		uc.compareDummyPassword(user.Password)
+		return nil, uc.registerFailedLogin(ctx, foundUser.UserId)
+	}
+
+	if err = foundUser.ComparePasswords(user.Password); err != nil {
+		return nil, uc.registerFailedLogin(ctx, foundUser.UserId)
+	}
+
+	if foundUser.DisabledAt != nil {
+		return nil, http_errors.AccountDisabled()
+	}
+
+	// with 2FA the failures keep counting until the second step succeeds, so a known password
+	// does not reset the lockout of someone guessing codes
+	if !foundUser.TotpEnabled {
+		if err = uc.userRepo.ResetFailedLogins(ctx, foundUser.UserId); err != nil {
+			return nil, http_errors.ParseErrors(err)
+		}
+	}
+
+	foundUser.SanitizePassword()
+	return foundUser, nil
+}
+
+// ChangePassword replaces the password of the user. Accounts that only ever logged in by OTP
+// have no password yet, they set their first one without an old password.
+func (uc *userUseCase) ChangePassword(ctx context.Context, userId uuid.UUID, oldPassword string, newPassword string) http_errors.RestErr {
+	foundUser, err := uc.userRepo.GetById(ctx, userId)
+
+	if err != nil {
+		return http_errors.ParseErrors(err)
+	}
+
+	if foundUser.Password != "" {
+		if err = foundUser.ComparePasswords(oldPassword); err != nil {
+			return http_errors.WrongOldPassword()
+		}
+	}
+
+	if restErr := uc.validatePassword(newPassword); restErr != nil {
+		return restErr
+	}
+
+	foundUser.Password = newPassword
+
+	if err = foundUser.HashPassword(uc.passwordHashCost()); err != nil {
+		return http_errors.ParseErrors(err)
+	}
+
+	if err = uc.userRepo.UpdatePassword(ctx, userId, foundUser.Password); err != nil {
+		return http_errors.ParseErrors(err)
+	}
+	return nil
+}
+
+func (uc *userUseCase) GetUsers(ctx context.Context, limit int, offset int) (*[]response_objects.ResponseUser, http_errors.RestErr) {
+	users, err := uc.userRepo.GetUsers(ctx, limit, offset)
+
+	if err != nil {
+		return nil, http_errors.ParseErrors(err)
+	}
+	return users, nil
+}
+
+func (uc *userUseCase) SetDisabled(ctx context.Context, userId uuid.UUID, disabled bool) http_errors.RestErr {
+	res, err := uc.userRepo.SetDisabled(ctx, userId, disabled)
+
 	if err != nil {
 		return http_errors.ParseErrors(err)
 	}
+
+	if affected, _ := res.RowsAffected(); affected == 0 {
+		return http_errors.UserNotFound()
+	}
+
+	if disabled {
+		return uc.sessionUC.RevokeAllSessions(ctx, userId)
+	}
 	return nil
 }
 
+func (uc *userUseCase) GetTaskCounts(ctx context.Context, userId uuid.UUID) (*response_objects.ResponseTaskCounts, http_errors.RestErr) {
+	counts, err := uc.userRepo.GetTaskCounts(ctx, userId)
+
+	if err != nil {
+		return nil, http_errors.ParseErrors(err)
+	}
+	return counts, nil
+}
+
 func (uc *userUseCase) GetByPhoneNumber(ctx context.Context, phone string) (*models.User, http_errors.RestErr) {
 	user, err := uc.userRepo.GetByPhoneNumber(ctx, phone)
 
@@ -41,34 +183,38 @@ func (uc *userUseCase) GetByPhoneNumber(ctx context.Context, phone string) (*mod
 	return user, nil
 }
 
-func (uc *userUseCase) GetAllTasks(ctx context.Context) (*[]response_objects.ResponseTask, http_errors.RestErr) {
-	tasks, err := uc.userRepo.GetAllTasks(ctx)
+func (uc *userUseCase) GetAllTasks(ctx context.Context, filter models.TaskFilter) (*[]response_objects.ResponseTask, string, http_errors.RestErr) {
+	if filter.Expand {
+		tasks, restErr := uc.expandTaskListing(ctx, filter)
+		return tasks, "", restErr
+	}
+
+	tasks, nextCursor, err := uc.userRepo.GetAllTasks(ctx, filter)
 	if err != nil {
-		return nil, http_errors.ParseErrors(err)
+		return nil, "", http_errors.ParseErrors(err)
 	}
-	return tasks, nil
+	return tasks, nextCursor, nil
 }
 
 func (uc *userUseCase) CreateTask(ctx context.Context, task models.Task) http_errors.RestErr {
-	responseTasks, _ := uc.userRepo.GetAllTasks(ctx)
-
-	if len(*responseTasks) > 0 {
-		for _, responseTask := range *responseTasks {
-			checkTime1 := task.StartTime
-			checkTime2 := task.EndTime
-			responseTaskStartTime, _ := time.Parse("02-01-2006 15:04", responseTask.StartTime)
-			responseTaskEndTime, _ := time.Parse("02-01-2006 15:04", responseTask.EndTime)
-			if (checkTime1.After(responseTaskStartTime) && checkTime1.Before(responseTaskEndTime)) || (checkTime2.After(responseTaskStartTime) && checkTime2.Before(
-				responseTaskEndTime)) || (checkTime1.Equal(responseTaskStartTime) || checkTime1.Equal(
-				responseTaskEndTime)) || (checkTime2.Equal(
-				responseTaskStartTime) || checkTime2.Equal(
-				responseTaskEndTime) || (checkTime1.Before(responseTaskStartTime) && checkTime2.After(responseTaskEndTime))) {
-				return http_errors.TaskExistsBetweenGivenTime()
-			}
+	if restErr := validatePriority(task.Priority); restErr != nil {
+		return restErr
+	}
+
+	if restErr := normalizeRecurrence(&task); restErr != nil {
+		return restErr
+	}
 
+	if task.ListId != nil {
+		if _, restErr := uc.getWritableList(ctx, *task.ListId); restErr != nil {
+			return restErr
 		}
 	}
 
+	if restErr := uc.checkOverlap(ctx, task); restErr != nil {
+		return restErr
+	}
+
 	err := uc.userRepo.CreateTask(ctx, task)
 
 	if err != nil {
@@ -97,39 +243,64 @@ func (uc *userUseCase) GetTaskById(ctx context.Context, taskId uuid.UUID) (*mode
 	t, err := uc.userRepo.GetTaskById(ctx, taskId)
 
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, http_errors.TaskNotFound()
+		}
 		return nil, http_errors.ParseErrors(err)
 	}
 
 	return t, nil
 }
 
-func (uc *userUseCase) UpdateTask(ctx context.Context, task models.Task) http_errors.RestErr {
+func (uc *userUseCase) GetTask(ctx context.Context, taskId uuid.UUID) (*response_objects.ResponseTask, http_errors.RestErr) {
+	t, err := uc.userRepo.GetTaskById(ctx, taskId)
 
-	//if task.IsDone == true {
-	//	now := time.Now()
-	//
-	//	if now.Before(task.StartTime) {
-	//		return http_errors.UpdateIsDoneErr()
-	//	}
-	//}
-
-	responseTasks, _ := uc.userRepo.GetAllTasks(ctx)
-
-	if len(*responseTasks) > 0 {
-		for _, responseTask := range *responseTasks {
-			checkTime1 := task.StartTime
-			checkTime2 := task.EndTime
-			responseTaskStartTime, _ := time.Parse("02-01-2006 15:04", responseTask.StartTime)
-			responseTaskEndTime, _ := time.Parse("02-01-2006 15:04", responseTask.EndTime)
-
-			if (checkTime1.After(responseTaskStartTime) && checkTime1.Before(responseTaskEndTime)) || (checkTime2.After(responseTaskStartTime) && checkTime2.Before(
-				responseTaskEndTime)) || (checkTime1.Equal(responseTaskStartTime) || checkTime1.Equal(
-				responseTaskEndTime)) || (checkTime2.Equal(
-				responseTaskStartTime) || checkTime2.Equal(
-				responseTaskEndTime) || (checkTime1.Before(responseTaskStartTime) && checkTime2.After(responseTaskEndTime))) {
-				return http_errors.TaskExistsBetweenGivenTime()
-			}
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, http_errors.TaskNotFound()
 		}
+		return nil, http_errors.ParseErrors(err)
+	}
+
+	responseTask := response_objects.NewResponseTask(ctx, *t)
+	return &responseTask, nil
+}
+
+func (uc *userUseCase) SearchTasks(ctx context.Context, text string, limit int, offset int) (*[]response_objects.ResponseTaskSearchResult, http_errors.RestErr) {
+	results, err := uc.userRepo.SearchTasks(ctx, text, limit, offset)
+
+	if err != nil {
+		return nil, http_errors.ParseErrors(err)
+	}
+	return results, nil
+}
+
+// UpdateTask saves the task, a status change has to be allowed by the task workflow.
+// For a recurring task the whole series is changed.
+func (uc *userUseCase) UpdateTask(ctx context.Context, task models.Task) http_errors.RestErr {
+	// trashed tasks have to be restored before they can be edited
+	current, restErr := getActiveTask(ctx, uc.userRepo, task.TaskID)
+	if restErr != nil {
+		return restErr
+	}
+
+	if restErr := validateTransition(current.Status, task); restErr != nil {
+		return restErr
+	}
+
+	if restErr := validatePriority(task.Priority); restErr != nil {
+		return restErr
+	}
+
+	if restErr := normalizeRecurrence(&task); restErr != nil {
+		return restErr
+	}
+
+	// occurrences edited on their own stay edited when the series changes
+	task.Overrides = current.Overrides
+
+	if restErr := uc.checkOverlap(ctx, task); restErr != nil {
+		return restErr
 	}
 
 	_, err := uc.userRepo.UpdateTask(ctx, task)
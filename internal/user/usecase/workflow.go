@@ -0,0 +1,176 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/google/uuid"
+	"time"
+	"uzinfocom-todo/internal/models"
+	"uzinfocom-todo/internal/models/response_objects"
+	"uzinfocom-todo/internal/user"
+	"uzinfocom-todo/pkg/http_errors"
+)
+
+// minRankGap is the smallest gap between two ranks that can still be split, below it the column is rebalanced
+const minRankGap = 1e-6
+
+var errNoRankAfterRebalance = errors.New("no rank fits between the neighbours of the task after rebalancing its column")
+
+// taskTransitions lists the statuses a task may move to from each status. Done and cancelled tasks
+// can only be reopened, blocked tasks have to be unblocked before they are done.
+var taskTransitions = map[string][]string{
+	models.TaskStatusTodo:       {models.TaskStatusInProgress, models.TaskStatusBlocked, models.TaskStatusDone, models.TaskStatusCancelled},
+	models.TaskStatusInProgress: {models.TaskStatusTodo, models.TaskStatusBlocked, models.TaskStatusDone, models.TaskStatusCancelled},
+	models.TaskStatusBlocked:    {models.TaskStatusTodo, models.TaskStatusInProgress, models.TaskStatusCancelled},
+	models.TaskStatusDone:       {models.TaskStatusTodo, models.TaskStatusInProgress},
+	models.TaskStatusCancelled:  {models.TaskStatusTodo},
+}
+
+// validateTransition checks that task may move from its current status to status. A task can only be
+// done once it has started.
+func validateTransition(current string, task models.Task) http_errors.RestErr {
+	if !models.IsWorkflowTaskStatus(task.Status) {
+		return http_errors.InvalidValue("status", models.TaskStatusTodo, models.TaskStatusInProgress, models.TaskStatusBlocked,
+			models.TaskStatusDone, models.TaskStatusCancelled)
+	}
+
+	if task.Status == current {
+		return nil
+	}
+
+	allowed := false
+	for _, next := range taskTransitions[current] {
+		if next == task.Status {
+			allowed = true
+			break
+		}
+	}
+
+	if !allowed {
+		return http_errors.InvalidTransition(current, task.Status)
+	}
+
+	if task.Status == models.TaskStatusDone && time.Now().Before(task.StartTime) {
+		return http_errors.UpdateIsDoneErr()
+	}
+	return nil
+}
+
+func validatePriority(priority string) http_errors.RestErr {
+	if !models.IsValidTaskPriority(priority) {
+		return http_errors.InvalidValue("priority", models.TaskPriorityLow, models.TaskPriorityMedium, models.TaskPriorityHigh,
+			models.TaskPriorityUrgent)
+	}
+	return nil
+}
+
+func (uc *userUseCase) RankTask(ctx context.Context, taskId uuid.UUID, afterId *uuid.UUID) (*response_objects.ResponseTask, http_errors.RestErr) {
+	var ranked *models.Task
+
+	err := uc.userRepo.InTx(ctx, func(txRepo user.Repository) error {
+		task, restErr := getActiveTask(ctx, txRepo, taskId)
+		if restErr != nil {
+			return restErr
+		}
+
+		rank, restErr := placeAfter(ctx, txRepo, *task, afterId)
+		if restErr != nil {
+			return restErr
+		}
+
+		if rank == nil {
+			// splitting the gap lost precision, spread the column out and try again
+			if err := txRepo.RebalanceRanks(ctx, task.Status); err != nil {
+				return err
+			}
+
+			if rank, restErr = placeAfter(ctx, txRepo, *task, afterId); restErr != nil {
+				return restErr
+			}
+
+			if rank == nil {
+				return errNoRankAfterRebalance
+			}
+		}
+
+		if _, err := txRepo.SetTaskRank(ctx, taskId, *rank); err != nil {
+			return err
+		}
+
+		var err error
+		ranked, err = txRepo.GetTaskById(ctx, taskId)
+		return err
+	})
+
+	if restErr, ok := err.(http_errors.RestErr); ok {
+		return nil, restErr
+	}
+
+	if err != nil {
+		return nil, http_errors.ParseErrors(err)
+	}
+
+	responseTask := response_objects.NewResponseTask(ctx, *ranked)
+	return &responseTask, nil
+}
+
+// placeAfter returns the rank that puts task right after afterId in its column. It returns nil
+// when no rank fits between the neighbours.
+func placeAfter(ctx context.Context, repo user.Repository, task models.Task, afterId *uuid.UUID) (*float64, http_errors.RestErr) {
+	var after *float64
+
+	if afterId != nil {
+		if *afterId == task.TaskID {
+			return nil, http_errors.RankAfterSelf()
+		}
+
+		afterTask, restErr := getActiveTask(ctx, repo, *afterId)
+		if restErr != nil {
+			return nil, restErr
+		}
+
+		if afterTask.Status != task.Status {
+			return nil, http_errors.RankAfterOtherStatus()
+		}
+		after = &afterTask.Rank
+	}
+
+	next, err := repo.NextRank(ctx, task.Status, after, task.TaskID)
+	if err != nil {
+		return nil, http_errors.ParseErrors(err)
+	}
+
+	var rank float64
+
+	switch {
+	case after == nil && next == nil:
+		rank = task.Rank
+	case after == nil:
+		rank = *next - models.TaskRankStep
+	case next == nil:
+		rank = *after + models.TaskRankStep
+	default:
+		if *next-*after < minRankGap {
+			return nil, nil
+		}
+		rank = *after + (*next-*after)/2
+	}
+	return &rank, nil
+}
+
+func getActiveTask(ctx context.Context, repo user.Repository, taskId uuid.UUID) (*models.Task, http_errors.RestErr) {
+	task, err := repo.GetTaskById(ctx, taskId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, http_errors.TaskNotFound()
+	}
+
+	if err != nil {
+		return nil, http_errors.ParseErrors(err)
+	}
+
+	if task.DeletedAt != nil {
+		return nil, http_errors.TaskNotFound()
+	}
+	return task, nil
+}
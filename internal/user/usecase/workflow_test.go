@@ -0,0 +1,155 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"github.com/google/uuid"
+	"net/http"
+	"testing"
+	"time"
+	"uzinfocom-todo/internal/models"
+	"uzinfocom-todo/internal/user"
+)
+
+// rankRepository keeps the tasks of a single user in memory, only the methods placeAfter uses are implemented
+type rankRepository struct {
+	user.Repository
+	tasks map[uuid.UUID]*models.Task
+}
+
+func newRankRepository(tasks ...models.Task) *rankRepository {
+	repo := &rankRepository{tasks: make(map[uuid.UUID]*models.Task)}
+	for i := range tasks {
+		repo.tasks[tasks[i].TaskID] = &tasks[i]
+	}
+	return repo
+}
+
+func (r *rankRepository) GetTaskById(ctx context.Context, taskId uuid.UUID) (*models.Task, error) {
+	task, ok := r.tasks[taskId]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	return task, nil
+}
+
+func (r *rankRepository) NextRank(ctx context.Context, status string, after *float64, taskId uuid.UUID) (*float64, error) {
+	var next *float64
+
+	for _, task := range r.tasks {
+		if task.TaskID == taskId || task.Status != status || task.DeletedAt != nil || (after != nil && task.Rank <= *after) {
+			continue
+		}
+
+		if next == nil || task.Rank < *next {
+			rank := task.Rank
+			next = &rank
+		}
+	}
+	return next, nil
+}
+
+func rankedTask(status string, rank float64) models.Task {
+	return models.Task{TaskID: uuid.New(), Status: status, Rank: rank, StartTime: time.Now().Add(-time.Hour)}
+}
+
+func TestValidateTransition(t *testing.T) {
+	started := time.Now().Add(-time.Hour)
+	upcoming := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name       string
+		current    string
+		next       string
+		startTime  time.Time
+		wantStatus int
+	}{
+		{"unchanged", models.TaskStatusBlocked, models.TaskStatusBlocked, started, 0},
+		{"start", models.TaskStatusTodo, models.TaskStatusInProgress, started, 0},
+		{"finish", models.TaskStatusInProgress, models.TaskStatusDone, started, 0},
+		{"reopen done", models.TaskStatusDone, models.TaskStatusTodo, started, 0},
+		{"reopen cancelled", models.TaskStatusCancelled, models.TaskStatusTodo, started, 0},
+		{"unblock", models.TaskStatusBlocked, models.TaskStatusInProgress, started, 0},
+		{"finish blocked", models.TaskStatusBlocked, models.TaskStatusDone, started, http.StatusConflict},
+		{"resume cancelled", models.TaskStatusCancelled, models.TaskStatusInProgress, started, http.StatusConflict},
+		{"cancel done", models.TaskStatusDone, models.TaskStatusCancelled, started, http.StatusConflict},
+		{"finish before the start", models.TaskStatusTodo, models.TaskStatusDone, upcoming, http.StatusBadRequest},
+		{"unknown status", models.TaskStatusTodo, "archived", started, http.StatusBadRequest},
+		{"filter only status", models.TaskStatusTodo, models.TaskStatusOpen, started, http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			restErr := validateTransition(tt.current, models.Task{Status: tt.next, StartTime: tt.startTime})
+
+			if tt.wantStatus == 0 {
+				if restErr != nil {
+					t.Errorf("got %v, want the transition allowed", restErr)
+				}
+				return
+			}
+
+			if restErr == nil || restErr.Status() != tt.wantStatus {
+				t.Errorf("got %v, want status %d", restErr, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestPlaceAfter(t *testing.T) {
+	first := rankedTask(models.TaskStatusTodo, 1024)
+	second := rankedTask(models.TaskStatusTodo, 2048)
+	moving := rankedTask(models.TaskStatusTodo, 3072)
+	crowded := rankedTask(models.TaskStatusTodo, 1024+minRankGap/2)
+	other := rankedTask(models.TaskStatusDone, 1024)
+	deleted := rankedTask(models.TaskStatusTodo, 512)
+	deleted.DeletedAt = time.Now()
+
+	tests := []struct {
+		name       string
+		tasks      []models.Task
+		afterId    *uuid.UUID
+		want       float64
+		wantNoRank bool
+		wantStatus int
+	}{
+		{name: "to the top", tasks: []models.Task{first, second}, want: 0},
+		{name: "between two tasks", tasks: []models.Task{first, second}, afterId: &first.TaskID, want: 1536},
+		{name: "to the bottom", tasks: []models.Task{first, second}, afterId: &second.TaskID, want: 3072},
+		{name: "alone in the column", want: 3072},
+		{name: "no gap left", tasks: []models.Task{first, crowded}, afterId: &first.TaskID, wantNoRank: true},
+		{name: "after itself", afterId: &moving.TaskID, wantStatus: http.StatusBadRequest},
+		{name: "after a task of another column", tasks: []models.Task{other}, afterId: &other.TaskID, wantStatus: http.StatusBadRequest},
+		{name: "after a deleted task", tasks: []models.Task{deleted}, afterId: &deleted.TaskID, wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newRankRepository(append(tt.tasks, moving)...)
+
+			rank, restErr := placeAfter(context.Background(), repo, moving, tt.afterId)
+
+			if tt.wantStatus != 0 {
+				if restErr == nil || restErr.Status() != tt.wantStatus {
+					t.Errorf("got %v, want status %d", restErr, tt.wantStatus)
+				}
+				return
+			}
+
+			if restErr != nil {
+				t.Fatalf("placeAfter: %v", restErr)
+			}
+
+			if tt.wantNoRank {
+				if rank != nil {
+					t.Errorf("rank = %v, want none", *rank)
+				}
+				return
+			}
+
+			if rank == nil || *rank != tt.want {
+				t.Errorf("rank = %v, want %v", rank, tt.want)
+			}
+		})
+	}
+}
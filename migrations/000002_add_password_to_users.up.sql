@@ -0,0 +1 @@
+ALTER TABLE users ADD COLUMN password varchar(255);
@@ -0,0 +1,13 @@
+CREATE TABLE otp_codes
+(
+    otp_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    phone_number varchar(20) NOT NULL,
+    code_hash varchar(64) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP DEFAULT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (phone_number) REFERENCES users(phone_number) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX otp_codes_phone_number_created_at_idx ON otp_codes (phone_number, created_at);
@@ -0,0 +1,15 @@
+ALTER TABLE users ADD COLUMN totp_secret varchar(64) DEFAULT NULL;
+ALTER TABLE users ADD COLUMN totp_enabled_at TIMESTAMP DEFAULT NULL;
+ALTER TABLE users ADD COLUMN totp_last_used_step BIGINT DEFAULT NULL;
+
+CREATE TABLE recovery_codes
+(
+    code_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL,
+    code_hash varchar(64) NOT NULL,
+    used_at TIMESTAMP DEFAULT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+CREATE INDEX recovery_codes_user_id_idx ON recovery_codes (user_id);
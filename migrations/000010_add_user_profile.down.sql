@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS phone_change_codes CASCADE;
+
+DELETE FROM tasks WHERE user_id IS NULL;
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS tasks_user_id_fkey;
+ALTER TABLE tasks ADD CONSTRAINT tasks_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(user_id);
+ALTER TABLE tasks ALTER COLUMN user_id SET NOT NULL;
+
+ALTER TABLE users DROP COLUMN IF EXISTS locale;
+ALTER TABLE users DROP COLUMN IF EXISTS timezone;
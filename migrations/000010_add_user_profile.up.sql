@@ -0,0 +1,22 @@
+ALTER TABLE users ADD COLUMN timezone varchar(64) NOT NULL DEFAULT 'UTC';
+ALTER TABLE users ADD COLUMN locale varchar(16) NOT NULL DEFAULT 'en';
+
+ALTER TABLE tasks ALTER COLUMN user_id DROP NOT NULL;
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS tasks_user_id_fkey;
+ALTER TABLE tasks
+    ADD CONSTRAINT tasks_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE;
+
+CREATE TABLE phone_change_codes
+(
+    change_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL,
+    new_phone_number varchar(20) NOT NULL,
+    code_hash varchar(64) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    expires_at TIMESTAMP NOT NULL,
+    used_at TIMESTAMP DEFAULT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+CREATE INDEX phone_change_codes_user_id_created_at_idx ON phone_change_codes (user_id, created_at);
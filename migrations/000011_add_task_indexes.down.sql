@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS tasks_description_trgm_idx;
+DROP INDEX IF EXISTS tasks_title_trgm_idx;
+
+DROP INDEX IF EXISTS tasks_user_id_title_idx;
+DROP INDEX IF EXISTS tasks_user_id_end_time_idx;
+DROP INDEX IF EXISTS tasks_user_id_start_time_idx;
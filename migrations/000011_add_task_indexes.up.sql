@@ -0,0 +1,8 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX tasks_user_id_start_time_idx ON tasks (user_id, start_time, task_id);
+CREATE INDEX tasks_user_id_end_time_idx ON tasks (user_id, end_time, task_id);
+CREATE INDEX tasks_user_id_title_idx ON tasks (user_id, title, task_id);
+
+CREATE INDEX tasks_title_trgm_idx ON tasks USING GIN (title gin_trgm_ops);
+CREATE INDEX tasks_description_trgm_idx ON tasks USING GIN (description gin_trgm_ops);
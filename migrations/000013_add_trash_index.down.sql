@@ -0,0 +1 @@
+DROP INDEX IF EXISTS tasks_deleted_at_idx;
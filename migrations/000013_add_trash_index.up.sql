@@ -0,0 +1 @@
+CREATE INDEX tasks_deleted_at_idx ON tasks (deletedAt) WHERE deletedAt IS NOT NULL;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS tasks_list_id_idx;
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS tasks_list_id_fkey;
+ALTER TABLE tasks DROP COLUMN IF EXISTS list_id;
+
+DROP TABLE IF EXISTS lists CASCADE;
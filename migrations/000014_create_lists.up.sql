@@ -0,0 +1,25 @@
+CREATE TABLE lists
+(
+    list_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL,
+    name varchar(64) NOT NULL,
+    colour varchar(7) DEFAULT NULL,
+    archived BOOLEAN NOT NULL DEFAULT FALSE,
+    position INT NOT NULL DEFAULT 0,
+    is_inbox BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE,
+    UNIQUE (user_id, name)
+);
+
+CREATE UNIQUE INDEX lists_user_id_inbox_idx ON lists (user_id) WHERE is_inbox;
+
+INSERT INTO lists (user_id, name, is_inbox) SELECT user_id, 'Inbox', TRUE FROM users;
+
+ALTER TABLE tasks ADD COLUMN list_id UUID DEFAULT NULL;
+ALTER TABLE tasks
+    ADD CONSTRAINT tasks_list_id_fkey FOREIGN KEY (list_id) REFERENCES lists(list_id) ON DELETE SET NULL;
+
+UPDATE tasks SET list_id = lists.list_id FROM lists WHERE lists.user_id = tasks.user_id AND lists.is_inbox;
+
+CREATE INDEX tasks_list_id_idx ON tasks (list_id);
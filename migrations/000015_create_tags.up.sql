@@ -0,0 +1,21 @@
+CREATE TABLE tags
+(
+    tag_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    user_id UUID NOT NULL,
+    name varchar(32) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX tags_user_id_name_idx ON tags (user_id, lower(name));
+
+CREATE TABLE task_tags
+(
+    task_id UUID NOT NULL,
+    tag_id UUID NOT NULL,
+    PRIMARY KEY (task_id, tag_id),
+    FOREIGN KEY (task_id) REFERENCES tasks(task_id) ON DELETE CASCADE,
+    FOREIGN KEY (tag_id) REFERENCES tags(tag_id) ON DELETE CASCADE
+);
+
+CREATE INDEX task_tags_tag_id_idx ON task_tags (tag_id);
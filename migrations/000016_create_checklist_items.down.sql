@@ -0,0 +1,3 @@
+ALTER TABLE tasks DROP COLUMN IF EXISTS auto_complete;
+
+DROP TABLE IF EXISTS checklist_items;
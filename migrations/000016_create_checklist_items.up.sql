@@ -0,0 +1,15 @@
+CREATE TABLE checklist_items
+(
+    item_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    task_id UUID NOT NULL,
+    title varchar(255) NOT NULL,
+    is_done BOOLEAN NOT NULL DEFAULT FALSE,
+    position INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP DEFAULT NULL,
+    FOREIGN KEY (task_id) REFERENCES tasks(task_id) ON DELETE CASCADE
+);
+
+CREATE INDEX checklist_items_task_id_idx ON checklist_items (task_id, position);
+
+ALTER TABLE tasks ADD COLUMN auto_complete BOOLEAN NOT NULL DEFAULT FALSE;
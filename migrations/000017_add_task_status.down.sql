@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS tasks_user_id_status_rank_idx;
+
+ALTER TABLE tasks ADD COLUMN isDone BOOLEAN NOT NULL DEFAULT FALSE;
+UPDATE tasks SET isDone = TRUE WHERE status = 'done';
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS rank;
+ALTER TABLE tasks DROP COLUMN IF EXISTS priority;
+ALTER TABLE tasks DROP COLUMN IF EXISTS status;
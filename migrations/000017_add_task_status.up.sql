@@ -0,0 +1,18 @@
+ALTER TABLE tasks ADD COLUMN status varchar(16) NOT NULL DEFAULT 'todo'
+    CHECK (status IN ('todo', 'in_progress', 'blocked', 'done', 'cancelled'));
+ALTER TABLE tasks ADD COLUMN priority varchar(8) NOT NULL DEFAULT 'medium'
+    CHECK (priority IN ('low', 'medium', 'high', 'urgent'));
+ALTER TABLE tasks ADD COLUMN rank DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+UPDATE tasks SET status = 'done' WHERE isDone;
+
+UPDATE tasks SET rank = ranked.position
+FROM (
+    SELECT task_id, row_number() OVER (PARTITION BY user_id, status ORDER BY start_time, task_id) * 1024 AS position
+    FROM tasks
+) ranked
+WHERE ranked.task_id = tasks.task_id;
+
+ALTER TABLE tasks DROP COLUMN isDone;
+
+CREATE INDEX tasks_user_id_status_rank_idx ON tasks (user_id, status, rank, task_id);
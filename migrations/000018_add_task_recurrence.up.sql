@@ -0,0 +1,15 @@
+ALTER TABLE tasks ADD COLUMN recurrence_rule varchar(255) DEFAULT NULL;
+
+CREATE TABLE task_occurrences
+(
+    task_id UUID NOT NULL,
+    occurrence_start TIMESTAMP NOT NULL,
+    title varchar(64) DEFAULT NULL,
+    description varchar(255) DEFAULT NULL,
+    start_time TIMESTAMP DEFAULT NULL,
+    end_time TIMESTAMP DEFAULT NULL,
+    status varchar(16) DEFAULT NULL
+        CHECK (status IN ('todo', 'in_progress', 'blocked', 'done', 'cancelled')),
+    PRIMARY KEY (task_id, occurrence_start),
+    FOREIGN KEY (task_id) REFERENCES tasks(task_id) ON DELETE CASCADE
+);
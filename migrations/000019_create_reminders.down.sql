@@ -0,0 +1,3 @@
+ALTER TABLE users DROP COLUMN IF EXISTS email;
+
+DROP TABLE IF EXISTS reminders;
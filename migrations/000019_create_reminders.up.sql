@@ -0,0 +1,19 @@
+CREATE TABLE reminders
+(
+    reminder_id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    task_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    minutes_before INT DEFAULT NULL CHECK (minutes_before >= 0),
+    remind_at TIMESTAMP DEFAULT NULL,
+    sent_at TIMESTAMP DEFAULT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error varchar(255) DEFAULT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((minutes_before IS NULL) <> (remind_at IS NULL)),
+    FOREIGN KEY (task_id) REFERENCES tasks(task_id) ON DELETE CASCADE,
+    FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+CREATE INDEX reminders_pending_idx ON reminders (task_id) WHERE sent_at IS NULL;
+
+ALTER TABLE users ADD COLUMN email varchar(255) DEFAULT NULL;
@@ -0,0 +1,26 @@
+UPDATE reminders r SET remind_at = (r.remind_at AT TIME ZONE u.timezone) AT TIME ZONE 'UTC'
+FROM users u
+WHERE u.user_id = r.user_id AND r.remind_at IS NOT NULL AND u.timezone <> 'UTC';
+
+ALTER TABLE reminders
+    ALTER COLUMN remind_at TYPE TIMESTAMP USING remind_at AT TIME ZONE 'UTC',
+    ALTER COLUMN sent_at TYPE TIMESTAMP USING sent_at AT TIME ZONE 'UTC';
+
+UPDATE task_occurrences o SET
+    occurrence_start = (o.occurrence_start AT TIME ZONE t.timezone) AT TIME ZONE 'UTC',
+    start_time = (o.start_time AT TIME ZONE t.timezone) AT TIME ZONE 'UTC',
+    end_time = (o.end_time AT TIME ZONE t.timezone) AT TIME ZONE 'UTC'
+FROM tasks t
+WHERE t.task_id = o.task_id AND t.timezone <> 'UTC';
+
+ALTER TABLE task_occurrences
+    ALTER COLUMN occurrence_start TYPE TIMESTAMP USING occurrence_start AT TIME ZONE 'UTC',
+    ALTER COLUMN start_time TYPE TIMESTAMP USING start_time AT TIME ZONE 'UTC',
+    ALTER COLUMN end_time TYPE TIMESTAMP USING end_time AT TIME ZONE 'UTC';
+
+ALTER TABLE tasks
+    ALTER COLUMN start_time TYPE TIMESTAMP USING start_time AT TIME ZONE timezone,
+    ALTER COLUMN end_time TYPE TIMESTAMP USING end_time AT TIME ZONE timezone,
+    ALTER COLUMN deletedAt TYPE TIMESTAMP USING deletedAt AT TIME ZONE 'UTC';
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS timezone;
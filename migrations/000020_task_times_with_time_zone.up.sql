@@ -0,0 +1,30 @@
+-- task times used to be stored as the wall clock time the user typed in, they are read in the
+-- time zone of their owner now. Times set by the server itself were written in UTC.
+ALTER TABLE tasks ADD COLUMN timezone varchar(64) NOT NULL DEFAULT 'UTC';
+
+UPDATE tasks t SET timezone = u.timezone FROM users u WHERE u.user_id = t.user_id;
+
+ALTER TABLE tasks
+    ALTER COLUMN start_time TYPE TIMESTAMPTZ USING start_time AT TIME ZONE timezone,
+    ALTER COLUMN end_time TYPE TIMESTAMPTZ USING end_time AT TIME ZONE timezone,
+    ALTER COLUMN deletedAt TYPE TIMESTAMPTZ USING deletedAt AT TIME ZONE 'UTC';
+
+ALTER TABLE task_occurrences
+    ALTER COLUMN occurrence_start TYPE TIMESTAMPTZ USING occurrence_start AT TIME ZONE 'UTC',
+    ALTER COLUMN start_time TYPE TIMESTAMPTZ USING start_time AT TIME ZONE 'UTC',
+    ALTER COLUMN end_time TYPE TIMESTAMPTZ USING end_time AT TIME ZONE 'UTC';
+
+UPDATE task_occurrences o SET
+    occurrence_start = (o.occurrence_start AT TIME ZONE 'UTC') AT TIME ZONE t.timezone,
+    start_time = (o.start_time AT TIME ZONE 'UTC') AT TIME ZONE t.timezone,
+    end_time = (o.end_time AT TIME ZONE 'UTC') AT TIME ZONE t.timezone
+FROM tasks t
+WHERE t.task_id = o.task_id AND t.timezone <> 'UTC';
+
+ALTER TABLE reminders
+    ALTER COLUMN remind_at TYPE TIMESTAMPTZ USING remind_at AT TIME ZONE 'UTC',
+    ALTER COLUMN sent_at TYPE TIMESTAMPTZ USING sent_at AT TIME ZONE 'UTC';
+
+UPDATE reminders r SET remind_at = (r.remind_at AT TIME ZONE 'UTC') AT TIME ZONE u.timezone
+FROM users u
+WHERE u.user_id = r.user_id AND r.remind_at IS NOT NULL AND u.timezone <> 'UTC';
@@ -0,0 +1,12 @@
+CREATE TABLE totp_challenges
+(
+    challenge_id UUID PRIMARY KEY,
+    user_id UUID NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    expires_at TIMESTAMPTZ NOT NULL,
+    used_at TIMESTAMPTZ DEFAULT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (user_id) REFERENCES users(user_id) ON DELETE CASCADE
+);
+
+CREATE INDEX totp_challenges_user_id_idx ON totp_challenges (user_id);
@@ -0,0 +1,3 @@
+-- a reminder is claimed by setting claimed_until, the claim of a scheduler that died while delivering
+-- it runs out and another one picks the reminder up
+ALTER TABLE reminders ADD COLUMN claimed_until TIMESTAMPTZ DEFAULT NULL;
@@ -0,0 +1,3 @@
+-- a relative reminder of a recurring task fires once for every occurrence, occurrence_start is the
+-- occurrence it fires for next. It is NULL until the first one has been sent.
+ALTER TABLE reminders ADD COLUMN occurrence_start TIMESTAMPTZ DEFAULT NULL;
@@ -0,0 +1 @@
+ALTER TABLE rate_limit_buckets ALTER COLUMN updated_at TYPE TIMESTAMP;
@@ -0,0 +1,2 @@
+-- updated_at is written with clock_timestamp(), which would otherwise be read back in the session time zone
+ALTER TABLE rate_limit_buckets ALTER COLUMN updated_at TYPE TIMESTAMPTZ;
@@ -0,0 +1,40 @@
+package db_postgres
+
+import (
+	"errors"
+	"github.com/jackc/pgx"
+)
+
+// SQLSTATE codes of the postgres errors the service tells apart
+const (
+	UniqueViolation           = "23505"
+	ForeignKeyViolation       = "23503"
+	CheckViolation            = "23514"
+	NotNullViolation          = "23502"
+	ExclusionViolation        = "23P01"
+	StringDataRightTruncation = "22001"
+	InvalidTextRepresentation = "22P02"
+	InvalidDatetimeFormat     = "22007"
+	DatetimeFieldOverflow     = "22008"
+	SerializationFailure      = "40001"
+	DeadlockDetected          = "40P01"
+	QueryCanceled             = "57014"
+)
+
+// Code returns the SQLSTATE of err, it is empty when err does not come from postgres
+func Code(err error) string {
+	var pgErr pgx.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code
+	}
+	return ""
+}
+
+// IsUniqueViolation reports whether err violates the unique constraint or unique index named constraint
+func IsUniqueViolation(err error, constraint string) bool {
+	var pgErr pgx.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	return pgErr.Code == UniqueViolation && pgErr.ConstraintName == constraint
+}
@@ -0,0 +1,41 @@
+package db_postgres
+
+import (
+	"context"
+	"database/sql"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// Queryer is implemented by both *sqlx.DB and *sqlx.Tx, repositories run their queries through it
+// so the same repository works inside and outside a transaction
+type Queryer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryxContext(ctx context.Context, query string, args ...interface{}) (*sqlx.Rows, error)
+	QueryRowxContext(ctx context.Context, query string, args ...interface{}) *sqlx.Row
+}
+
+// InTx runs fn in a transaction that is committed when fn returns nil and rolled back otherwise
+func InTx(ctx context.Context, db *sqlx.DB, fn func(tx *sqlx.Tx) error) error {
+	tx, err := db.BeginTxx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err = fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// TaskExists reports whether the task belongs to the user and is not in the trash
+func TaskExists(ctx context.Context, q Queryer, userId uuid.UUID, taskId uuid.UUID) (bool, error) {
+	query := `SELECT EXISTS (SELECT 1 FROM tasks WHERE task_id = $1 AND user_id = $2 AND deletedAt IS NULL)`
+	var exists bool
+
+	if err := q.QueryRowxContext(ctx, query, taskId, userId).Scan(&exists); err != nil {
+		return false, err
+	}
+	return exists, nil
+}
@@ -0,0 +1,114 @@
+package http_errors
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Codes are the machine-readable identifiers of errors sent to clients as "code". They are part of
+// the API, so an existing code is never renamed or reused for another error.
+const (
+	CodeBadRequest               = "BAD_REQUEST"
+	CodeNotFound                 = "NOT_FOUND"
+	CodeUnauthorized             = "UNAUTHORIZED"
+	CodeRequestTimeout           = "REQUEST_TIMEOUT"
+	CodeConflict                 = "CONFLICT"
+	CodeReferenceNotFound        = "REFERENCE_NOT_FOUND"
+	CodeConcurrentUpdate         = "CONCURRENT_UPDATE"
+	CodeInternalServerError      = "INTERNAL_SERVER_ERROR"
+	CodeValidationFailed         = "VALIDATION_FAILED"
+	CodePhoneTaken               = "PHONE_TAKEN"
+	CodeSamePhoneNumber          = "SAME_PHONE_NUMBER"
+	CodeInvalidCredentials       = "INVALID_CREDENTIALS"
+	CodeWrongOldPassword         = "WRONG_OLD_PASSWORD"
+	CodeAccountDisabled          = "ACCOUNT_DISABLED"
+	CodeAccountLocked            = "ACCOUNT_LOCKED"
+	CodeUserNotFound             = "USER_NOT_FOUND"
+	CodeInvalidOtp               = "INVALID_OTP"
+	CodeOtpRequestsLimit         = "OTP_REQUESTS_LIMIT"
+	CodeOtpAttemptsLimit         = "OTP_ATTEMPTS_LIMIT"
+	CodeSmsDeliveryFailed        = "SMS_DELIVERY_FAILED"
+	CodeInvalidRefreshToken      = "INVALID_REFRESH_TOKEN"
+	CodeRefreshTokenReused       = "REFRESH_TOKEN_REUSED"
+	CodeSessionRevoked           = "SESSION_REVOKED"
+	CodeSessionNotFound          = "SESSION_NOT_FOUND"
+	CodeInvalidApiKey            = "INVALID_API_KEY"
+	CodeApiKeyNotFound           = "API_KEY_NOT_FOUND"
+	CodeInsufficientScope        = "INSUFFICIENT_SCOPE"
+	CodeTotpAlreadyEnabled       = "TOTP_ALREADY_ENABLED"
+	CodeTotpNotEnrolled          = "TOTP_NOT_ENROLLED"
+	CodeTotpNotEnabled           = "TOTP_NOT_ENABLED"
+	CodeInvalidTotpCode          = "INVALID_TOTP_CODE"
+	CodeInvalidChallenge         = "INVALID_CHALLENGE"
+	CodeTooManyRequests          = "TOO_MANY_REQUESTS"
+	CodeObjectNotFoundToDelete   = "OBJECT_NOT_FOUND_TO_DELETE"
+	CodeObjectNotFoundToUpdate   = "OBJECT_NOT_FOUND_TO_UPDATE"
+	CodeTaskNotFound             = "TASK_NOT_FOUND"
+	CodeTaskOverlap              = "TASK_OVERLAP"
+	CodeTaskNotStarted           = "TASK_NOT_STARTED"
+	CodeTaskNotInTrash           = "TASK_NOT_IN_TRASH"
+	CodeTaskNotRecurring         = "TASK_NOT_RECURRING"
+	CodeOccurrenceNotFound       = "OCCURRENCE_NOT_FOUND"
+	CodeBatchRolledBack          = "BATCH_ROLLED_BACK"
+	CodeBatchOperationNotApplied = "BATCH_OPERATION_NOT_APPLIED"
+	CodeListNotFound             = "LIST_NOT_FOUND"
+	CodeListExists               = "LIST_EXISTS"
+	CodeListArchived             = "LIST_ARCHIVED"
+	CodeInboxListChange          = "INBOX_LIST_CHANGE"
+	CodeTagNotFound              = "TAG_NOT_FOUND"
+	CodeTagExists                = "TAG_EXISTS"
+	CodeChecklistItemNotFound    = "CHECKLIST_ITEM_NOT_FOUND"
+	CodeReminderNotFound         = "REMINDER_NOT_FOUND"
+	CodeInvalidToken             = "INVALID_TOKEN"
+	CodeInvalidTimezone          = "INVALID_TIMEZONE"
+	CodeInvalidJson              = "INVALID_JSON"
+	CodeInvalidTaskId            = "INVALID_TASK_ID"
+	CodeInvalidListId            = "INVALID_LIST_ID"
+	CodeInvalidTagId             = "INVALID_TAG_ID"
+	CodeInvalidChecklistItemId   = "INVALID_CHECKLIST_ITEM_ID"
+	CodeInvalidReminderId        = "INVALID_REMINDER_ID"
+	CodeInvalidOccurrenceId      = "INVALID_OCCURRENCE_ID"
+	CodeInvalidTime              = "INVALID_TIME"
+	CodeInvalidValue             = "INVALID_VALUE"
+	CodeRequired                 = "REQUIRED"
+	CodeInvalidLength            = "INVALID_LENGTH"
+	CodeOutOfRange               = "OUT_OF_RANGE"
+	CodeNegativeValue            = "NEGATIVE_VALUE"
+	CodeEndBeforeStart           = "END_BEFORE_START"
+	CodeInvalidPeriod            = "INVALID_PERIOD"
+	CodeExpandRequiresPeriod     = "EXPAND_REQUIRES_PERIOD"
+	CodeExpandWindowTooLong      = "EXPAND_WINDOW_TOO_LONG"
+	CodeCursorWithExpand         = "CURSOR_WITH_EXPAND"
+	CodeInvalidCursor            = "INVALID_CURSOR"
+	CodeCursorSortMismatch       = "CURSOR_SORT_MISMATCH"
+	CodeSearchQueryRequired      = "SEARCH_QUERY_REQUIRED"
+	CodeInvalidRecurrenceRule    = "INVALID_RECURRENCE_RULE"
+	CodeInvalidTransition        = "INVALID_TRANSITION"
+	CodeRankAfterSelf            = "RANK_AFTER_SELF"
+	CodeRankAfterOtherStatus     = "RANK_AFTER_OTHER_STATUS"
+	CodeInvalidBatchSize         = "INVALID_BATCH_SIZE"
+	CodePasswordTooShort         = "PASSWORD_TOO_SHORT"
+	CodePasswordTooLong          = "PASSWORD_TOO_LONG"
+	CodePasswordDigitRequired    = "PASSWORD_DIGIT_REQUIRED"
+	CodePasswordLetterRequired   = "PASSWORD_LETTER_REQUIRED"
+	CodePasswordSpecialRequired  = "PASSWORD_SPECIAL_REQUIRED"
+	CodePasswordRequiredToDelete = "PASSWORD_REQUIRED_TO_DELETE"
+	CodeCannotDisableSelf        = "CANNOT_DISABLE_SELF"
+	CodeInvalidEmail             = "INVALID_EMAIL"
+	CodeReminderTimeConflict     = "REMINDER_TIME_CONFLICT"
+	CodeReminderTimeRequired     = "REMINDER_TIME_REQUIRED"
+	CodeRemindersLimit           = "REMINDERS_LIMIT"
+	CodeReminderInPast           = "REMINDER_IN_PAST"
+	CodeUnknownScope             = "UNKNOWN_SCOPE"
+	CodeInvalidColour            = "INVALID_COLOUR"
+	CodeTagNameComma             = "TAG_NAME_COMMA"
+)
+
+// StatusCode is the code of errors that have none of their own, such as BAD_REQUEST for 400
+func StatusCode(status int) string {
+	text := http.StatusText(status)
+	if text == "" {
+		return CodeInternalServerError
+	}
+	return strings.ToUpper(strings.NewReplacer(" ", "_", "-", "_", "'", "").Replace(text))
+}
@@ -3,10 +3,18 @@ package http_errors
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"log"
+	"math"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
+	"uzinfocom-todo/pkg/db/db_postgres"
+	"uzinfocom-todo/pkg/message"
+	"uzinfocom-todo/pkg/validator"
 )
 
 var (
@@ -19,26 +27,172 @@ var (
 	ObjectNotFoundForDeletingError = errors.New("object not found to delete")
 	ObjNotFoundToUpdate            = errors.New("object not found to update")
 	UpdateIsDoneError              = errors.New("to set up isDone to true you have to be in the interval of task times")
+	InvalidCredentialsError        = errors.New("invalid phone number or password")
+	WrongOldPasswordError          = errors.New("old password is incorrect")
+	InvalidOtpError                = errors.New("verification code is invalid or expired")
+	OtpRequestsLimitError          = errors.New("too many verification codes requested, try again later")
+	OtpAttemptsLimitError          = errors.New("too many attempts, request a new verification code")
+	SmsDeliveryFailedError         = errors.New("the verification code could not be sent, try again later")
+	InvalidRefreshTokenError       = errors.New("refresh token is invalid or expired")
+	RefreshTokenReusedError        = errors.New("refresh token has already been used, please log in again")
+	SessionRevokedError            = errors.New("session has been revoked")
+	SessionNotFoundError           = errors.New("session not found")
+	InvalidApiKeyError             = errors.New("invalid api key")
+	ApiKeyNotFoundError            = errors.New("api key not found")
+	AccountDisabledError           = errors.New("account is disabled")
+	InsufficientScopeError         = errors.New("insufficient scope")
+	UserNotFoundError              = errors.New("user not found")
+	TotpAlreadyEnabledError        = errors.New("two-factor authentication is already enabled")
+	TotpNotEnrolledError           = errors.New("two-factor authentication enrollment has not been started")
+	TotpNotEnabledError            = errors.New("two-factor authentication is not enabled")
+	InvalidTotpCodeError           = errors.New("authentication code is invalid")
+	InvalidChallengeError          = errors.New("two-factor challenge is invalid or expired, please log in again")
+	TooManyRequestsError           = errors.New("too many requests, try again later")
+	AccountLockedError             = errors.New("too many failed login attempts, account is temporarily locked")
+	SamePhoneNumberError           = errors.New("new phone number is the same as the current one")
+	TaskNotInTrashError            = errors.New("task not found in trash")
+	BatchRolledBackError           = errors.New("batch was rolled back, no operation was applied")
+	BatchOperationNotAppliedError  = errors.New("not applied because another operation in the batch failed")
+	ListNotFoundError              = errors.New("list not found")
+	ListAlreadyExistsError         = errors.New("list with given name already exists")
+	ListArchivedError              = errors.New("list is archived")
+	InboxListChangeError           = errors.New("the \"Inbox\" list cannot be renamed, archived or deleted")
+	TaskNotFoundError              = errors.New("task not found")
+	OccurrenceNotFoundError        = errors.New("occurrence not found")
+	TaskNotRecurringError          = errors.New("task is not recurring")
+	TagNotFoundError               = errors.New("tag not found")
+	TagAlreadyExistsError          = errors.New("tag with given name already exists")
+	ChecklistItemNotFoundError     = errors.New("checklist item not found")
+	ReminderNotFoundError          = errors.New("reminder not found")
+	ValidationFailedError          = errors.New("request validation failed")
+	ConflictError                  = errors.New("object already exists")
+	ReferenceNotFoundError         = errors.New("referenced object does not exist")
+	ConcurrentUpdateError          = errors.New("request conflicts with a concurrent one, try again")
+	InternalServerError            = errors.New("internal server error")
+	InvalidTokenError              = errors.New("invalid token")
+	InvalidTimezoneError           = errors.New("timezone must be a valid IANA time zone name")
+	InvalidJsonError               = errors.New("json format is incorrect for {object}")
+	InvalidTaskIdError             = errors.New("task id is not a valid UUID")
+	InvalidListIdError             = errors.New("list id is not a valid UUID")
+	InvalidTagIdError              = errors.New("tag id is not a valid UUID")
+	InvalidChecklistItemIdError    = errors.New("checklist item id is not a valid UUID")
+	InvalidReminderIdError         = errors.New("reminder id is not a valid UUID")
+	InvalidOccurrenceIdError       = errors.New("occurrence id must be a UTC time in the format yyyymmddThhmmssZ")
+	InvalidTimeError               = errors.New("{field} must be in RFC 3339 or in the format dd-mm-yyyy hh:mm")
+	InvalidValueError              = errors.New("{field} must be one of {values}")
+	RequiredError                  = errors.New("{field} is required")
+	InvalidLengthError             = errors.New("{field} must be between 1 and {max} characters long")
+	OutOfRangeError                = errors.New("{field} must be between {min} and {max}")
+	NegativeValueError             = errors.New("{field} must not be negative")
+	EndBeforeStartError            = errors.New("end_time must be after start_time")
+	InvalidPeriodError             = errors.New("to must not be before from")
+	ExpandRequiresPeriodError      = errors.New("expand requires from and to")
+	ExpandWindowTooLongError       = errors.New("expand window must not be longer than {days} days")
+	CursorWithExpandError          = errors.New("cursor cannot be used with expand")
+	InvalidCursorError             = errors.New("cursor is invalid")
+	CursorSortMismatchError        = errors.New("cursor was issued for a different sort order")
+	SearchQueryRequiredError       = errors.New("search query q is required")
+	InvalidRecurrenceRuleError     = errors.New("recurrence rule is invalid: {reason}")
+	InvalidTransitionError         = errors.New("task cannot move from {from} to {to}")
+	RankAfterSelfError             = errors.New("after_id must be another task")
+	RankAfterOtherStatusError      = errors.New("after_id must be a task with the same status")
+	BatchSizeError                 = errors.New("a batch must contain between 1 and {max} operations")
+	PasswordTooShortError          = errors.New("password must be at least {min} characters long")
+	PasswordTooLongError           = errors.New("password must be at most {max} bytes long")
+	PasswordDigitRequiredError     = errors.New("password must contain at least one digit")
+	PasswordLetterRequiredError    = errors.New("password must contain at least one letter")
+	PasswordSpecialRequiredError   = errors.New("password must contain at least one special character")
+	PasswordRequiredToDeleteError  = errors.New("password is required to delete the account")
+	CannotDisableSelfError         = errors.New("you can not disable your own account")
+	InvalidEmailError              = errors.New("email must be a valid email address")
+	ReminderTimeConflictError      = errors.New("only one of minutes_before and remind_at can be set")
+	ReminderTimeRequiredError      = errors.New("minutes_before or remind_at is required")
+	RemindersLimitError            = errors.New("a task can have at most {max} reminders")
+	ReminderInPastError            = errors.New("the reminder would fire in the past")
+	UnknownScopeError              = errors.New("unknown scope: {scope}")
+	InvalidColourError             = errors.New("colour must be in #RRGGBB format")
+	TagNameCommaError              = errors.New("tag name must not contain commas")
 )
 
 type RestErr interface {
 	Status() int
 	Error() string
+	// Code is the stable machine-readable identifier of the error, one of the Code constants
+	Code() string
+	// Detail is the message of the error without its status
+	Detail() string
+	// Params are the values filled into the message of Code to make Detail, such as a limit
+	Params() message.Params
 }
 
 type RestError struct {
-	ErrStatus int    `json:"status,omitempty"`
-	ErrError  string `json:"error,omitempty"`
+	ErrStatus int            `json:"status,omitempty"`
+	ErrError  string         `json:"error,omitempty"`
+	ErrCode   string         `json:"code,omitempty"`
+	ErrParams message.Params `json:"-"`
 }
 
+// restErrorPrefix starts the message of every RestError, followed by its detail
+const restErrorPrefix = "status: %d - errors: "
+
 func (e RestError) Error() string {
-	return fmt.Sprintf("status: %d - errors: %s", e.ErrStatus, e.ErrError)
+	return fmt.Sprintf(restErrorPrefix, e.ErrStatus) + e.ErrError
+}
+
+// TrimStatus returns the detail of a message made by RestError.Error, other messages are returned as they are
+func TrimStatus(status int, message string) string {
+	return strings.TrimPrefix(message, fmt.Sprintf(restErrorPrefix, status))
 }
 
 func (e RestError) Status() int {
 	return e.ErrStatus
 }
 
+func (e RestError) Code() string {
+	if e.ErrCode == "" {
+		return StatusCode(e.ErrStatus)
+	}
+	return e.ErrCode
+}
+
+func (e RestError) Detail() string {
+	return e.ErrError
+}
+
+func (e RestError) Params() message.Params {
+	return e.ErrParams
+}
+
+// RetryAfterErr is implemented by errors that tell the client when to retry
+type RetryAfterErr interface {
+	RestErr
+	RetryAfter() time.Duration
+}
+
+type TooManyRequestsRestError struct {
+	RestError
+	ErrRetryAfter time.Duration `json:"-"`
+}
+
+func (e TooManyRequestsRestError) RetryAfter() time.Duration {
+	return e.ErrRetryAfter
+}
+
+// ValidationErr is implemented by errors that carry the fields of the request that were rejected
+type ValidationErr interface {
+	RestErr
+	FieldErrors() []validator.FieldError
+}
+
+type ValidationRestError struct {
+	RestError
+	ErrFields []validator.FieldError `json:"-"`
+}
+
+func (e ValidationRestError) FieldErrors() []validator.FieldError {
+	return e.ErrFields
+}
+
 func NewRestError(status int, err string) RestErr {
 	return RestError{
 		ErrStatus: status,
@@ -46,10 +200,29 @@ func NewRestError(status int, err string) RestErr {
 	}
 }
 
+func NewCodedRestError(status int, code string, err string) RestErr {
+	return RestError{
+		ErrStatus: status,
+		ErrError:  err,
+		ErrCode:   code,
+	}
+}
+
+// newParamRestError fills params into the message of err, they are kept so the message can be translated
+func newParamRestError(status int, code string, err error, params message.Params) RestErr {
+	return RestError{
+		ErrStatus: status,
+		ErrError:  message.Format(err.Error(), params),
+		ErrCode:   code,
+		ErrParams: params,
+	}
+}
+
 func TaskExistsBetweenGivenTime() RestErr {
 	return RestError{
 		ErrStatus: http.StatusBadRequest,
 		ErrError:  TaskAlreadyExistsTime.Error(),
+		ErrCode:   CodeTaskOverlap,
 	}
 }
 
@@ -57,6 +230,7 @@ func UpdateIsDoneErr() RestErr {
 	return RestError{
 		ErrStatus: http.StatusBadRequest,
 		ErrError:  UpdateIsDoneError.Error(),
+		ErrCode:   CodeTaskNotStarted,
 	}
 }
 
@@ -64,6 +238,7 @@ func ObjectNotFoundToDelete() RestErr {
 	return RestError{
 		ErrStatus: http.StatusNotFound,
 		ErrError:  ObjectNotFoundForDeletingError.Error(),
+		ErrCode:   CodeObjectNotFoundToDelete,
 	}
 }
 
@@ -71,31 +246,686 @@ func ObjectNotFoundToUpdate() RestErr {
 	return RestError{
 		ErrStatus: http.StatusNotFound,
 		ErrError:  ObjNotFoundToUpdate.Error(),
+		ErrCode:   CodeObjectNotFoundToUpdate,
+	}
+}
+
+func InvalidCredentials() RestErr {
+	return RestError{
+		ErrStatus: http.StatusUnauthorized,
+		ErrError:  InvalidCredentialsError.Error(),
+		ErrCode:   CodeInvalidCredentials,
+	}
+}
+
+func WrongOldPassword() RestErr {
+	return RestError{
+		ErrStatus: http.StatusBadRequest,
+		ErrError:  WrongOldPasswordError.Error(),
+		ErrCode:   CodeWrongOldPassword,
+	}
+}
+
+func InvalidOtp() RestErr {
+	return RestError{
+		ErrStatus: http.StatusUnauthorized,
+		ErrError:  InvalidOtpError.Error(),
+		ErrCode:   CodeInvalidOtp,
+	}
+}
+
+func OtpRequestsLimitExceeded() RestErr {
+	return RestError{
+		ErrStatus: http.StatusTooManyRequests,
+		ErrError:  OtpRequestsLimitError.Error(),
+		ErrCode:   CodeOtpRequestsLimit,
+	}
+}
+
+func OtpAttemptsLimitExceeded() RestErr {
+	return RestError{
+		ErrStatus: http.StatusTooManyRequests,
+		ErrError:  OtpAttemptsLimitError.Error(),
+		ErrCode:   CodeOtpAttemptsLimit,
+	}
+}
+
+func SmsDeliveryFailed() RestErr {
+	return RestError{
+		ErrStatus: http.StatusServiceUnavailable,
+		ErrError:  SmsDeliveryFailedError.Error(),
+		ErrCode:   CodeSmsDeliveryFailed,
+	}
+}
+
+func InvalidRefreshToken() RestErr {
+	return RestError{
+		ErrStatus: http.StatusUnauthorized,
+		ErrError:  InvalidRefreshTokenError.Error(),
+		ErrCode:   CodeInvalidRefreshToken,
+	}
+}
+
+func RefreshTokenReused() RestErr {
+	return RestError{
+		ErrStatus: http.StatusUnauthorized,
+		ErrError:  RefreshTokenReusedError.Error(),
+		ErrCode:   CodeRefreshTokenReused,
+	}
+}
+
+func SessionRevoked() RestErr {
+	return RestError{
+		ErrStatus: http.StatusUnauthorized,
+		ErrError:  SessionRevokedError.Error(),
+		ErrCode:   CodeSessionRevoked,
+	}
+}
+
+func SessionNotFound() RestErr {
+	return RestError{
+		ErrStatus: http.StatusNotFound,
+		ErrError:  SessionNotFoundError.Error(),
+		ErrCode:   CodeSessionNotFound,
+	}
+}
+
+func InvalidApiKey() RestErr {
+	return RestError{
+		ErrStatus: http.StatusUnauthorized,
+		ErrError:  InvalidApiKeyError.Error(),
+		ErrCode:   CodeInvalidApiKey,
+	}
+}
+
+func ApiKeyNotFound() RestErr {
+	return RestError{
+		ErrStatus: http.StatusNotFound,
+		ErrError:  ApiKeyNotFoundError.Error(),
+		ErrCode:   CodeApiKeyNotFound,
 	}
 }
 
+func AccountDisabled() RestErr {
+	return RestError{
+		ErrStatus: http.StatusForbidden,
+		ErrError:  AccountDisabledError.Error(),
+		ErrCode:   CodeAccountDisabled,
+	}
+}
+
+func InsufficientScope() RestErr {
+	return RestError{
+		ErrStatus: http.StatusForbidden,
+		ErrError:  InsufficientScopeError.Error(),
+		ErrCode:   CodeInsufficientScope,
+	}
+}
+
+func UserNotFound() RestErr {
+	return RestError{
+		ErrStatus: http.StatusNotFound,
+		ErrError:  UserNotFoundError.Error(),
+		ErrCode:   CodeUserNotFound,
+	}
+}
+
+func TotpAlreadyEnabled() RestErr {
+	return RestError{
+		ErrStatus: http.StatusConflict,
+		ErrError:  TotpAlreadyEnabledError.Error(),
+		ErrCode:   CodeTotpAlreadyEnabled,
+	}
+}
+
+func TotpNotEnrolled() RestErr {
+	return RestError{
+		ErrStatus: http.StatusBadRequest,
+		ErrError:  TotpNotEnrolledError.Error(),
+		ErrCode:   CodeTotpNotEnrolled,
+	}
+}
+
+func TotpNotEnabled() RestErr {
+	return RestError{
+		ErrStatus: http.StatusBadRequest,
+		ErrError:  TotpNotEnabledError.Error(),
+		ErrCode:   CodeTotpNotEnabled,
+	}
+}
+
+func InvalidTotpCode() RestErr {
+	return RestError{
+		ErrStatus: http.StatusUnauthorized,
+		ErrError:  InvalidTotpCodeError.Error(),
+		ErrCode:   CodeInvalidTotpCode,
+	}
+}
+
+func InvalidChallenge() RestErr {
+	return RestError{
+		ErrStatus: http.StatusUnauthorized,
+		ErrError:  InvalidChallengeError.Error(),
+		ErrCode:   CodeInvalidChallenge,
+	}
+}
+
+func TooManyRequests(retryAfter time.Duration) RestErr {
+	return TooManyRequestsRestError{
+		RestError: RestError{
+			ErrStatus: http.StatusTooManyRequests,
+			ErrError:  TooManyRequestsError.Error(),
+			ErrCode:   CodeTooManyRequests,
+		},
+		ErrRetryAfter: retryAfter,
+	}
+}
+
+func AccountLocked(retryAfter time.Duration) RestErr {
+	return TooManyRequestsRestError{
+		RestError: RestError{
+			ErrStatus: http.StatusTooManyRequests,
+			ErrError:  AccountLockedError.Error(),
+			ErrCode:   CodeAccountLocked,
+		},
+		ErrRetryAfter: retryAfter,
+	}
+}
+
+// ValidationFailed returns nil when v has no errors
+func ValidationFailed(v *validator.Validator) RestErr {
+	if v.Valid() {
+		return nil
+	}
+
+	return ValidationRestError{
+		RestError: RestError{
+			ErrStatus: http.StatusUnprocessableEntity,
+			ErrError:  ValidationFailedError.Error(),
+			ErrCode:   CodeValidationFailed,
+		},
+		ErrFields: v.Errors(),
+	}
+}
+
+func PhoneTaken() RestErr {
+	return RestError{
+		ErrStatus: http.StatusConflict,
+		ErrError:  ExistsPhoneNumberError.Error(),
+		ErrCode:   CodePhoneTaken,
+	}
+}
+
+// BatchRolledBack takes the status of the first operation that failed
+func BatchRolledBack(status int) RestErr {
+	return RestError{
+		ErrStatus: status,
+		ErrError:  BatchRolledBackError.Error(),
+		ErrCode:   CodeBatchRolledBack,
+	}
+}
+
+// BatchOperationNotApplied is reported for the operations of a rolled back batch that did not fail themselves
+func BatchOperationNotApplied() RestErr {
+	return RestError{
+		ErrStatus: http.StatusFailedDependency,
+		ErrError:  BatchOperationNotAppliedError.Error(),
+		ErrCode:   CodeBatchOperationNotApplied,
+	}
+}
+
+func SamePhoneNumber() RestErr {
+	return RestError{
+		ErrStatus: http.StatusBadRequest,
+		ErrError:  SamePhoneNumberError.Error(),
+		ErrCode:   CodeSamePhoneNumber,
+	}
+}
+
+func TaskNotInTrash() RestErr {
+	return RestError{
+		ErrStatus: http.StatusNotFound,
+		ErrError:  TaskNotInTrashError.Error(),
+		ErrCode:   CodeTaskNotInTrash,
+	}
+}
+
+func ListNotFound() RestErr {
+	return RestError{
+		ErrStatus: http.StatusNotFound,
+		ErrError:  ListNotFoundError.Error(),
+		ErrCode:   CodeListNotFound,
+	}
+}
+
+func ListAlreadyExists() RestErr {
+	return RestError{
+		ErrStatus: http.StatusConflict,
+		ErrError:  ListAlreadyExistsError.Error(),
+		ErrCode:   CodeListExists,
+	}
+}
+
+func ListArchived() RestErr {
+	return RestError{
+		ErrStatus: http.StatusBadRequest,
+		ErrError:  ListArchivedError.Error(),
+		ErrCode:   CodeListArchived,
+	}
+}
+
+func InboxListChange() RestErr {
+	return RestError{
+		ErrStatus: http.StatusBadRequest,
+		ErrError:  InboxListChangeError.Error(),
+		ErrCode:   CodeInboxListChange,
+	}
+}
+
+func TaskNotFound() RestErr {
+	return RestError{
+		ErrStatus: http.StatusNotFound,
+		ErrError:  TaskNotFoundError.Error(),
+		ErrCode:   CodeTaskNotFound,
+	}
+}
+
+func OccurrenceNotFound() RestErr {
+	return RestError{
+		ErrStatus: http.StatusNotFound,
+		ErrError:  OccurrenceNotFoundError.Error(),
+		ErrCode:   CodeOccurrenceNotFound,
+	}
+}
+
+func TaskNotRecurring() RestErr {
+	return RestError{
+		ErrStatus: http.StatusBadRequest,
+		ErrError:  TaskNotRecurringError.Error(),
+		ErrCode:   CodeTaskNotRecurring,
+	}
+}
+
+func TagNotFound() RestErr {
+	return RestError{
+		ErrStatus: http.StatusNotFound,
+		ErrError:  TagNotFoundError.Error(),
+		ErrCode:   CodeTagNotFound,
+	}
+}
+
+func TagAlreadyExists() RestErr {
+	return RestError{
+		ErrStatus: http.StatusConflict,
+		ErrError:  TagAlreadyExistsError.Error(),
+		ErrCode:   CodeTagExists,
+	}
+}
+
+func ChecklistItemNotFound() RestErr {
+	return RestError{
+		ErrStatus: http.StatusNotFound,
+		ErrError:  ChecklistItemNotFoundError.Error(),
+		ErrCode:   CodeChecklistItemNotFound,
+	}
+}
+
+func ReminderNotFound() RestErr {
+	return RestError{
+		ErrStatus: http.StatusNotFound,
+		ErrError:  ReminderNotFoundError.Error(),
+		ErrCode:   CodeReminderNotFound,
+	}
+}
+
+func InvalidToken() RestErr {
+	return RestError{
+		ErrStatus: http.StatusUnauthorized,
+		ErrError:  InvalidTokenError.Error(),
+		ErrCode:   CodeInvalidToken,
+	}
+}
+
+func InvalidTimezone() RestErr {
+	return RestError{
+		ErrStatus: http.StatusBadRequest,
+		ErrError:  InvalidTimezoneError.Error(),
+		ErrCode:   CodeInvalidTimezone,
+	}
+}
+
+func InvalidJson(object string) RestErr {
+	return newParamRestError(http.StatusBadRequest, CodeInvalidJson, InvalidJsonError, message.Params{"object": object})
+}
+
+func InvalidTaskId() RestErr {
+	return RestError{
+		ErrStatus: http.StatusBadRequest,
+		ErrError:  InvalidTaskIdError.Error(),
+		ErrCode:   CodeInvalidTaskId,
+	}
+}
+
+func InvalidListId() RestErr {
+	return RestError{
+		ErrStatus: http.StatusBadRequest,
+		ErrError:  InvalidListIdError.Error(),
+		ErrCode:   CodeInvalidListId,
+	}
+}
+
+func InvalidTagId() RestErr {
+	return RestError{
+		ErrStatus: http.StatusBadRequest,
+		ErrError:  InvalidTagIdError.Error(),
+		ErrCode:   CodeInvalidTagId,
+	}
+}
+
+func InvalidChecklistItemId() RestErr {
+	return RestError{
+		ErrStatus: http.StatusBadRequest,
+		ErrError:  InvalidChecklistItemIdError.Error(),
+		ErrCode:   CodeInvalidChecklistItemId,
+	}
+}
+
+func InvalidReminderId() RestErr {
+	return RestError{
+		ErrStatus: http.StatusBadRequest,
+		ErrError:  InvalidReminderIdError.Error(),
+		ErrCode:   CodeInvalidReminderId,
+	}
+}
+
+func InvalidOccurrenceId() RestErr {
+	return RestError{
+		ErrStatus: http.StatusBadRequest,
+		ErrError:  InvalidOccurrenceIdError.Error(),
+		ErrCode:   CodeInvalidOccurrenceId,
+	}
+}
+
+func InvalidTime(field string) RestErr {
+	return newParamRestError(http.StatusBadRequest, CodeInvalidTime, InvalidTimeError, message.Params{"field": field})
+}
+
+func InvalidValue(field string, values ...string) RestErr {
+	return newParamRestError(http.StatusBadRequest, CodeInvalidValue, InvalidValueError, message.Params{
+		"field":  field,
+		"values": strings.Join(values, ", "),
+	})
+}
+
+func Required(field string) RestErr {
+	return newParamRestError(http.StatusBadRequest, CodeRequired, RequiredError, message.Params{"field": field})
+}
+
+func InvalidLength(field string, max int) RestErr {
+	return newParamRestError(http.StatusBadRequest, CodeInvalidLength, InvalidLengthError, message.Params{
+		"field": field,
+		"max":   strconv.Itoa(max),
+	})
+}
+
+func OutOfRange(field string, min int, max int) RestErr {
+	return newParamRestError(http.StatusBadRequest, CodeOutOfRange, OutOfRangeError, message.Params{
+		"field": field,
+		"min":   strconv.Itoa(min),
+		"max":   strconv.Itoa(max),
+	})
+}
+
+func NegativeValue(field string) RestErr {
+	return newParamRestError(http.StatusBadRequest, CodeNegativeValue, NegativeValueError, message.Params{"field": field})
+}
+
+func EndBeforeStart() RestErr {
+	return RestError{
+		ErrStatus: http.StatusBadRequest,
+		ErrError:  EndBeforeStartError.Error(),
+		ErrCode:   CodeEndBeforeStart,
+	}
+}
+
+func InvalidPeriod() RestErr {
+	return RestError{
+		ErrStatus: http.StatusBadRequest,
+		ErrError:  InvalidPeriodError.Error(),
+		ErrCode:   CodeInvalidPeriod,
+	}
+}
+
+func ExpandRequiresPeriod() RestErr {
+	return RestError{
+		ErrStatus: http.StatusBadRequest,
+		ErrError:  ExpandRequiresPeriodError.Error(),
+		ErrCode:   CodeExpandRequiresPeriod,
+	}
+}
+
+func ExpandWindowTooLong(days int) RestErr {
+	return newParamRestError(http.StatusBadRequest, CodeExpandWindowTooLong, ExpandWindowTooLongError, message.Params{"days": strconv.Itoa(days)})
+}
+
+func CursorWithExpand() RestErr {
+	return RestError{
+		ErrStatus: http.StatusBadRequest,
+		ErrError:  CursorWithExpandError.Error(),
+		ErrCode:   CodeCursorWithExpand,
+	}
+}
+
+func InvalidCursor() RestErr {
+	return RestError{
+		ErrStatus: http.StatusBadRequest,
+		ErrError:  InvalidCursorError.Error(),
+		ErrCode:   CodeInvalidCursor,
+	}
+}
+
+func CursorSortMismatch() RestErr {
+	return RestError{
+		ErrStatus: http.StatusBadRequest,
+		ErrError:  CursorSortMismatchError.Error(),
+		ErrCode:   CodeCursorSortMismatch,
+	}
+}
+
+func SearchQueryRequired() RestErr {
+	return RestError{
+		ErrStatus: http.StatusBadRequest,
+		ErrError:  SearchQueryRequiredError.Error(),
+		ErrCode:   CodeSearchQueryRequired,
+	}
+}
+
+func InvalidRecurrenceRule(reason string) RestErr {
+	return newParamRestError(http.StatusBadRequest, CodeInvalidRecurrenceRule, InvalidRecurrenceRuleError, message.Params{"reason": reason})
+}
+
+func InvalidTransition(from string, to string) RestErr {
+	return newParamRestError(http.StatusConflict, CodeInvalidTransition, InvalidTransitionError, message.Params{
+		"from": from,
+		"to":   to,
+	})
+}
+
+func RankAfterSelf() RestErr {
+	return RestError{
+		ErrStatus: http.StatusBadRequest,
+		ErrError:  RankAfterSelfError.Error(),
+		ErrCode:   CodeRankAfterSelf,
+	}
+}
+
+func RankAfterOtherStatus() RestErr {
+	return RestError{
+		ErrStatus: http.StatusBadRequest,
+		ErrError:  RankAfterOtherStatusError.Error(),
+		ErrCode:   CodeRankAfterOtherStatus,
+	}
+}
+
+func InvalidBatchSize(max int) RestErr {
+	return newParamRestError(http.StatusBadRequest, CodeInvalidBatchSize, BatchSizeError, message.Params{"max": strconv.Itoa(max)})
+}
+
+func PasswordTooShort(min int) RestErr {
+	return newParamRestError(http.StatusBadRequest, CodePasswordTooShort, PasswordTooShortError, message.Params{"min": strconv.Itoa(min)})
+}
+
+func PasswordTooLong(max int) RestErr {
+	return newParamRestError(http.StatusBadRequest, CodePasswordTooLong, PasswordTooLongError, message.Params{"max": strconv.Itoa(max)})
+}
+
+func PasswordDigitRequired() RestErr {
+	return RestError{
+		ErrStatus: http.StatusBadRequest,
+		ErrError:  PasswordDigitRequiredError.Error(),
+		ErrCode:   CodePasswordDigitRequired,
+	}
+}
+
+func PasswordLetterRequired() RestErr {
+	return RestError{
+		ErrStatus: http.StatusBadRequest,
+		ErrError:  PasswordLetterRequiredError.Error(),
+		ErrCode:   CodePasswordLetterRequired,
+	}
+}
+
+func PasswordSpecialRequired() RestErr {
+	return RestError{
+		ErrStatus: http.StatusBadRequest,
+		ErrError:  PasswordSpecialRequiredError.Error(),
+		ErrCode:   CodePasswordSpecialRequired,
+	}
+}
+
+func PasswordRequiredToDelete() RestErr {
+	return RestError{
+		ErrStatus: http.StatusBadRequest,
+		ErrError:  PasswordRequiredToDeleteError.Error(),
+		ErrCode:   CodePasswordRequiredToDelete,
+	}
+}
+
+func CannotDisableSelf() RestErr {
+	return RestError{
+		ErrStatus: http.StatusBadRequest,
+		ErrError:  CannotDisableSelfError.Error(),
+		ErrCode:   CodeCannotDisableSelf,
+	}
+}
+
+func InvalidEmail() RestErr {
+	return RestError{
+		ErrStatus: http.StatusBadRequest,
+		ErrError:  InvalidEmailError.Error(),
+		ErrCode:   CodeInvalidEmail,
+	}
+}
+
+func ReminderTimeConflict() RestErr {
+	return RestError{
+		ErrStatus: http.StatusBadRequest,
+		ErrError:  ReminderTimeConflictError.Error(),
+		ErrCode:   CodeReminderTimeConflict,
+	}
+}
+
+func ReminderTimeRequired() RestErr {
+	return RestError{
+		ErrStatus: http.StatusBadRequest,
+		ErrError:  ReminderTimeRequiredError.Error(),
+		ErrCode:   CodeReminderTimeRequired,
+	}
+}
+
+func RemindersLimit(max int) RestErr {
+	return newParamRestError(http.StatusConflict, CodeRemindersLimit, RemindersLimitError, message.Params{"max": strconv.Itoa(max)})
+}
+
+func ReminderInPast() RestErr {
+	return RestError{
+		ErrStatus: http.StatusUnprocessableEntity,
+		ErrError:  ReminderInPastError.Error(),
+		ErrCode:   CodeReminderInPast,
+	}
+}
+
+func UnknownScope(scope string) RestErr {
+	return newParamRestError(http.StatusBadRequest, CodeUnknownScope, UnknownScopeError, message.Params{"scope": scope})
+}
+
+func InvalidColour() RestErr {
+	return RestError{
+		ErrStatus: http.StatusBadRequest,
+		ErrError:  InvalidColourError.Error(),
+		ErrCode:   CodeInvalidColour,
+	}
+}
+
+func TagNameComma() RestErr {
+	return RestError{
+		ErrStatus: http.StatusBadRequest,
+		ErrError:  TagNameCommaError.Error(),
+		ErrCode:   CodeTagNameComma,
+	}
+}
+
+// internalServerError hides the cause from the client, it is logged instead
+func internalServerError(err error) RestErr {
+	log.Printf("internal server error: %v", err)
+	return NewCodedRestError(http.StatusInternalServerError, CodeInternalServerError, InternalServerError.Error())
+}
+
+func SetRetryAfter(w http.ResponseWriter, restErr RestErr) {
+	retryErr, ok := restErr.(RetryAfterErr)
+	if !ok || retryErr.RetryAfter() <= 0 {
+		return
+	}
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryErr.RetryAfter().Seconds()))))
+}
+
+// ParseErrors turns an error of a repository into the RestErr sent to the client. Errors of postgres
+// are told apart by their SQLSTATE, use cases map the domain errors of their repositories before.
 func ParseErrors(err error) RestErr {
+	var restErr RestErr
+	var syntaxErr *json.SyntaxError
+	var unmarshalErr *json.UnmarshalTypeError
+
 	switch {
+	case errors.As(err, &restErr):
+		return restErr
 	case errors.Is(err, sql.ErrNoRows):
-		return NewRestError(http.StatusNotFound, fmt.Sprintf("%s", NotFound.Error()))
+		return NewCodedRestError(http.StatusNotFound, CodeNotFound, NotFound.Error())
 	case errors.Is(err, context.DeadlineExceeded):
-		return NewRestError(http.StatusRequestTimeout, fmt.Sprintf("%s", RequestTimeoutError.Error()))
-	case strings.Contains(err.Error(), "SQLSTATE"):
+		return NewCodedRestError(http.StatusRequestTimeout, CodeRequestTimeout, RequestTimeoutError.Error())
+	case db_postgres.Code(err) != "":
 		return parseSqlErrors(err)
-	case strings.Contains(err.Error(), "Unmarshal"):
-		return NewRestError(http.StatusBadRequest, fmt.Sprintf("%s", BadRequest.Error()))
-	case strings.Contains(err.Error(), "UUID"):
-		return NewRestError(http.StatusBadRequest, err.Error())
-	case strings.Contains(strings.ToLower(err.Error()), "token"):
-		return NewRestError(http.StatusUnauthorized, fmt.Sprintf("%s", Unauthorized.Error()))
+	case errors.As(err, &syntaxErr), errors.As(err, &unmarshalErr):
+		return NewCodedRestError(http.StatusBadRequest, CodeBadRequest, BadRequest.Error())
 	default:
-		return NewRestError(http.StatusInternalServerError, err.Error())
+		return internalServerError(err)
 	}
 }
 
 func parseSqlErrors(err error) RestErr {
-	if strings.Contains(err.Error(), "23505") {
-		return NewRestError(http.StatusBadRequest, fmt.Sprintf("%s", ExistsPhoneNumberError.Error()))
+	switch db_postgres.Code(err) {
+	case db_postgres.UniqueViolation, db_postgres.ExclusionViolation:
+		return NewCodedRestError(http.StatusConflict, CodeConflict, ConflictError.Error())
+	case db_postgres.ForeignKeyViolation:
+		return NewCodedRestError(http.StatusBadRequest, CodeReferenceNotFound, ReferenceNotFoundError.Error())
+	case db_postgres.SerializationFailure, db_postgres.DeadlockDetected:
+		return NewCodedRestError(http.StatusConflict, CodeConcurrentUpdate, ConcurrentUpdateError.Error())
+	case db_postgres.QueryCanceled:
+		return NewCodedRestError(http.StatusRequestTimeout, CodeRequestTimeout, RequestTimeoutError.Error())
+	case db_postgres.CheckViolation, db_postgres.NotNullViolation, db_postgres.StringDataRightTruncation,
+		db_postgres.InvalidTextRepresentation, db_postgres.InvalidDatetimeFormat, db_postgres.DatetimeFieldOverflow:
+		return NewCodedRestError(http.StatusBadRequest, CodeBadRequest, BadRequest.Error())
+	default:
+		return internalServerError(err)
 	}
-	return NewRestError(http.StatusBadRequest, fmt.Sprintf("%s", BadRequest.Error()))
 }
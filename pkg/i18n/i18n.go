@@ -0,0 +1,123 @@
+package i18n
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"uzinfocom-todo/internal/models"
+	"uzinfocom-todo/pkg/message"
+	"uzinfocom-todo/pkg/validator"
+)
+
+// catalogue holds the messages of every supported locale keyed by the error code they describe or, for
+// the messages of field errors, by their message id
+var catalogue = map[string]map[string]string{
+	models.LocaleEnglish:       english,
+	models.LocaleRussian:       russian,
+	models.LocaleUzbekLatin:    uzbekLatin,
+	models.LocaleUzbekCyrillic: uzbekCyrillic,
+}
+
+// fallbacks are tried in order when a locale lacks a message, DefaultLocale comes last
+var fallbacks = map[string][]string{
+	models.LocaleUzbekCyrillic: {models.LocaleUzbekLatin},
+}
+
+// Message returns the message of code in locale, falling back to related locales and then to
+// DefaultLocale. ok is false when no locale has a message for code.
+func Message(locale string, code string) (message string, ok bool) {
+	chain := append([]string{locale}, fallbacks[locale]...)
+	chain = append(chain, models.DefaultLocale)
+
+	for _, l := range chain {
+		if message, ok = catalogue[l][code]; ok {
+			return message, true
+		}
+	}
+	return "", false
+}
+
+// Translate returns the message of code in locale with params filled in when detail is the English
+// message of code. Other details, such as the ones a caller wrote by hand, are returned as they are.
+func Translate(locale string, code string, detail string, params message.Params) string {
+	if message.Format(english[code], params) != detail {
+		return detail
+	}
+
+	if translated, ok := Message(locale, code); ok {
+		return message.Format(translated, params)
+	}
+	return detail
+}
+
+func TranslateFieldErrors(locale string, errs []validator.FieldError) []validator.FieldError {
+	translated := make([]validator.FieldError, len(errs))
+
+	for i, fieldErr := range errs {
+		translated[i] = fieldErr
+		translated[i].Message = Translate(locale, fieldErr.MessageId, fieldErr.Message, fieldErr.Params)
+	}
+	return translated
+}
+
+// Negotiate picks the supported locale that fits an Accept-Language header best, it returns an
+// empty string when none of the listed languages is supported
+func Negotiate(acceptLanguage string) string {
+	type weighted struct {
+		tag     string
+		quality float64
+	}
+
+	var tags []weighted
+
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		params := strings.Split(strings.TrimSpace(part), ";")
+		tag := strings.TrimSpace(params[0])
+		quality := 1.0
+
+		for _, param := range params[1:] {
+			name, value, found := strings.Cut(strings.TrimSpace(param), "=")
+			if found && strings.TrimSpace(name) == "q" {
+				if q, err := strconv.ParseFloat(strings.TrimSpace(value), 64); err == nil {
+					quality = q
+				}
+			}
+		}
+
+		if tag != "" && quality > 0 {
+			tags = append(tags, weighted{tag: tag, quality: quality})
+		}
+	}
+
+	sort.SliceStable(tags, func(i, j int) bool {
+		return tags[i].quality > tags[j].quality
+	})
+
+	for _, t := range tags {
+		if locale := matchLocale(t.tag); locale != "" {
+			return locale
+		}
+	}
+	return ""
+}
+
+// matchLocale maps a language tag such as ru-RU or uz-Cyrl-UZ to a supported locale. Uzbek is
+// written in Latin script unless the tag asks for Cyrillic.
+func matchLocale(tag string) string {
+	subtags := strings.Split(strings.ToLower(strings.ReplaceAll(tag, "_", "-")), "-")
+
+	switch subtags[0] {
+	case "en":
+		return models.LocaleEnglish
+	case "ru":
+		return models.LocaleRussian
+	case "uz":
+		for _, subtag := range subtags[1:] {
+			if subtag == "cyrl" {
+				return models.LocaleUzbekCyrillic
+			}
+		}
+		return models.LocaleUzbekLatin
+	}
+	return ""
+}
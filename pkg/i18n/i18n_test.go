@@ -0,0 +1,48 @@
+package i18n
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"uzinfocom-todo/internal/models"
+)
+
+func TestEveryKeyExistsInEveryLocale(t *testing.T) {
+	for _, locale := range models.SupportedLocales {
+		messages, ok := catalogue[locale]
+		if !ok {
+			t.Errorf("locale %s has no catalogue", locale)
+			continue
+		}
+
+		for code := range english {
+			if messages[code] == "" {
+				t.Errorf("locale %s has no message for %s", locale, code)
+			}
+		}
+
+		for code := range messages {
+			if _, ok := english[code]; !ok {
+				t.Errorf("locale %s has a message for %s, which is not in the %s catalogue", locale, code, models.LocaleEnglish)
+			}
+		}
+	}
+}
+
+func TestTranslationsKeepPlaceholders(t *testing.T) {
+	placeholder := regexp.MustCompile(`\{[a-z_]+\}`)
+
+	for locale, messages := range catalogue {
+		for code, message := range messages {
+			want := placeholder.FindAllString(english[code], -1)
+			got := placeholder.FindAllString(message, -1)
+			sort.Strings(want)
+			sort.Strings(got)
+
+			if strings.Join(got, ",") != strings.Join(want, ",") {
+				t.Errorf("locale %s message for %s has placeholders %v, want %v", locale, code, got, want)
+			}
+		}
+	}
+}
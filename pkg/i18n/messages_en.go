@@ -0,0 +1,171 @@
+package i18n
+
+import (
+	"uzinfocom-todo/pkg/http_errors"
+	"uzinfocom-todo/pkg/validator"
+)
+
+// english is taken from the errors themselves, so a message is only translated while it is the one the error carries
+var english = map[string]string{
+	http_errors.CodeBadRequest:               http_errors.BadRequest.Error(),
+	http_errors.CodeNotFound:                 http_errors.NotFound.Error(),
+	http_errors.CodeUnauthorized:             http_errors.Unauthorized.Error(),
+	http_errors.CodeRequestTimeout:           http_errors.RequestTimeoutError.Error(),
+	http_errors.CodeConflict:                 http_errors.ConflictError.Error(),
+	http_errors.CodeReferenceNotFound:        http_errors.ReferenceNotFoundError.Error(),
+	http_errors.CodeConcurrentUpdate:         http_errors.ConcurrentUpdateError.Error(),
+	http_errors.CodeValidationFailed:         http_errors.ValidationFailedError.Error(),
+	http_errors.CodePhoneTaken:               http_errors.ExistsPhoneNumberError.Error(),
+	http_errors.CodeSamePhoneNumber:          http_errors.SamePhoneNumberError.Error(),
+	http_errors.CodeInvalidCredentials:       http_errors.InvalidCredentialsError.Error(),
+	http_errors.CodeWrongOldPassword:         http_errors.WrongOldPasswordError.Error(),
+	http_errors.CodeAccountDisabled:          http_errors.AccountDisabledError.Error(),
+	http_errors.CodeAccountLocked:            http_errors.AccountLockedError.Error(),
+	http_errors.CodeUserNotFound:             http_errors.UserNotFoundError.Error(),
+	http_errors.CodeInvalidOtp:               http_errors.InvalidOtpError.Error(),
+	http_errors.CodeOtpRequestsLimit:         http_errors.OtpRequestsLimitError.Error(),
+	http_errors.CodeOtpAttemptsLimit:         http_errors.OtpAttemptsLimitError.Error(),
+	http_errors.CodeSmsDeliveryFailed:        http_errors.SmsDeliveryFailedError.Error(),
+	http_errors.CodeInvalidRefreshToken:      http_errors.InvalidRefreshTokenError.Error(),
+	http_errors.CodeRefreshTokenReused:       http_errors.RefreshTokenReusedError.Error(),
+	http_errors.CodeSessionRevoked:           http_errors.SessionRevokedError.Error(),
+	http_errors.CodeSessionNotFound:          http_errors.SessionNotFoundError.Error(),
+	http_errors.CodeInvalidApiKey:            http_errors.InvalidApiKeyError.Error(),
+	http_errors.CodeApiKeyNotFound:           http_errors.ApiKeyNotFoundError.Error(),
+	http_errors.CodeInsufficientScope:        http_errors.InsufficientScopeError.Error(),
+	http_errors.CodeTotpAlreadyEnabled:       http_errors.TotpAlreadyEnabledError.Error(),
+	http_errors.CodeTotpNotEnrolled:          http_errors.TotpNotEnrolledError.Error(),
+	http_errors.CodeTotpNotEnabled:           http_errors.TotpNotEnabledError.Error(),
+	http_errors.CodeInvalidTotpCode:          http_errors.InvalidTotpCodeError.Error(),
+	http_errors.CodeInvalidChallenge:         http_errors.InvalidChallengeError.Error(),
+	http_errors.CodeTooManyRequests:          http_errors.TooManyRequestsError.Error(),
+	http_errors.CodeObjectNotFoundToDelete:   http_errors.ObjectNotFoundForDeletingError.Error(),
+	http_errors.CodeObjectNotFoundToUpdate:   http_errors.ObjNotFoundToUpdate.Error(),
+	http_errors.CodeTaskNotFound:             http_errors.TaskNotFoundError.Error(),
+	http_errors.CodeTaskOverlap:              http_errors.TaskAlreadyExistsTime.Error(),
+	http_errors.CodeTaskNotStarted:           http_errors.UpdateIsDoneError.Error(),
+	http_errors.CodeTaskNotInTrash:           http_errors.TaskNotInTrashError.Error(),
+	http_errors.CodeTaskNotRecurring:         http_errors.TaskNotRecurringError.Error(),
+	http_errors.CodeOccurrenceNotFound:       http_errors.OccurrenceNotFoundError.Error(),
+	http_errors.CodeBatchRolledBack:          http_errors.BatchRolledBackError.Error(),
+	http_errors.CodeBatchOperationNotApplied: http_errors.BatchOperationNotAppliedError.Error(),
+	http_errors.CodeListNotFound:             http_errors.ListNotFoundError.Error(),
+	http_errors.CodeListExists:               http_errors.ListAlreadyExistsError.Error(),
+	http_errors.CodeListArchived:             http_errors.ListArchivedError.Error(),
+	http_errors.CodeInboxListChange:          http_errors.InboxListChangeError.Error(),
+	http_errors.CodeTagNotFound:              http_errors.TagNotFoundError.Error(),
+	http_errors.CodeTagExists:                http_errors.TagAlreadyExistsError.Error(),
+	http_errors.CodeChecklistItemNotFound:    http_errors.ChecklistItemNotFoundError.Error(),
+	http_errors.CodeReminderNotFound:         http_errors.ReminderNotFoundError.Error(),
+	http_errors.CodeInternalServerError:      http_errors.InternalServerError.Error(),
+	http_errors.CodeInvalidToken:             http_errors.InvalidTokenError.Error(),
+	http_errors.CodeInvalidTimezone:          http_errors.InvalidTimezoneError.Error(),
+	http_errors.CodeInvalidJson:              http_errors.InvalidJsonError.Error(),
+	http_errors.CodeInvalidTaskId:            http_errors.InvalidTaskIdError.Error(),
+	http_errors.CodeInvalidListId:            http_errors.InvalidListIdError.Error(),
+	http_errors.CodeInvalidTagId:             http_errors.InvalidTagIdError.Error(),
+	http_errors.CodeInvalidChecklistItemId:   http_errors.InvalidChecklistItemIdError.Error(),
+	http_errors.CodeInvalidReminderId:        http_errors.InvalidReminderIdError.Error(),
+	http_errors.CodeInvalidOccurrenceId:      http_errors.InvalidOccurrenceIdError.Error(),
+	http_errors.CodeInvalidTime:              http_errors.InvalidTimeError.Error(),
+	http_errors.CodeInvalidValue:             http_errors.InvalidValueError.Error(),
+	http_errors.CodeRequired:                 http_errors.RequiredError.Error(),
+	http_errors.CodeInvalidLength:            http_errors.InvalidLengthError.Error(),
+	http_errors.CodeOutOfRange:               http_errors.OutOfRangeError.Error(),
+	http_errors.CodeNegativeValue:            http_errors.NegativeValueError.Error(),
+	http_errors.CodeEndBeforeStart:           http_errors.EndBeforeStartError.Error(),
+	http_errors.CodeInvalidPeriod:            http_errors.InvalidPeriodError.Error(),
+	http_errors.CodeExpandRequiresPeriod:     http_errors.ExpandRequiresPeriodError.Error(),
+	http_errors.CodeExpandWindowTooLong:      http_errors.ExpandWindowTooLongError.Error(),
+	http_errors.CodeCursorWithExpand:         http_errors.CursorWithExpandError.Error(),
+	http_errors.CodeInvalidCursor:            http_errors.InvalidCursorError.Error(),
+	http_errors.CodeCursorSortMismatch:       http_errors.CursorSortMismatchError.Error(),
+	http_errors.CodeSearchQueryRequired:      http_errors.SearchQueryRequiredError.Error(),
+	http_errors.CodeInvalidRecurrenceRule:    http_errors.InvalidRecurrenceRuleError.Error(),
+	http_errors.CodeInvalidTransition:        http_errors.InvalidTransitionError.Error(),
+	http_errors.CodeRankAfterSelf:            http_errors.RankAfterSelfError.Error(),
+	http_errors.CodeRankAfterOtherStatus:     http_errors.RankAfterOtherStatusError.Error(),
+	http_errors.CodeInvalidBatchSize:         http_errors.BatchSizeError.Error(),
+	http_errors.CodePasswordTooShort:         http_errors.PasswordTooShortError.Error(),
+	http_errors.CodePasswordTooLong:          http_errors.PasswordTooLongError.Error(),
+	http_errors.CodePasswordDigitRequired:    http_errors.PasswordDigitRequiredError.Error(),
+	http_errors.CodePasswordLetterRequired:   http_errors.PasswordLetterRequiredError.Error(),
+	http_errors.CodePasswordSpecialRequired:  http_errors.PasswordSpecialRequiredError.Error(),
+	http_errors.CodePasswordRequiredToDelete: http_errors.PasswordRequiredToDeleteError.Error(),
+	http_errors.CodeCannotDisableSelf:        http_errors.CannotDisableSelfError.Error(),
+	http_errors.CodeInvalidEmail:             http_errors.InvalidEmailError.Error(),
+	http_errors.CodeReminderTimeConflict:     http_errors.ReminderTimeConflictError.Error(),
+	http_errors.CodeReminderTimeRequired:     http_errors.ReminderTimeRequiredError.Error(),
+	http_errors.CodeRemindersLimit:           http_errors.RemindersLimitError.Error(),
+	http_errors.CodeReminderInPast:           http_errors.ReminderInPastError.Error(),
+	http_errors.CodeUnknownScope:             http_errors.UnknownScopeError.Error(),
+	http_errors.CodeInvalidColour:            http_errors.InvalidColourError.Error(),
+	http_errors.CodeTagNameComma:             http_errors.TagNameCommaError.Error(),
+
+	// messages of field errors
+	validator.MessageRequired:     validator.Messages[validator.MessageRequired],
+	validator.MessageRequiredWith: validator.Messages[validator.MessageRequiredWith],
+	validator.MessageTooLong:      validator.Messages[validator.MessageTooLong],
+	validator.MessagePhone:        validator.Messages[validator.MessagePhone],
+	validator.MessageTime:         validator.Messages[validator.MessageTime],
+	validator.MessageAfter:        validator.Messages[validator.MessageAfter],
+
+	// messages of successful responses
+	MessageTokensIssued:                 "Access and Refresh Tokens generated successfully",
+	MessageTokensRefreshed:              "Access and Refresh Tokens refreshed successfully",
+	MessageTwoFactorRequired:            "two-factor authentication required",
+	MessageUserCreated:                  "user created",
+	MessageUsersFetched:                 "Users are fetched",
+	MessageUserDisabled:                 "user disabled successfully",
+	MessageUserEnabled:                  "user enabled successfully",
+	MessageAccountDeleted:               "Account is deleted",
+	MessagePasswordChanged:              "password changed successfully",
+	MessageProfileFetched:               "Profile is fetched",
+	MessageProfileUpdated:               "Profile is updated",
+	MessageOtpSent:                      "verification code sent",
+	MessagePhoneChangeCodeSent:          "verification code sent to the new phone number",
+	MessagePhoneNumberChanged:           "phone number changed successfully",
+	MessageTotpEnrollmentStarted:        "scan the QR code and confirm it with a code from your authenticator app",
+	MessageTotpEnabled:                  "two-factor authentication enabled, store the recovery codes in a safe place",
+	MessageTotpDisabled:                 "two-factor authentication disabled",
+	MessageSessionsFetched:              "Sessions are fetched",
+	MessageLoggedOut:                    "logged out successfully",
+	MessageLoggedOutEverywhere:          "logged out from all sessions successfully",
+	MessageApiKeysFetched:               "Api keys are fetched",
+	MessageApiKeyCreated:                "api key created, store it now because it will not be shown again",
+	MessageApiKeyRevoked:                "api key revoked successfully",
+	MessageTaskFetched:                  "Task is fetched",
+	MessageTasksFetched:                 "Tasks are fetched",
+	MessageTasksFound:                   "Tasks are found",
+	MessageTaskCountsFetched:            "Task counts are fetched",
+	MessageTaskCreated:                  "task created successfully",
+	MessageTaskUpdated:                  "task updated successfully",
+	MessageTaskDeleted:                  "task deleted successfully",
+	MessageTaskPurged:                   "task deleted permanently",
+	MessageTaskRestored:                 "task restored successfully",
+	MessageTaskMoved:                    "task moved successfully",
+	MessageTaskReordered:                "task reordered successfully",
+	MessageOccurrenceUpdated:            "occurrence updated successfully",
+	MessageTrashEmptied:                 "trash emptied successfully",
+	MessageBatchExecuted:                "batch executed",
+	MessageListsFetched:                 "Lists are fetched",
+	MessageListFetched:                  "List is fetched",
+	MessageListCreated:                  "list created successfully",
+	MessageListUpdated:                  "list updated successfully",
+	MessageListDeleted:                  "list deleted, its tasks were moved to the \"Inbox\" list",
+	MessageTagsFetched:                  "Tags are fetched",
+	MessageTagCreated:                   "tag created successfully",
+	MessageTagRenamed:                   "tag renamed successfully",
+	MessageTagDeleted:                   "tag deleted successfully",
+	MessageTagAttached:                  "tag attached to task",
+	MessageTagDetached:                  "tag detached from task",
+	MessageChecklistItemsFetched:        "Checklist items are fetched",
+	MessageChecklistItemCreated:         "checklist item created successfully",
+	MessageChecklistItemUpdated:         "checklist item updated successfully",
+	MessageChecklistItemUpdatedTaskDone: "checklist item updated successfully, task completed",
+	MessageChecklistItemDeleted:         "checklist item deleted successfully",
+	MessageChecklistItemDeletedTaskDone: "checklist item deleted successfully, task completed",
+	MessageRemindersFetched:             "Reminders are fetched",
+	MessageReminderCreated:              "reminder created successfully",
+	MessageReminderDeleted:              "reminder deleted successfully",
+}
@@ -0,0 +1,170 @@
+package i18n
+
+import (
+	"uzinfocom-todo/pkg/http_errors"
+	"uzinfocom-todo/pkg/validator"
+)
+
+var russian = map[string]string{
+	http_errors.CodeBadRequest:               "некорректный запрос",
+	http_errors.CodeNotFound:                 "не найдено",
+	http_errors.CodeUnauthorized:             "требуется авторизация",
+	http_errors.CodeRequestTimeout:           "время ожидания запроса истекло",
+	http_errors.CodeConflict:                 "объект уже существует",
+	http_errors.CodeReferenceNotFound:        "связанный объект не существует",
+	http_errors.CodeConcurrentUpdate:         "запрос конфликтует с параллельным запросом, повторите попытку",
+	http_errors.CodeValidationFailed:         "запрос не прошёл проверку",
+	http_errors.CodePhoneTaken:               "пользователь с таким номером телефона уже существует",
+	http_errors.CodeSamePhoneNumber:          "новый номер телефона совпадает с текущим",
+	http_errors.CodeInvalidCredentials:       "неверный номер телефона или пароль",
+	http_errors.CodeWrongOldPassword:         "старый пароль указан неверно",
+	http_errors.CodeAccountDisabled:          "учётная запись отключена",
+	http_errors.CodeAccountLocked:            "слишком много неудачных попыток входа, учётная запись временно заблокирована",
+	http_errors.CodeUserNotFound:             "пользователь не найден",
+	http_errors.CodeInvalidOtp:               "код подтверждения неверен или истёк",
+	http_errors.CodeOtpRequestsLimit:         "запрошено слишком много кодов подтверждения, повторите попытку позже",
+	http_errors.CodeOtpAttemptsLimit:         "слишком много попыток, запросите новый код подтверждения",
+	http_errors.CodeSmsDeliveryFailed:        "не удалось отправить код подтверждения, повторите попытку позже",
+	http_errors.CodeInvalidRefreshToken:      "токен обновления неверен или истёк",
+	http_errors.CodeRefreshTokenReused:       "токен обновления уже использован, войдите заново",
+	http_errors.CodeSessionRevoked:           "сеанс завершён",
+	http_errors.CodeSessionNotFound:          "сеанс не найден",
+	http_errors.CodeInvalidApiKey:            "неверный API-ключ",
+	http_errors.CodeApiKeyNotFound:           "API-ключ не найден",
+	http_errors.CodeInsufficientScope:        "недостаточно прав доступа",
+	http_errors.CodeTotpAlreadyEnabled:       "двухфакторная аутентификация уже включена",
+	http_errors.CodeTotpNotEnrolled:          "подключение двухфакторной аутентификации не начато",
+	http_errors.CodeTotpNotEnabled:           "двухфакторная аутентификация не включена",
+	http_errors.CodeInvalidTotpCode:          "неверный код аутентификации",
+	http_errors.CodeInvalidChallenge:         "проверка второго фактора недействительна или истекла, войдите заново",
+	http_errors.CodeTooManyRequests:          "слишком много запросов, повторите попытку позже",
+	http_errors.CodeObjectNotFoundToDelete:   "объект для удаления не найден",
+	http_errors.CodeObjectNotFoundToUpdate:   "объект для обновления не найден",
+	http_errors.CodeTaskNotFound:             "задача не найдена",
+	http_errors.CodeTaskOverlap:              "на это время уже есть задача",
+	http_errors.CodeTaskNotStarted:           "задачу можно отметить выполненной только в пределах её времени",
+	http_errors.CodeTaskNotInTrash:           "задача не найдена в корзине",
+	http_errors.CodeTaskNotRecurring:         "задача не является повторяющейся",
+	http_errors.CodeOccurrenceNotFound:       "повторение задачи не найдено",
+	http_errors.CodeBatchRolledBack:          "пакет отменён, ни одна операция не применена",
+	http_errors.CodeBatchOperationNotApplied: "не применено, так как другая операция пакета завершилась ошибкой",
+	http_errors.CodeListNotFound:             "список не найден",
+	http_errors.CodeListExists:               "список с таким названием уже существует",
+	http_errors.CodeListArchived:             "список находится в архиве",
+	http_errors.CodeInboxListChange:          "список «Inbox» нельзя переименовать, архивировать или удалить",
+	http_errors.CodeTagNotFound:              "тег не найден",
+	http_errors.CodeTagExists:                "тег с таким названием уже существует",
+	http_errors.CodeChecklistItemNotFound:    "пункт чек-листа не найден",
+	http_errors.CodeReminderNotFound:         "напоминание не найдено",
+	http_errors.CodeInternalServerError:      "внутренняя ошибка сервера",
+	http_errors.CodeInvalidToken:             "недействительный токен",
+	http_errors.CodeInvalidTimezone:          "часовой пояс должен быть допустимым названием из базы IANA",
+	http_errors.CodeInvalidJson:              "некорректный формат JSON для {object}",
+	http_errors.CodeInvalidTaskId:            "идентификатор задачи не является корректным UUID",
+	http_errors.CodeInvalidListId:            "идентификатор списка не является корректным UUID",
+	http_errors.CodeInvalidTagId:             "идентификатор тега не является корректным UUID",
+	http_errors.CodeInvalidChecklistItemId:   "идентификатор пункта чек-листа не является корректным UUID",
+	http_errors.CodeInvalidReminderId:        "идентификатор напоминания не является корректным UUID",
+	http_errors.CodeInvalidOccurrenceId:      "идентификатор повторения должен быть временем UTC в формате yyyymmddThhmmssZ",
+	http_errors.CodeInvalidTime:              "{field} должно быть в формате RFC 3339 или dd-mm-yyyy hh:mm",
+	http_errors.CodeInvalidValue:             "{field} должно быть одним из значений: {values}",
+	http_errors.CodeRequired:                 "поле {field} обязательно",
+	http_errors.CodeInvalidLength:            "длина {field} должна быть от 1 до {max} символов",
+	http_errors.CodeOutOfRange:               "{field} должно быть от {min} до {max}",
+	http_errors.CodeNegativeValue:            "{field} не может быть отрицательным",
+	http_errors.CodeEndBeforeStart:           "end_time должно быть позже start_time",
+	http_errors.CodeInvalidPeriod:            "to не может быть раньше from",
+	http_errors.CodeExpandRequiresPeriod:     "для expand нужны from и to",
+	http_errors.CodeExpandWindowTooLong:      "окно expand не может быть длиннее {days} дней",
+	http_errors.CodeCursorWithExpand:         "cursor нельзя использовать вместе с expand",
+	http_errors.CodeInvalidCursor:            "некорректный курсор",
+	http_errors.CodeCursorSortMismatch:       "курсор выдан для другого порядка сортировки",
+	http_errors.CodeSearchQueryRequired:      "требуется поисковый запрос q",
+	http_errors.CodeInvalidRecurrenceRule:    "некорректное правило повторения: {reason}",
+	http_errors.CodeInvalidTransition:        "задачу нельзя перевести из {from} в {to}",
+	http_errors.CodeRankAfterSelf:            "after_id должен указывать на другую задачу",
+	http_errors.CodeRankAfterOtherStatus:     "after_id должен указывать на задачу с тем же статусом",
+	http_errors.CodeInvalidBatchSize:         "пакет должен содержать от 1 до {max} операций",
+	http_errors.CodePasswordTooShort:         "пароль должен содержать не менее {min} символов",
+	http_errors.CodePasswordTooLong:          "пароль должен занимать не более {max} байт",
+	http_errors.CodePasswordDigitRequired:    "пароль должен содержать хотя бы одну цифру",
+	http_errors.CodePasswordLetterRequired:   "пароль должен содержать хотя бы одну букву",
+	http_errors.CodePasswordSpecialRequired:  "пароль должен содержать хотя бы один специальный символ",
+	http_errors.CodePasswordRequiredToDelete: "для удаления аккаунта требуется пароль",
+	http_errors.CodeCannotDisableSelf:        "нельзя отключить собственный аккаунт",
+	http_errors.CodeInvalidEmail:             "email должен быть корректным адресом электронной почты",
+	http_errors.CodeReminderTimeConflict:     "можно указать только одно из minutes_before и remind_at",
+	http_errors.CodeReminderTimeRequired:     "требуется minutes_before или remind_at",
+	http_errors.CodeRemindersLimit:           "у задачи может быть не более {max} напоминаний",
+	http_errors.CodeReminderInPast:           "напоминание сработало бы в прошлом",
+	http_errors.CodeUnknownScope:             "неизвестная область доступа: {scope}",
+	http_errors.CodeInvalidColour:            "цвет должен быть в формате #RRGGBB",
+	http_errors.CodeTagNameComma:             "название тега не может содержать запятые",
+
+	// messages of field errors
+	validator.MessageRequired:     "поле {field} обязательно",
+	validator.MessageRequiredWith: "поле {field} обязательно при изменении {other}",
+	validator.MessageTooLong:      "длина {field} не должна превышать {max} символов",
+	validator.MessagePhone:        "{field} должен быть в формате E.164, например +998901234567",
+	validator.MessageTime:         "{field} должно быть в формате RFC 3339 или dd-mm-yyyy hh:mm",
+	validator.MessageAfter:        "{field} должно быть позже {other}",
+
+	// messages of successful responses
+	MessageTokensIssued:                 "токены доступа и обновления успешно созданы",
+	MessageTokensRefreshed:              "токены доступа и обновления успешно обновлены",
+	MessageTwoFactorRequired:            "требуется двухфакторная аутентификация",
+	MessageUserCreated:                  "пользователь создан",
+	MessageUsersFetched:                 "пользователи получены",
+	MessageUserDisabled:                 "пользователь успешно отключён",
+	MessageUserEnabled:                  "пользователь успешно включён",
+	MessageAccountDeleted:               "аккаунт удалён",
+	MessagePasswordChanged:              "пароль успешно изменён",
+	MessageProfileFetched:               "профиль получен",
+	MessageProfileUpdated:               "профиль обновлён",
+	MessageOtpSent:                      "код подтверждения отправлен",
+	MessagePhoneChangeCodeSent:          "код подтверждения отправлен на новый номер телефона",
+	MessagePhoneNumberChanged:           "номер телефона успешно изменён",
+	MessageTotpEnrollmentStarted:        "отсканируйте QR-код и подтвердите его кодом из приложения-аутентификатора",
+	MessageTotpEnabled:                  "двухфакторная аутентификация включена, сохраните коды восстановления в надёжном месте",
+	MessageTotpDisabled:                 "двухфакторная аутентификация отключена",
+	MessageSessionsFetched:              "сеансы получены",
+	MessageLoggedOut:                    "выход выполнен успешно",
+	MessageLoggedOutEverywhere:          "выполнен выход из всех сеансов",
+	MessageApiKeysFetched:               "API-ключи получены",
+	MessageApiKeyCreated:                "API-ключ создан, сохраните его сейчас, потому что он больше не будет показан",
+	MessageApiKeyRevoked:                "API-ключ успешно отозван",
+	MessageTaskFetched:                  "задача получена",
+	MessageTasksFetched:                 "задачи получены",
+	MessageTasksFound:                   "задачи найдены",
+	MessageTaskCountsFetched:            "количество задач получено",
+	MessageTaskCreated:                  "задача успешно создана",
+	MessageTaskUpdated:                  "задача успешно обновлена",
+	MessageTaskDeleted:                  "задача успешно удалена",
+	MessageTaskPurged:                   "задача удалена навсегда",
+	MessageTaskRestored:                 "задача успешно восстановлена",
+	MessageTaskMoved:                    "задача успешно перемещена",
+	MessageTaskReordered:                "порядок задачи успешно изменён",
+	MessageOccurrenceUpdated:            "повторение задачи успешно обновлено",
+	MessageTrashEmptied:                 "корзина успешно очищена",
+	MessageBatchExecuted:                "пакет выполнен",
+	MessageListsFetched:                 "списки получены",
+	MessageListFetched:                  "список получен",
+	MessageListCreated:                  "список успешно создан",
+	MessageListUpdated:                  "список успешно обновлён",
+	MessageListDeleted:                  "список удалён, его задачи перенесены в список «Inbox»",
+	MessageTagsFetched:                  "теги получены",
+	MessageTagCreated:                   "тег успешно создан",
+	MessageTagRenamed:                   "тег успешно переименован",
+	MessageTagDeleted:                   "тег успешно удалён",
+	MessageTagAttached:                  "тег добавлен к задаче",
+	MessageTagDetached:                  "тег удалён из задачи",
+	MessageChecklistItemsFetched:        "пункты чек-листа получены",
+	MessageChecklistItemCreated:         "пункт чек-листа успешно создан",
+	MessageChecklistItemUpdated:         "пункт чек-листа успешно обновлён",
+	MessageChecklistItemUpdatedTaskDone: "пункт чек-листа успешно обновлён, задача выполнена",
+	MessageChecklistItemDeleted:         "пункт чек-листа успешно удалён",
+	MessageChecklistItemDeletedTaskDone: "пункт чек-листа успешно удалён, задача выполнена",
+	MessageRemindersFetched:             "напоминания получены",
+	MessageReminderCreated:              "напоминание успешно создано",
+	MessageReminderDeleted:              "напоминание успешно удалено",
+}
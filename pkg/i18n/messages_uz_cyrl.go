@@ -0,0 +1,170 @@
+package i18n
+
+import (
+	"uzinfocom-todo/pkg/http_errors"
+	"uzinfocom-todo/pkg/validator"
+)
+
+var uzbekCyrillic = map[string]string{
+	http_errors.CodeBadRequest:               "нотўғри сўров",
+	http_errors.CodeNotFound:                 "топилмади",
+	http_errors.CodeUnauthorized:             "авторизация талаб қилинади",
+	http_errors.CodeRequestTimeout:           "сўровни кутиш вақти тугади",
+	http_errors.CodeConflict:                 "объект аллақачон мавжуд",
+	http_errors.CodeReferenceNotFound:        "боғланган объект мавжуд эмас",
+	http_errors.CodeConcurrentUpdate:         "сўров бошқа параллел сўров билан тўқнашди, қайтадан уриниб кўринг",
+	http_errors.CodeValidationFailed:         "сўров текширувдан ўтмади",
+	http_errors.CodePhoneTaken:               "бундай телефон рақамли фойдаланувчи аллақачон мавжуд",
+	http_errors.CodeSamePhoneNumber:          "янги телефон рақами жорий рақам билан бир хил",
+	http_errors.CodeInvalidCredentials:       "телефон рақами ёки парол нотўғри",
+	http_errors.CodeWrongOldPassword:         "эски парол нотўғри",
+	http_errors.CodeAccountDisabled:          "ҳисоб ўчирилган",
+	http_errors.CodeAccountLocked:            "киришга муваффақиятсиз уринишлар жуда кўп, ҳисоб вақтинча блокланди",
+	http_errors.CodeUserNotFound:             "фойдаланувчи топилмади",
+	http_errors.CodeInvalidOtp:               "тасдиқлаш коди нотўғри ёки муддати ўтган",
+	http_errors.CodeOtpRequestsLimit:         "жуда кўп тасдиқлаш коди сўралди, кейинроқ қайта уриниб кўринг",
+	http_errors.CodeOtpAttemptsLimit:         "уринишлар жуда кўп, янги тасдиқлаш кодини сўранг",
+	http_errors.CodeSmsDeliveryFailed:        "тасдиқлаш кодини юбориб бўлмади, кейинроқ қайта уриниб кўринг",
+	http_errors.CodeInvalidRefreshToken:      "янгилаш токени нотўғри ёки муддати ўтган",
+	http_errors.CodeRefreshTokenReused:       "янгилаш токени аллақачон ишлатилган, қайтадан киринг",
+	http_errors.CodeSessionRevoked:           "сеанс якунланган",
+	http_errors.CodeSessionNotFound:          "сеанс топилмади",
+	http_errors.CodeInvalidApiKey:            "API калити нотўғри",
+	http_errors.CodeApiKeyNotFound:           "API калити топилмади",
+	http_errors.CodeInsufficientScope:        "кириш ҳуқуқлари етарли эмас",
+	http_errors.CodeTotpAlreadyEnabled:       "икки босқичли аутентификация аллақачон ёқилган",
+	http_errors.CodeTotpNotEnrolled:          "икки босқичли аутентификацияни улаш бошланмаган",
+	http_errors.CodeTotpNotEnabled:           "икки босқичли аутентификация ёқилмаган",
+	http_errors.CodeInvalidTotpCode:          "аутентификация коди нотўғри",
+	http_errors.CodeInvalidChallenge:         "иккинчи босқич текшируви нотўғри ёки муддати ўтган, қайтадан киринг",
+	http_errors.CodeTooManyRequests:          "сўровлар жуда кўп, кейинроқ қайта уриниб кўринг",
+	http_errors.CodeObjectNotFoundToDelete:   "ўчириш учун объект топилмади",
+	http_errors.CodeObjectNotFoundToUpdate:   "янгилаш учун объект топилмади",
+	http_errors.CodeTaskNotFound:             "вазифа топилмади",
+	http_errors.CodeTaskOverlap:              "бу вақт оралиғида вазифа аллақачон мавжуд",
+	http_errors.CodeTaskNotStarted:           "вазифани фақат унинг вақт оралиғида бажарилган деб белгилаш мумкин",
+	http_errors.CodeTaskNotInTrash:           "вазифа саватда топилмади",
+	http_errors.CodeTaskNotRecurring:         "вазифа такрорланувчи эмас",
+	http_errors.CodeOccurrenceNotFound:       "вазифанинг такрори топилмади",
+	http_errors.CodeBatchRolledBack:          "пакет бекор қилинди, ҳеч бир амал бажарилмади",
+	http_errors.CodeBatchOperationNotApplied: "пакетдаги бошқа амал муваффақиятсиз бўлгани учун бажарилмади",
+	http_errors.CodeListNotFound:             "рўйхат топилмади",
+	http_errors.CodeListExists:               "бундай номли рўйхат аллақачон мавжуд",
+	http_errors.CodeListArchived:             "рўйхат архивланган",
+	http_errors.CodeInboxListChange:          "«Inbox» рўйхатини қайта номлаш, архивлаш ёки ўчириш мумкин эмас",
+	http_errors.CodeTagNotFound:              "тег топилмади",
+	http_errors.CodeTagExists:                "бундай номли тег аллақачон мавжуд",
+	http_errors.CodeChecklistItemNotFound:    "назорат рўйхати банди топилмади",
+	http_errors.CodeReminderNotFound:         "эслатма топилмади",
+	http_errors.CodeInternalServerError:      "сервернинг ички хатоси",
+	http_errors.CodeInvalidToken:             "токен нотўғри",
+	http_errors.CodeInvalidTimezone:          "вақт минтақаси IANA базасидаги тўғри ном бўлиши керак",
+	http_errors.CodeInvalidJson:              "{object} учун JSON формати нотўғри",
+	http_errors.CodeInvalidTaskId:            "вазифа идентификатори тўғри UUID эмас",
+	http_errors.CodeInvalidListId:            "рўйхат идентификатори тўғри UUID эмас",
+	http_errors.CodeInvalidTagId:             "тег идентификатори тўғри UUID эмас",
+	http_errors.CodeInvalidChecklistItemId:   "назорат рўйхати банди идентификатори тўғри UUID эмас",
+	http_errors.CodeInvalidReminderId:        "эслатма идентификатори тўғри UUID эмас",
+	http_errors.CodeInvalidOccurrenceId:      "такрор идентификатори yyyymmddThhmmssZ форматидаги UTC вақти бўлиши керак",
+	http_errors.CodeInvalidTime:              "{field} RFC 3339 ёки dd-mm-yyyy hh:mm форматида бўлиши керак",
+	http_errors.CodeInvalidValue:             "{field} қуйидагилардан бири бўлиши керак: {values}",
+	http_errors.CodeRequired:                 "{field} майдони тўлдирилиши шарт",
+	http_errors.CodeInvalidLength:            "{field} узунлиги 1 дан {max} белгигача бўлиши керак",
+	http_errors.CodeOutOfRange:               "{field} {min} дан {max} гача бўлиши керак",
+	http_errors.CodeNegativeValue:            "{field} манфий бўлмаслиги керак",
+	http_errors.CodeEndBeforeStart:           "end_time start_time дан кейин бўлиши керак",
+	http_errors.CodeInvalidPeriod:            "to from дан олдин бўлмаслиги керак",
+	http_errors.CodeExpandRequiresPeriod:     "expand учун from ва to керак",
+	http_errors.CodeExpandWindowTooLong:      "expand оралиғи {days} кундан узун бўлмаслиги керак",
+	http_errors.CodeCursorWithExpand:         "cursor ни expand билан бирга ишлатиб бўлмайди",
+	http_errors.CodeInvalidCursor:            "курсор нотўғри",
+	http_errors.CodeCursorSortMismatch:       "курсор бошқа саралаш тартиби учун берилган",
+	http_errors.CodeSearchQueryRequired:      "q қидирув сўрови талаб қилинади",
+	http_errors.CodeInvalidRecurrenceRule:    "такрорланиш қоидаси нотўғри: {reason}",
+	http_errors.CodeInvalidTransition:        "вазифани {from} ҳолатидан {to} ҳолатига ўтказиб бўлмайди",
+	http_errors.CodeRankAfterSelf:            "after_id бошқа вазифа бўлиши керак",
+	http_errors.CodeRankAfterOtherStatus:     "after_id худди шу ҳолатдаги вазифа бўлиши керак",
+	http_errors.CodeInvalidBatchSize:         "пакетда 1 дан {max} тагача амал бўлиши керак",
+	http_errors.CodePasswordTooShort:         "парол камида {min} белгидан иборат бўлиши керак",
+	http_errors.CodePasswordTooLong:          "парол кўпи билан {max} байт бўлиши керак",
+	http_errors.CodePasswordDigitRequired:    "паролда камида битта рақам бўлиши керак",
+	http_errors.CodePasswordLetterRequired:   "паролда камида битта ҳарф бўлиши керак",
+	http_errors.CodePasswordSpecialRequired:  "паролда камида битта махсус белги бўлиши керак",
+	http_errors.CodePasswordRequiredToDelete: "ҳисобни ўчириш учун парол талаб қилинади",
+	http_errors.CodeCannotDisableSelf:        "ўз ҳисобингизни ўчириб қўя олмайсиз",
+	http_errors.CodeInvalidEmail:             "email тўғри электрон почта манзили бўлиши керак",
+	http_errors.CodeReminderTimeConflict:     "minutes_before ва remind_at дан фақат биттасини кўрсатиш мумкин",
+	http_errors.CodeReminderTimeRequired:     "minutes_before ёки remind_at талаб қилинади",
+	http_errors.CodeRemindersLimit:           "вазифада кўпи билан {max} та эслатма бўлиши мумкин",
+	http_errors.CodeReminderInPast:           "эслатма ўтган вақтда ишга тушган бўларди",
+	http_errors.CodeUnknownScope:             "номаълум кириш ҳуқуқи: {scope}",
+	http_errors.CodeInvalidColour:            "ранг #RRGGBB форматида бўлиши керак",
+	http_errors.CodeTagNameComma:             "тег номида вергул бўлмаслиги керак",
+
+	// messages of field errors
+	validator.MessageRequired:     "{field} майдони тўлдирилиши шарт",
+	validator.MessageRequiredWith: "{other} ўзгартирилганда {field} майдони тўлдирилиши шарт",
+	validator.MessageTooLong:      "{field} узунлиги {max} белгидан ошмаслиги керак",
+	validator.MessagePhone:        "{field} E.164 форматида бўлиши керак, масалан +998901234567",
+	validator.MessageTime:         "{field} RFC 3339 ёки dd-mm-yyyy hh:mm форматида бўлиши керак",
+	validator.MessageAfter:        "{field} {other} дан кейин бўлиши керак",
+
+	// messages of successful responses
+	MessageTokensIssued:                 "кириш ва янгилаш токенлари муваффақиятли яратилди",
+	MessageTokensRefreshed:              "кириш ва янгилаш токенлари муваффақиятли янгиланди",
+	MessageTwoFactorRequired:            "икки босқичли аутентификация талаб қилинади",
+	MessageUserCreated:                  "фойдаланувчи яратилди",
+	MessageUsersFetched:                 "фойдаланувчилар олинди",
+	MessageUserDisabled:                 "фойдаланувчи муваффақиятли ўчириб қўйилди",
+	MessageUserEnabled:                  "фойдаланувчи муваффақиятли ёқилди",
+	MessageAccountDeleted:               "ҳисоб ўчирилди",
+	MessagePasswordChanged:              "парол муваффақиятли ўзгартирилди",
+	MessageProfileFetched:               "профил олинди",
+	MessageProfileUpdated:               "профил янгиланди",
+	MessageOtpSent:                      "тасдиқлаш коди юборилди",
+	MessagePhoneChangeCodeSent:          "тасдиқлаш коди янги телефон рақамига юборилди",
+	MessagePhoneNumberChanged:           "телефон рақами муваффақиятли ўзгартирилди",
+	MessageTotpEnrollmentStarted:        "QR кодни сканерланг ва уни аутентификатор иловасидаги код билан тасдиқланг",
+	MessageTotpEnabled:                  "икки босқичли аутентификация ёқилди, тиклаш кодларини хавфсиз жойда сақланг",
+	MessageTotpDisabled:                 "икки босқичли аутентификация ўчирилди",
+	MessageSessionsFetched:              "сеанслар олинди",
+	MessageLoggedOut:                    "тизимдан муваффақиятли чиқилди",
+	MessageLoggedOutEverywhere:          "барча сеанслардан муваффақиятли чиқилди",
+	MessageApiKeysFetched:               "API калитлари олинди",
+	MessageApiKeyCreated:                "API калити яратилди, уни ҳозир сақлаб қўйинг, чунки у бошқа кўрсатилмайди",
+	MessageApiKeyRevoked:                "API калити муваффақиятли бекор қилинди",
+	MessageTaskFetched:                  "вазифа олинди",
+	MessageTasksFetched:                 "вазифалар олинди",
+	MessageTasksFound:                   "вазифалар топилди",
+	MessageTaskCountsFetched:            "вазифалар сони олинди",
+	MessageTaskCreated:                  "вазифа муваффақиятли яратилди",
+	MessageTaskUpdated:                  "вазифа муваффақиятли янгиланди",
+	MessageTaskDeleted:                  "вазифа муваффақиятли ўчирилди",
+	MessageTaskPurged:                   "вазифа бутунлай ўчирилди",
+	MessageTaskRestored:                 "вазифа муваффақиятли тикланди",
+	MessageTaskMoved:                    "вазифа муваффақиятли кўчирилди",
+	MessageTaskReordered:                "вазифа тартиби муваффақиятли ўзгартирилди",
+	MessageOccurrenceUpdated:            "вазифанинг такрори муваффақиятли янгиланди",
+	MessageTrashEmptied:                 "сават муваффақиятли тозаланди",
+	MessageBatchExecuted:                "пакет бажарилди",
+	MessageListsFetched:                 "рўйхатлар олинди",
+	MessageListFetched:                  "рўйхат олинди",
+	MessageListCreated:                  "рўйхат муваффақиятли яратилди",
+	MessageListUpdated:                  "рўйхат муваффақиятли янгиланди",
+	MessageListDeleted:                  "рўйхат ўчирилди, унинг вазифалари «Inbox» рўйхатига кўчирилди",
+	MessageTagsFetched:                  "теглар олинди",
+	MessageTagCreated:                   "тег муваффақиятли яратилди",
+	MessageTagRenamed:                   "тег муваффақиятли қайта номланди",
+	MessageTagDeleted:                   "тег муваффақиятли ўчирилди",
+	MessageTagAttached:                  "тег вазифага бириктирилди",
+	MessageTagDetached:                  "тег вазифадан ажратилди",
+	MessageChecklistItemsFetched:        "назорат рўйхати бандлари олинди",
+	MessageChecklistItemCreated:         "назорат рўйхати банди муваффақиятли яратилди",
+	MessageChecklistItemUpdated:         "назорат рўйхати банди муваффақиятли янгиланди",
+	MessageChecklistItemUpdatedTaskDone: "назорат рўйхати банди муваффақиятли янгиланди, вазифа бажарилди",
+	MessageChecklistItemDeleted:         "назорат рўйхати банди муваффақиятли ўчирилди",
+	MessageChecklistItemDeletedTaskDone: "назорат рўйхати банди муваффақиятли ўчирилди, вазифа бажарилди",
+	MessageRemindersFetched:             "эслатмалар олинди",
+	MessageReminderCreated:              "эслатма муваффақиятли яратилди",
+	MessageReminderDeleted:              "эслатма муваффақиятли ўчирилди",
+}
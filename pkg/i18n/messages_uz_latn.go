@@ -0,0 +1,170 @@
+package i18n
+
+import (
+	"uzinfocom-todo/pkg/http_errors"
+	"uzinfocom-todo/pkg/validator"
+)
+
+var uzbekLatin = map[string]string{
+	http_errors.CodeBadRequest:               "notoʻgʻri soʻrov",
+	http_errors.CodeNotFound:                 "topilmadi",
+	http_errors.CodeUnauthorized:             "avtorizatsiya talab qilinadi",
+	http_errors.CodeRequestTimeout:           "soʻrovni kutish vaqti tugadi",
+	http_errors.CodeConflict:                 "obyekt allaqachon mavjud",
+	http_errors.CodeReferenceNotFound:        "bogʻlangan obyekt mavjud emas",
+	http_errors.CodeConcurrentUpdate:         "soʻrov boshqa parallel soʻrov bilan toʻqnashdi, qaytadan urinib koʻring",
+	http_errors.CodeValidationFailed:         "soʻrov tekshiruvdan oʻtmadi",
+	http_errors.CodePhoneTaken:               "bunday telefon raqamli foydalanuvchi allaqachon mavjud",
+	http_errors.CodeSamePhoneNumber:          "yangi telefon raqami joriy raqam bilan bir xil",
+	http_errors.CodeInvalidCredentials:       "telefon raqami yoki parol notoʻgʻri",
+	http_errors.CodeWrongOldPassword:         "eski parol notoʻgʻri",
+	http_errors.CodeAccountDisabled:          "hisob oʻchirilgan",
+	http_errors.CodeAccountLocked:            "kirishga muvaffaqiyatsiz urinishlar juda koʻp, hisob vaqtincha bloklandi",
+	http_errors.CodeUserNotFound:             "foydalanuvchi topilmadi",
+	http_errors.CodeInvalidOtp:               "tasdiqlash kodi notoʻgʻri yoki muddati oʻtgan",
+	http_errors.CodeOtpRequestsLimit:         "juda koʻp tasdiqlash kodi soʻraldi, keyinroq qayta urinib koʻring",
+	http_errors.CodeOtpAttemptsLimit:         "urinishlar juda koʻp, yangi tasdiqlash kodini soʻrang",
+	http_errors.CodeSmsDeliveryFailed:        "tasdiqlash kodini yuborib boʻlmadi, keyinroq qayta urinib koʻring",
+	http_errors.CodeInvalidRefreshToken:      "yangilash tokeni notoʻgʻri yoki muddati oʻtgan",
+	http_errors.CodeRefreshTokenReused:       "yangilash tokeni allaqachon ishlatilgan, qaytadan kiring",
+	http_errors.CodeSessionRevoked:           "seans yakunlangan",
+	http_errors.CodeSessionNotFound:          "seans topilmadi",
+	http_errors.CodeInvalidApiKey:            "API kaliti notoʻgʻri",
+	http_errors.CodeApiKeyNotFound:           "API kaliti topilmadi",
+	http_errors.CodeInsufficientScope:        "kirish huquqlari yetarli emas",
+	http_errors.CodeTotpAlreadyEnabled:       "ikki bosqichli autentifikatsiya allaqachon yoqilgan",
+	http_errors.CodeTotpNotEnrolled:          "ikki bosqichli autentifikatsiyani ulash boshlanmagan",
+	http_errors.CodeTotpNotEnabled:           "ikki bosqichli autentifikatsiya yoqilmagan",
+	http_errors.CodeInvalidTotpCode:          "autentifikatsiya kodi notoʻgʻri",
+	http_errors.CodeInvalidChallenge:         "ikkinchi bosqich tekshiruvi notoʻgʻri yoki muddati oʻtgan, qaytadan kiring",
+	http_errors.CodeTooManyRequests:          "soʻrovlar juda koʻp, keyinroq qayta urinib koʻring",
+	http_errors.CodeObjectNotFoundToDelete:   "oʻchirish uchun obyekt topilmadi",
+	http_errors.CodeObjectNotFoundToUpdate:   "yangilash uchun obyekt topilmadi",
+	http_errors.CodeTaskNotFound:             "vazifa topilmadi",
+	http_errors.CodeTaskOverlap:              "bu vaqt oraligʻida vazifa allaqachon mavjud",
+	http_errors.CodeTaskNotStarted:           "vazifani faqat uning vaqt oraligʻida bajarilgan deb belgilash mumkin",
+	http_errors.CodeTaskNotInTrash:           "vazifa savatda topilmadi",
+	http_errors.CodeTaskNotRecurring:         "vazifa takrorlanuvchi emas",
+	http_errors.CodeOccurrenceNotFound:       "vazifaning takrori topilmadi",
+	http_errors.CodeBatchRolledBack:          "paket bekor qilindi, hech bir amal bajarilmadi",
+	http_errors.CodeBatchOperationNotApplied: "paketdagi boshqa amal muvaffaqiyatsiz boʻlgani uchun bajarilmadi",
+	http_errors.CodeListNotFound:             "roʻyxat topilmadi",
+	http_errors.CodeListExists:               "bunday nomli roʻyxat allaqachon mavjud",
+	http_errors.CodeListArchived:             "roʻyxat arxivlangan",
+	http_errors.CodeInboxListChange:          "«Inbox» roʻyxatini qayta nomlash, arxivlash yoki oʻchirish mumkin emas",
+	http_errors.CodeTagNotFound:              "teg topilmadi",
+	http_errors.CodeTagExists:                "bunday nomli teg allaqachon mavjud",
+	http_errors.CodeChecklistItemNotFound:    "nazorat roʻyxati bandi topilmadi",
+	http_errors.CodeReminderNotFound:         "eslatma topilmadi",
+	http_errors.CodeInternalServerError:      "serverning ichki xatosi",
+	http_errors.CodeInvalidToken:             "token notoʻgʻri",
+	http_errors.CodeInvalidTimezone:          "vaqt mintaqasi IANA bazasidagi toʻgʻri nom boʻlishi kerak",
+	http_errors.CodeInvalidJson:              "{object} uchun JSON formati notoʻgʻri",
+	http_errors.CodeInvalidTaskId:            "vazifa identifikatori toʻgʻri UUID emas",
+	http_errors.CodeInvalidListId:            "roʻyxat identifikatori toʻgʻri UUID emas",
+	http_errors.CodeInvalidTagId:             "teg identifikatori toʻgʻri UUID emas",
+	http_errors.CodeInvalidChecklistItemId:   "nazorat roʻyxati bandi identifikatori toʻgʻri UUID emas",
+	http_errors.CodeInvalidReminderId:        "eslatma identifikatori toʻgʻri UUID emas",
+	http_errors.CodeInvalidOccurrenceId:      "takror identifikatori yyyymmddThhmmssZ formatidagi UTC vaqti boʻlishi kerak",
+	http_errors.CodeInvalidTime:              "{field} RFC 3339 yoki dd-mm-yyyy hh:mm formatida boʻlishi kerak",
+	http_errors.CodeInvalidValue:             "{field} quyidagilardan biri boʻlishi kerak: {values}",
+	http_errors.CodeRequired:                 "{field} maydoni toʻldirilishi shart",
+	http_errors.CodeInvalidLength:            "{field} uzunligi 1 dan {max} belgigacha boʻlishi kerak",
+	http_errors.CodeOutOfRange:               "{field} {min} dan {max} gacha boʻlishi kerak",
+	http_errors.CodeNegativeValue:            "{field} manfiy boʻlmasligi kerak",
+	http_errors.CodeEndBeforeStart:           "end_time start_time dan keyin boʻlishi kerak",
+	http_errors.CodeInvalidPeriod:            "to from dan oldin boʻlmasligi kerak",
+	http_errors.CodeExpandRequiresPeriod:     "expand uchun from va to kerak",
+	http_errors.CodeExpandWindowTooLong:      "expand oraligʻi {days} kundan uzun boʻlmasligi kerak",
+	http_errors.CodeCursorWithExpand:         "cursor ni expand bilan birga ishlatib boʻlmaydi",
+	http_errors.CodeInvalidCursor:            "kursor notoʻgʻri",
+	http_errors.CodeCursorSortMismatch:       "kursor boshqa saralash tartibi uchun berilgan",
+	http_errors.CodeSearchQueryRequired:      "q qidiruv soʻrovi talab qilinadi",
+	http_errors.CodeInvalidRecurrenceRule:    "takrorlanish qoidasi notoʻgʻri: {reason}",
+	http_errors.CodeInvalidTransition:        "vazifani {from} holatidan {to} holatiga oʻtkazib boʻlmaydi",
+	http_errors.CodeRankAfterSelf:            "after_id boshqa vazifa boʻlishi kerak",
+	http_errors.CodeRankAfterOtherStatus:     "after_id xuddi shu holatdagi vazifa boʻlishi kerak",
+	http_errors.CodeInvalidBatchSize:         "paketda 1 dan {max} tagacha amal boʻlishi kerak",
+	http_errors.CodePasswordTooShort:         "parol kamida {min} belgidan iborat boʻlishi kerak",
+	http_errors.CodePasswordTooLong:          "parol koʻpi bilan {max} bayt boʻlishi kerak",
+	http_errors.CodePasswordDigitRequired:    "parolda kamida bitta raqam boʻlishi kerak",
+	http_errors.CodePasswordLetterRequired:   "parolda kamida bitta harf boʻlishi kerak",
+	http_errors.CodePasswordSpecialRequired:  "parolda kamida bitta maxsus belgi boʻlishi kerak",
+	http_errors.CodePasswordRequiredToDelete: "hisobni oʻchirish uchun parol talab qilinadi",
+	http_errors.CodeCannotDisableSelf:        "oʻz hisobingizni oʻchirib qoʻya olmaysiz",
+	http_errors.CodeInvalidEmail:             "email toʻgʻri elektron pochta manzili boʻlishi kerak",
+	http_errors.CodeReminderTimeConflict:     "minutes_before va remind_at dan faqat bittasini koʻrsatish mumkin",
+	http_errors.CodeReminderTimeRequired:     "minutes_before yoki remind_at talab qilinadi",
+	http_errors.CodeRemindersLimit:           "vazifada koʻpi bilan {max} ta eslatma boʻlishi mumkin",
+	http_errors.CodeReminderInPast:           "eslatma oʻtgan vaqtda ishga tushgan boʻlardi",
+	http_errors.CodeUnknownScope:             "nomaʼlum kirish huquqi: {scope}",
+	http_errors.CodeInvalidColour:            "rang #RRGGBB formatida boʻlishi kerak",
+	http_errors.CodeTagNameComma:             "teg nomida vergul boʻlmasligi kerak",
+
+	// messages of field errors
+	validator.MessageRequired:     "{field} maydoni toʻldirilishi shart",
+	validator.MessageRequiredWith: "{other} oʻzgartirilganda {field} maydoni toʻldirilishi shart",
+	validator.MessageTooLong:      "{field} uzunligi {max} belgidan oshmasligi kerak",
+	validator.MessagePhone:        "{field} E.164 formatida boʻlishi kerak, masalan +998901234567",
+	validator.MessageTime:         "{field} RFC 3339 yoki dd-mm-yyyy hh:mm formatida boʻlishi kerak",
+	validator.MessageAfter:        "{field} {other} dan keyin boʻlishi kerak",
+
+	// messages of successful responses
+	MessageTokensIssued:                 "kirish va yangilash tokenlari muvaffaqiyatli yaratildi",
+	MessageTokensRefreshed:              "kirish va yangilash tokenlari muvaffaqiyatli yangilandi",
+	MessageTwoFactorRequired:            "ikki bosqichli autentifikatsiya talab qilinadi",
+	MessageUserCreated:                  "foydalanuvchi yaratildi",
+	MessageUsersFetched:                 "foydalanuvchilar olindi",
+	MessageUserDisabled:                 "foydalanuvchi muvaffaqiyatli oʻchirib qoʻyildi",
+	MessageUserEnabled:                  "foydalanuvchi muvaffaqiyatli yoqildi",
+	MessageAccountDeleted:               "hisob oʻchirildi",
+	MessagePasswordChanged:              "parol muvaffaqiyatli oʻzgartirildi",
+	MessageProfileFetched:               "profil olindi",
+	MessageProfileUpdated:               "profil yangilandi",
+	MessageOtpSent:                      "tasdiqlash kodi yuborildi",
+	MessagePhoneChangeCodeSent:          "tasdiqlash kodi yangi telefon raqamiga yuborildi",
+	MessagePhoneNumberChanged:           "telefon raqami muvaffaqiyatli oʻzgartirildi",
+	MessageTotpEnrollmentStarted:        "QR kodni skanerlang va uni autentifikator ilovasidagi kod bilan tasdiqlang",
+	MessageTotpEnabled:                  "ikki bosqichli autentifikatsiya yoqildi, tiklash kodlarini xavfsiz joyda saqlang",
+	MessageTotpDisabled:                 "ikki bosqichli autentifikatsiya oʻchirildi",
+	MessageSessionsFetched:              "seanslar olindi",
+	MessageLoggedOut:                    "tizimdan muvaffaqiyatli chiqildi",
+	MessageLoggedOutEverywhere:          "barcha seanslardan muvaffaqiyatli chiqildi",
+	MessageApiKeysFetched:               "API kalitlari olindi",
+	MessageApiKeyCreated:                "API kaliti yaratildi, uni hozir saqlab qoʻying, chunki u boshqa koʻrsatilmaydi",
+	MessageApiKeyRevoked:                "API kaliti muvaffaqiyatli bekor qilindi",
+	MessageTaskFetched:                  "vazifa olindi",
+	MessageTasksFetched:                 "vazifalar olindi",
+	MessageTasksFound:                   "vazifalar topildi",
+	MessageTaskCountsFetched:            "vazifalar soni olindi",
+	MessageTaskCreated:                  "vazifa muvaffaqiyatli yaratildi",
+	MessageTaskUpdated:                  "vazifa muvaffaqiyatli yangilandi",
+	MessageTaskDeleted:                  "vazifa muvaffaqiyatli oʻchirildi",
+	MessageTaskPurged:                   "vazifa butunlay oʻchirildi",
+	MessageTaskRestored:                 "vazifa muvaffaqiyatli tiklandi",
+	MessageTaskMoved:                    "vazifa muvaffaqiyatli koʻchirildi",
+	MessageTaskReordered:                "vazifa tartibi muvaffaqiyatli oʻzgartirildi",
+	MessageOccurrenceUpdated:            "vazifaning takrori muvaffaqiyatli yangilandi",
+	MessageTrashEmptied:                 "savat muvaffaqiyatli tozalandi",
+	MessageBatchExecuted:                "paket bajarildi",
+	MessageListsFetched:                 "roʻyxatlar olindi",
+	MessageListFetched:                  "roʻyxat olindi",
+	MessageListCreated:                  "roʻyxat muvaffaqiyatli yaratildi",
+	MessageListUpdated:                  "roʻyxat muvaffaqiyatli yangilandi",
+	MessageListDeleted:                  "roʻyxat oʻchirildi, uning vazifalari «Inbox» roʻyxatiga koʻchirildi",
+	MessageTagsFetched:                  "teglar olindi",
+	MessageTagCreated:                   "teg muvaffaqiyatli yaratildi",
+	MessageTagRenamed:                   "teg muvaffaqiyatli qayta nomlandi",
+	MessageTagDeleted:                   "teg muvaffaqiyatli oʻchirildi",
+	MessageTagAttached:                  "teg vazifaga biriktirildi",
+	MessageTagDetached:                  "teg vazifadan ajratildi",
+	MessageChecklistItemsFetched:        "nazorat roʻyxati bandlari olindi",
+	MessageChecklistItemCreated:         "nazorat roʻyxati bandi muvaffaqiyatli yaratildi",
+	MessageChecklistItemUpdated:         "nazorat roʻyxati bandi muvaffaqiyatli yangilandi",
+	MessageChecklistItemUpdatedTaskDone: "nazorat roʻyxati bandi muvaffaqiyatli yangilandi, vazifa bajarildi",
+	MessageChecklistItemDeleted:         "nazorat roʻyxati bandi muvaffaqiyatli oʻchirildi",
+	MessageChecklistItemDeletedTaskDone: "nazorat roʻyxati bandi muvaffaqiyatli oʻchirildi, vazifa bajarildi",
+	MessageRemindersFetched:             "eslatmalar olindi",
+	MessageReminderCreated:              "eslatma muvaffaqiyatli yaratildi",
+	MessageReminderDeleted:              "eslatma muvaffaqiyatli oʻchirildi",
+}
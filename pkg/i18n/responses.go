@@ -0,0 +1,62 @@
+package i18n
+
+// ids of the messages of successful responses, the catalogue of every locale has a message for each of them
+const (
+	MessageTokensIssued                 = "TOKENS_ISSUED"
+	MessageTokensRefreshed              = "TOKENS_REFRESHED"
+	MessageTwoFactorRequired            = "TWO_FACTOR_REQUIRED"
+	MessageUserCreated                  = "USER_CREATED"
+	MessageUsersFetched                 = "USERS_FETCHED"
+	MessageUserDisabled                 = "USER_DISABLED"
+	MessageUserEnabled                  = "USER_ENABLED"
+	MessageAccountDeleted               = "ACCOUNT_DELETED"
+	MessagePasswordChanged              = "PASSWORD_CHANGED"
+	MessageProfileFetched               = "PROFILE_FETCHED"
+	MessageProfileUpdated               = "PROFILE_UPDATED"
+	MessageOtpSent                      = "OTP_SENT"
+	MessagePhoneChangeCodeSent          = "PHONE_CHANGE_CODE_SENT"
+	MessagePhoneNumberChanged           = "PHONE_NUMBER_CHANGED"
+	MessageTotpEnrollmentStarted        = "TOTP_ENROLLMENT_STARTED"
+	MessageTotpEnabled                  = "TOTP_ENABLED"
+	MessageTotpDisabled                 = "TOTP_DISABLED"
+	MessageSessionsFetched              = "SESSIONS_FETCHED"
+	MessageLoggedOut                    = "LOGGED_OUT"
+	MessageLoggedOutEverywhere          = "LOGGED_OUT_EVERYWHERE"
+	MessageApiKeysFetched               = "API_KEYS_FETCHED"
+	MessageApiKeyCreated                = "API_KEY_CREATED"
+	MessageApiKeyRevoked                = "API_KEY_REVOKED"
+	MessageTaskFetched                  = "TASK_FETCHED"
+	MessageTasksFetched                 = "TASKS_FETCHED"
+	MessageTasksFound                   = "TASKS_FOUND"
+	MessageTaskCountsFetched            = "TASK_COUNTS_FETCHED"
+	MessageTaskCreated                  = "TASK_CREATED"
+	MessageTaskUpdated                  = "TASK_UPDATED"
+	MessageTaskDeleted                  = "TASK_DELETED"
+	MessageTaskPurged                   = "TASK_PURGED"
+	MessageTaskRestored                 = "TASK_RESTORED"
+	MessageTaskMoved                    = "TASK_MOVED"
+	MessageTaskReordered                = "TASK_REORDERED"
+	MessageOccurrenceUpdated            = "OCCURRENCE_UPDATED"
+	MessageTrashEmptied                 = "TRASH_EMPTIED"
+	MessageBatchExecuted                = "BATCH_EXECUTED"
+	MessageListsFetched                 = "LISTS_FETCHED"
+	MessageListFetched                  = "LIST_FETCHED"
+	MessageListCreated                  = "LIST_CREATED"
+	MessageListUpdated                  = "LIST_UPDATED"
+	MessageListDeleted                  = "LIST_DELETED"
+	MessageTagsFetched                  = "TAGS_FETCHED"
+	MessageTagCreated                   = "TAG_CREATED"
+	MessageTagRenamed                   = "TAG_RENAMED"
+	MessageTagDeleted                   = "TAG_DELETED"
+	MessageTagAttached                  = "TAG_ATTACHED"
+	MessageTagDetached                  = "TAG_DETACHED"
+	MessageChecklistItemsFetched        = "CHECKLIST_ITEMS_FETCHED"
+	MessageChecklistItemCreated         = "CHECKLIST_ITEM_CREATED"
+	MessageChecklistItemUpdated         = "CHECKLIST_ITEM_UPDATED"
+	MessageChecklistItemUpdatedTaskDone = "CHECKLIST_ITEM_UPDATED_TASK_DONE"
+	MessageChecklistItemDeleted         = "CHECKLIST_ITEM_DELETED"
+	MessageChecklistItemDeletedTaskDone = "CHECKLIST_ITEM_DELETED_TASK_DONE"
+	MessageRemindersFetched             = "REMINDERS_FETCHED"
+	MessageReminderCreated              = "REMINDER_CREATED"
+	MessageReminderDeleted              = "REMINDER_DELETED"
+)
@@ -0,0 +1,19 @@
+package message
+
+import "strings"
+
+// Params are the values of the placeholders of a message, {max} is filled in with Params["max"]
+type Params map[string]string
+
+// Format fills the placeholders of template with params, placeholders without a value are left as they are
+func Format(template string, params Params) string {
+	if len(params) == 0 {
+		return template
+	}
+
+	pairs := make([]string, 0, len(params)*2)
+	for name, value := range params {
+		pairs = append(pairs, "{"+name+"}", value)
+	}
+	return strings.NewReplacer(pairs...).Replace(template)
+}
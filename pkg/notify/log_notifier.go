@@ -0,0 +1,18 @@
+package notify
+
+import (
+	"context"
+	"log"
+)
+
+type logNotifier struct{}
+
+// NewLogNotifier returns a Notifier that only writes reminders to the application log, for local development
+func NewLogNotifier() Notifier {
+	return &logNotifier{}
+}
+
+func (n *logNotifier) Notify(ctx context.Context, notification Notification) error {
+	log.Printf("reminder for %s: %s", notification.UserId, notification.Text())
+	return nil
+}
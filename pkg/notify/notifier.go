@@ -0,0 +1,60 @@
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"time"
+	"uzinfocom-todo/config"
+)
+
+const (
+	LogNotifierType     = "log"
+	WebhookNotifierType = "webhook"
+	SmtpNotifierType    = "smtp"
+)
+
+// ErrNoRecipient is returned by notifiers that cannot reach the user, such as SMTP without an email address
+var ErrNoRecipient = errors.New("user has no address for this notifier")
+
+// Notification is a task reminder for a single user
+type Notification struct {
+	ReminderId  uuid.UUID `json:"reminder_id"`
+	UserId      uuid.UUID `json:"user_id"`
+	Name        string    `json:"name"`
+	PhoneNumber string    `json:"phone_number"`
+	Email       string    `json:"email,omitempty"`
+	TaskId      uuid.UUID `json:"task_id"`
+	TaskTitle   string    `json:"task_title"`
+	StartTime   time.Time `json:"start_time"`
+	RemindAt    time.Time `json:"remind_at"`
+}
+
+func (n Notification) Subject() string {
+	return "Reminder: " + n.TaskTitle
+}
+
+func (n Notification) Text() string {
+	return fmt.Sprintf("Hello %s, your task %q starts at %s.", n.Name, n.TaskTitle, n.StartTime.Format("02-01-2006 15:04 MST"))
+}
+
+// Notifier delivers reminders to users
+type Notifier interface {
+	Notify(ctx context.Context, notification Notification) error
+}
+
+// NewNotifier builds the notifier selected by cfg.Notify.Notifier, falling back to the log notifier
+func NewNotifier(cfg *config.Config) (Notifier, error) {
+	switch cfg.Notify.Notifier {
+	case "", LogNotifierType:
+		return NewLogNotifier(), nil
+	case WebhookNotifierType:
+		return NewWebhookNotifier(cfg.Notify.WebhookUrl, cfg.Notify.WebhookSecret, cfg.Notify.WebhookTimeout)
+	case SmtpNotifierType:
+		return NewSmtpNotifier(cfg.Notify.SmtpHost, cfg.Notify.SmtpPort, cfg.Notify.SmtpUsername, cfg.Notify.SmtpPassword, cfg.Notify.SmtpFrom,
+			cfg.Notify.SmtpTimeout)
+	default:
+		return nil, fmt.Errorf("unknown notifier: %s", cfg.Notify.Notifier)
+	}
+}
@@ -0,0 +1,135 @@
+package notify
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+const defaultSmtpTimeout = 10 * time.Second
+
+type smtpNotifier struct {
+	host    string
+	addr    string
+	auth    smtp.Auth
+	from    string
+	timeout time.Duration
+}
+
+// NewSmtpNotifier returns a Notifier that emails the user. Without a username no authentication is
+// done, which is what local SMTP stand-ins such as MailHog expect. A message that is not delivered
+// within timeout is given up.
+func NewSmtpNotifier(host string, port int, username string, password string, from string, timeout time.Duration) (Notifier, error) {
+	if host == "" || from == "" {
+		return nil, errors.New("smtp host and from address are required")
+	}
+
+	if port <= 0 {
+		port = 25
+	}
+
+	if timeout <= 0 {
+		timeout = defaultSmtpTimeout
+	}
+
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return &smtpNotifier{
+		host:    host,
+		addr:    net.JoinHostPort(host, fmt.Sprint(port)),
+		auth:    auth,
+		from:    from,
+		timeout: timeout,
+	}, nil
+}
+
+func (n *smtpNotifier) Notify(ctx context.Context, notification Notification) error {
+	if notification.Email == "" {
+		return ErrNoRecipient
+	}
+
+	if strings.ContainsAny(notification.Email, "\r\n") {
+		return fmt.Errorf("invalid email address %q", notification.Email)
+	}
+
+	headers := []string{
+		"From: " + n.from,
+		"To: " + notification.Email,
+		"Subject: " + mime.QEncoding.Encode("utf-8", notification.Subject()),
+		"Date: " + time.Now().Format(time.RFC1123Z),
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=utf-8",
+	}
+	message := strings.Join(headers, "\r\n") + "\r\n\r\n" + notification.Text() + "\r\n"
+
+	return n.send(ctx, notification.Email, []byte(message))
+}
+
+// send does what smtp.SendMail does, but on a connection that is dialled with ctx and closed once
+// the timeout has passed, so a stalled server cannot hold the scheduler
+func (n *smtpNotifier) send(ctx context.Context, to string, message []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, n.timeout)
+	defer cancel()
+
+	dialer := net.Dialer{}
+	conn, err := dialer.DialContext(ctx, "tcp", n.addr)
+	if err != nil {
+		return err
+	}
+
+	deadline, _ := ctx.Deadline()
+	if err = conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return err
+	}
+
+	client, err := smtp.NewClient(conn, n.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err = client.StartTLS(&tls.Config{ServerName: n.host}); err != nil {
+			return err
+		}
+	}
+
+	if n.auth != nil {
+		if err = client.Auth(n.auth); err != nil {
+			return err
+		}
+	}
+
+	if err = client.Mail(n.from); err != nil {
+		return err
+	}
+
+	if err = client.Rcpt(to); err != nil {
+		return err
+	}
+
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+
+	if _, err = w.Write(message); err != nil {
+		return err
+	}
+
+	if err = w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
@@ -0,0 +1,78 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const defaultWebhookTimeout = 10 * time.Second
+
+// SignatureHeader carries the hex HMAC-SHA256 of the request body when a webhook secret is configured
+const SignatureHeader = "X-Todo-Signature"
+
+type webhookNotifier struct {
+	url    string
+	secret string
+	client *http.Client
+}
+
+// NewWebhookNotifier returns a Notifier that POSTs every notification as JSON to the given URL
+func NewWebhookNotifier(webhookUrl string, secret string, timeout time.Duration) (Notifier, error) {
+	if webhookUrl == "" {
+		return nil, errors.New("webhook url is empty")
+	}
+
+	if _, err := url.ParseRequestURI(webhookUrl); err != nil {
+		return nil, fmt.Errorf("webhook url is invalid: %w", err)
+	}
+
+	if timeout <= 0 {
+		timeout = defaultWebhookTimeout
+	}
+
+	return &webhookNotifier{
+		url:    webhookUrl,
+		secret: secret,
+		client: &http.Client{Timeout: timeout},
+	}, nil
+}
+
+func (n *webhookNotifier) Notify(ctx context.Context, notification Notification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	if n.secret != "" {
+		mac := hmac.New(sha256.New, []byte(n.secret))
+		mac.Write(body)
+		req.Header.Set(SignatureHeader, hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook responded with %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const maxMemoryBuckets = 100000
+
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+	limit     Limit
+}
+
+type memoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+}
+
+// NewMemoryStore keeps buckets in process memory, limits are therefore per instance
+func NewMemoryStore() Store {
+	return &memoryStore{
+		buckets: make(map[string]*bucket),
+	}
+}
+
+func (s *memoryStore) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+
+	b, ok := s.buckets[key]
+	if !ok {
+		if len(s.buckets) >= maxMemoryBuckets {
+			s.evictFull(now)
+		}
+		b = &bucket{tokens: limit.capacity(), updatedAt: now}
+		s.buckets[key] = b
+	}
+
+	tokens, result := take(b.tokens, now.Sub(b.updatedAt), limit)
+	b.tokens = tokens
+	b.updatedAt = now
+	b.limit = limit
+
+	return result, nil
+}
+
+// evictFull drops buckets that have refilled completely, they behave exactly like missing ones.
+// When none has, the least recently used bucket is dropped so the map never grows past maxMemoryBuckets.
+func (s *memoryStore) evictFull(now time.Time) {
+	var oldestKey string
+	var oldest *bucket
+
+	for key, b := range s.buckets {
+		if b.tokens+now.Sub(b.updatedAt).Seconds()*b.limit.ratePerSecond() >= b.limit.capacity() {
+			delete(s.buckets, key)
+			continue
+		}
+
+		if oldest == nil || b.updatedAt.Before(oldest.updatedAt) {
+			oldestKey, oldest = key, b
+		}
+	}
+
+	if len(s.buckets) >= maxMemoryBuckets && oldest != nil {
+		delete(s.buckets, oldestKey)
+	}
+}
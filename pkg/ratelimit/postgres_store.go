@@ -0,0 +1,89 @@
+package ratelimit
+
+import (
+	"context"
+	"database/sql"
+	"github.com/jmoiron/sqlx"
+	"log"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	cleanupEvery     = 1000
+	cleanupOlderThan = 24 * time.Hour
+)
+
+type postgresStore struct {
+	db    *sqlx.DB
+	calls uint64
+}
+
+// NewPostgresStore keeps buckets in the rate_limit_buckets table so every instance shares the same limits
+func NewPostgresStore(db *sqlx.DB) Store {
+	return &postgresStore{
+		db: db,
+	}
+}
+
+func (s *postgresStore) Take(ctx context.Context, key string, limit Limit) (Result, error) {
+	if atomic.AddUint64(&s.calls, 1)%cleanupEvery == 0 {
+		go s.cleanup()
+	}
+
+	tx, err := s.db.BeginTxx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return Result{}, err
+	}
+	defer tx.Rollback()
+
+	if _, err = tx.ExecContext(
+		ctx,
+		`INSERT INTO rate_limit_buckets (bucket_key, tokens, updated_at) VALUES ($1, $2, clock_timestamp())
+			ON CONFLICT (bucket_key) DO NOTHING`,
+		key,
+		limit.capacity(),
+	); err != nil {
+		return Result{}, err
+	}
+
+	var tokens, elapsedSeconds float64
+
+	if err = tx.QueryRowxContext(
+		ctx,
+		`SELECT tokens, EXTRACT(EPOCH FROM (clock_timestamp() - updated_at))::float8 FROM rate_limit_buckets
+			WHERE bucket_key = $1 FOR UPDATE`,
+		key,
+	).Scan(&tokens, &elapsedSeconds); err != nil {
+		return Result{}, err
+	}
+
+	tokens, result := take(tokens, time.Duration(elapsedSeconds*float64(time.Second)), limit)
+
+	if _, err = tx.ExecContext(
+		ctx,
+		`UPDATE rate_limit_buckets SET tokens = $1, updated_at = clock_timestamp() WHERE bucket_key = $2`,
+		tokens,
+		key,
+	); err != nil {
+		return Result{}, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return Result{}, err
+	}
+	return result, nil
+}
+
+func (s *postgresStore) cleanup() {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	if _, err := s.db.ExecContext(
+		ctx,
+		`DELETE FROM rate_limit_buckets WHERE updated_at < clock_timestamp() - make_interval(secs => $1)`,
+		cleanupOlderThan.Seconds(),
+	); err != nil {
+		log.Printf("ratelimit: cleaning up buckets failed: %v", err)
+	}
+}
@@ -0,0 +1,86 @@
+package ratelimit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"github.com/jmoiron/sqlx"
+	"math"
+	"time"
+	"uzinfocom-todo/config"
+)
+
+const (
+	MemoryStoreType   = "memory"
+	PostgresStoreType = "postgres"
+)
+
+// maxKeyLength is the length of rate_limit_buckets.bucket_key
+const maxKeyLength = 255
+
+// Limit allows Requests per Period on average with bursts of up to Requests
+type Limit struct {
+	Requests int
+	Period   time.Duration
+}
+
+type Result struct {
+	Allowed    bool
+	RetryAfter time.Duration
+}
+
+// Store keeps one token bucket per key
+type Store interface {
+	Take(ctx context.Context, key string, limit Limit) (Result, error)
+}
+
+// Key is the bucket of key in the namespace name. A key that would not fit a bucket is replaced by its
+// SHA-256, so clients cannot make the store fail or hold arbitrarily long keys.
+func Key(name string, key string) string {
+	bucketKey := name + ":" + key
+	if len(bucketKey) <= maxKeyLength {
+		return bucketKey
+	}
+
+	sum := sha256.Sum256([]byte(key))
+	return name + ":sha256:" + hex.EncodeToString(sum[:])
+}
+
+func NewStore(cfg *config.Config, db *sqlx.DB) (Store, error) {
+	switch cfg.Server.RateLimitStore {
+	case "", MemoryStoreType:
+		return NewMemoryStore(), nil
+	case PostgresStoreType:
+		return NewPostgresStore(db), nil
+	default:
+		return nil, errors.New("unknown rate limit store: " + cfg.Server.RateLimitStore)
+	}
+}
+
+func (l Limit) capacity() float64 {
+	return float64(l.Requests)
+}
+
+func (l Limit) ratePerSecond() float64 {
+	return float64(l.Requests) / l.Period.Seconds()
+}
+
+func (l Limit) Enabled() bool {
+	return l.Requests > 0 && l.Period > 0
+}
+
+// take refills a bucket that had tokens elapsed ago and tries to take one token from it
+func take(tokens float64, elapsed time.Duration, limit Limit) (float64, Result) {
+	tokens = math.Min(limit.capacity(), tokens+elapsed.Seconds()*limit.ratePerSecond())
+
+	if tokens >= 1 {
+		return tokens - 1, Result{Allowed: true}
+	}
+
+	wait := (1 - tokens) / limit.ratePerSecond()
+	return tokens, Result{
+		Allowed:    false,
+		RetryAfter: time.Duration(math.Ceil(wait)) * time.Second,
+	}
+}
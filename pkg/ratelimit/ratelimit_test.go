@@ -0,0 +1,118 @@
+package ratelimit
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTakeRefillsBucket(t *testing.T) {
+	// 10 requests per minute refill one token every 6 seconds
+	limit := Limit{Requests: 10, Period: time.Minute}
+
+	tests := []struct {
+		name       string
+		tokens     float64
+		elapsed    time.Duration
+		allowed    bool
+		left       float64
+		retryAfter time.Duration
+	}{
+		{"full bucket", 10, 0, true, 9, 0},
+		{"last token", 1, 0, true, 0, 0},
+		{"empty bucket", 0, 0, false, 0, 6 * time.Second},
+		{"partly refilled", 0, 3 * time.Second, false, 0.5, 3 * time.Second},
+		{"refilled one token", 0, 6 * time.Second, true, 0, 0},
+		{"refill is capped at the capacity", 5, time.Hour, true, 9, 0},
+		{"retry after is rounded up to seconds", 0, 4500 * time.Millisecond, false, 0.75, 2 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			left, result := take(tt.tokens, tt.elapsed, limit)
+
+			if result.Allowed != tt.allowed {
+				t.Fatalf("allowed = %v, want %v", result.Allowed, tt.allowed)
+			}
+
+			if diff := left - tt.left; diff > 1e-9 || diff < -1e-9 {
+				t.Errorf("tokens left = %v, want %v", left, tt.left)
+			}
+
+			if result.RetryAfter != tt.retryAfter {
+				t.Errorf("retry after = %v, want %v", result.RetryAfter, tt.retryAfter)
+			}
+		})
+	}
+}
+
+func TestMemoryStoreLimitsBursts(t *testing.T) {
+	store := NewMemoryStore()
+	limit := Limit{Requests: 3, Period: time.Hour}
+
+	for i := 1; i <= 4; i++ {
+		result, err := store.Take(context.Background(), "ip:127.0.0.1", limit)
+		if err != nil {
+			t.Fatalf("take %d: %v", i, err)
+		}
+
+		if want := i <= 3; result.Allowed != want {
+			t.Errorf("take %d allowed = %v, want %v", i, result.Allowed, want)
+		}
+	}
+
+	result, err := store.Take(context.Background(), "ip:127.0.0.2", limit)
+	if err != nil || !result.Allowed {
+		t.Errorf("another key shares the bucket: allowed = %v, err = %v", result.Allowed, err)
+	}
+}
+
+func TestMemoryStoreEvictsOldestBucketWhenFull(t *testing.T) {
+	store := NewMemoryStore().(*memoryStore)
+	limit := Limit{Requests: 3, Period: time.Hour}
+	now := time.Now()
+
+	// none of the buckets has refilled, so only the oldest one can make room
+	for i := 0; i < maxMemoryBuckets; i++ {
+		store.buckets[strconv.Itoa(i)] = &bucket{tokens: 0, updatedAt: now.Add(time.Duration(i) * time.Millisecond), limit: limit}
+	}
+
+	if _, err := store.Take(context.Background(), "new", limit); err != nil {
+		t.Fatalf("take: %v", err)
+	}
+
+	if len(store.buckets) > maxMemoryBuckets {
+		t.Errorf("store holds %d buckets, want at most %d", len(store.buckets), maxMemoryBuckets)
+	}
+
+	if _, ok := store.buckets["0"]; ok {
+		t.Error("the oldest bucket was kept")
+	}
+}
+
+func TestKeyFitsBucketColumn(t *testing.T) {
+	tests := []struct {
+		name string
+		key  string
+		want string
+	}{
+		{"short key", "+998901234567", "phone:+998901234567"},
+		{"long key", strings.Repeat("9", 300), "phone:sha256:"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := Key("phone", tt.key)
+
+			if len(key) > maxKeyLength {
+				t.Errorf("key has %d bytes, the column holds %d", len(key), maxKeyLength)
+			}
+
+			if !strings.HasPrefix(key, tt.want) {
+				t.Errorf("key = %s, want it to start with %s", key, tt.want)
+			}
+		})
+	}
+}
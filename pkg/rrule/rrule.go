@@ -0,0 +1,359 @@
+package rrule
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Supported RFC 5545 frequencies
+const (
+	Daily   = "DAILY"
+	Weekly  = "WEEKLY"
+	Monthly = "MONTHLY"
+)
+
+// maxPeriods bounds how many days, weeks or months are walked for a single expansion
+const maxPeriods = 100000
+
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+var weekdayCodes = map[time.Weekday]string{
+	time.Monday:    "MO",
+	time.Tuesday:   "TU",
+	time.Wednesday: "WE",
+	time.Thursday:  "TH",
+	time.Friday:    "FR",
+	time.Saturday:  "SA",
+	time.Sunday:    "SU",
+}
+
+var ErrInvalidRule = errors.New("recurrence rule is invalid")
+
+// Weekday is a BYDAY entry. N is the ordinal within the month (-1 is the last one), 0 means every such day.
+type Weekday struct {
+	Day time.Weekday
+	N   int
+}
+
+// Rule is the subset of an RRULE this service understands: FREQ, INTERVAL, BYDAY, COUNT and UNTIL
+type Rule struct {
+	Freq     string
+	Interval int
+	ByDay    []Weekday
+	Count    int
+	Until    *time.Time
+}
+
+// Parse reads a rule such as "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE;COUNT=10", an "RRULE:" prefix is allowed
+func Parse(s string) (*Rule, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "RRULE:")
+	rule := &Rule{Interval: 1}
+	seen := map[string]bool{}
+
+	for _, part := range strings.Split(s, ";") {
+		name, value, ok := strings.Cut(part, "=")
+		name = strings.ToUpper(strings.TrimSpace(name))
+		value = strings.ToUpper(strings.TrimSpace(value))
+
+		if !ok || value == "" {
+			return nil, fmt.Errorf("%w: %q is not a NAME=VALUE pair", ErrInvalidRule, part)
+		}
+
+		if seen[name] {
+			return nil, fmt.Errorf("%w: %s is repeated", ErrInvalidRule, name)
+		}
+		seen[name] = true
+
+		var err error
+
+		switch name {
+		case "FREQ":
+			if value != Daily && value != Weekly && value != Monthly {
+				return nil, fmt.Errorf("%w: FREQ must be DAILY, WEEKLY or MONTHLY", ErrInvalidRule)
+			}
+			rule.Freq = value
+		case "INTERVAL":
+			if rule.Interval, err = strconv.Atoi(value); err != nil || rule.Interval < 1 {
+				return nil, fmt.Errorf("%w: INTERVAL must be a positive number", ErrInvalidRule)
+			}
+		case "COUNT":
+			if rule.Count, err = strconv.Atoi(value); err != nil || rule.Count < 1 {
+				return nil, fmt.Errorf("%w: COUNT must be a positive number", ErrInvalidRule)
+			}
+		case "UNTIL":
+			until, err := parseUntil(value)
+			if err != nil {
+				return nil, err
+			}
+			rule.Until = &until
+		case "BYDAY":
+			if rule.ByDay, err = parseByDay(value); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("%w: %s is not supported", ErrInvalidRule, name)
+		}
+	}
+
+	if rule.Freq == "" {
+		return nil, fmt.Errorf("%w: FREQ is required", ErrInvalidRule)
+	}
+
+	if rule.Count > 0 && rule.Until != nil {
+		return nil, fmt.Errorf("%w: COUNT and UNTIL cannot be used together", ErrInvalidRule)
+	}
+
+	for _, day := range rule.ByDay {
+		if day.N != 0 && rule.Freq != Monthly {
+			return nil, fmt.Errorf("%w: BYDAY ordinals are only allowed with FREQ=MONTHLY", ErrInvalidRule)
+		}
+	}
+	return rule, nil
+}
+
+func parseUntil(value string) (time.Time, error) {
+	for _, layout := range []string{"20060102T150405Z", "20060102T150405"} {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+
+	// a date-only UNTIL includes the whole day
+	if t, err := time.Parse("20060102", value); err == nil {
+		return t.Add(24*time.Hour - time.Second), nil
+	}
+	return time.Time{}, fmt.Errorf("%w: UNTIL must look like 20060102T150405Z", ErrInvalidRule)
+}
+
+func parseByDay(value string) ([]Weekday, error) {
+	days := []Weekday{}
+
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if len(item) < 2 {
+			return nil, fmt.Errorf("%w: %q is not a BYDAY value", ErrInvalidRule, item)
+		}
+
+		day, ok := weekdays[item[len(item)-2:]]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q is not a BYDAY value", ErrInvalidRule, item)
+		}
+
+		n := 0
+		if ordinal := item[:len(item)-2]; ordinal != "" {
+			var err error
+			if n, err = strconv.Atoi(ordinal); err != nil || n == 0 || n < -5 || n > 5 {
+				return nil, fmt.Errorf("%w: %q is not a BYDAY value", ErrInvalidRule, item)
+			}
+		}
+		days = append(days, Weekday{Day: day, N: n})
+	}
+	return days, nil
+}
+
+func (r Rule) String() string {
+	parts := []string{"FREQ=" + r.Freq}
+
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+
+	if len(r.ByDay) > 0 {
+		days := make([]string, 0, len(r.ByDay))
+		for _, day := range r.ByDay {
+			code := weekdayCodes[day.Day]
+			if day.N != 0 {
+				code = strconv.Itoa(day.N) + code
+			}
+			days = append(days, code)
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format("20060102T150405Z"))
+	}
+	return strings.Join(parts, ";")
+}
+
+// Between returns the starts of the occurrences of a series beginning at dtstart that fall within [from, to].
+// As in RFC 5545 dtstart is always the first occurrence and counts towards COUNT.
+func (r Rule) Between(dtstart time.Time, from time.Time, to time.Time) []time.Time {
+	occurrences := []time.Time{}
+	count := 0
+
+	emit := func(t time.Time) bool {
+		if r.Until != nil && t.After(*r.Until) {
+			return false
+		}
+
+		if t.After(to) {
+			return false
+		}
+
+		count++
+		if !t.Before(from) {
+			occurrences = append(occurrences, t)
+		}
+		return r.Count == 0 || count < r.Count
+	}
+
+	if !emit(dtstart) {
+		return occurrences
+	}
+
+	for period := 0; period < maxPeriods; period++ {
+		periodStart, candidates := r.period(dtstart, period)
+
+		if periodStart.After(to) {
+			break
+		}
+
+		for _, candidate := range candidates {
+			if !candidate.After(dtstart) {
+				continue
+			}
+
+			if !emit(candidate) {
+				return occurrences
+			}
+		}
+	}
+	return occurrences
+}
+
+func (r Rule) Includes(dtstart time.Time, t time.Time) bool {
+	for _, occurrence := range r.Between(dtstart, t, t) {
+		if occurrence.Equal(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// period returns the first moment of the n-th day, week or month of the series and the sorted occurrence
+// candidates in it, each at the time of day of dtstart
+func (r Rule) period(dtstart time.Time, n int) (time.Time, []time.Time) {
+	at := func(year int, month time.Month, day int) time.Time {
+		return Date(year, month, day, dtstart.Hour(), dtstart.Minute(), dtstart.Second(), dtstart.Location())
+	}
+
+	switch r.Freq {
+	case Daily:
+		day := at(dtstart.Year(), dtstart.Month(), dtstart.Day()+n*r.Interval)
+		periodStart := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+
+		if len(r.ByDay) > 0 && !r.hasWeekday(day.Weekday()) {
+			return periodStart, nil
+		}
+		return periodStart, []time.Time{day}
+
+	case Weekly:
+		// weeks start on Monday, the RFC 5545 default WKST
+		offset := (int(dtstart.Weekday()) + 6) % 7
+		monday := time.Date(dtstart.Year(), dtstart.Month(), dtstart.Day()-offset+7*n*r.Interval, 0, 0, 0, 0, dtstart.Location())
+		candidates := []time.Time{}
+
+		for i := 0; i < 7; i++ {
+			day := at(monday.Year(), monday.Month(), monday.Day()+i)
+			if (len(r.ByDay) == 0 && day.Weekday() == dtstart.Weekday()) || r.hasWeekday(day.Weekday()) {
+				candidates = append(candidates, day)
+			}
+		}
+		return monday, candidates
+
+	default:
+		first := time.Date(dtstart.Year(), dtstart.Month()+time.Month(n*r.Interval), 1, 0, 0, 0, 0, dtstart.Location())
+		daysInMonth := time.Date(first.Year(), first.Month()+1, 0, 0, 0, 0, 0, first.Location()).Day()
+
+		if len(r.ByDay) == 0 {
+			// months that are too short for the day of dtstart are skipped, as RFC 5545 requires
+			if dtstart.Day() > daysInMonth {
+				return first, nil
+			}
+			return first, []time.Time{at(first.Year(), first.Month(), dtstart.Day())}
+		}
+
+		days := map[int]bool{}
+		for _, byDay := range r.ByDay {
+			matching := []int{}
+			for day := 1; day <= daysInMonth; day++ {
+				if time.Date(first.Year(), first.Month(), day, 0, 0, 0, 0, first.Location()).Weekday() == byDay.Day {
+					matching = append(matching, day)
+				}
+			}
+
+			switch {
+			case byDay.N == 0:
+				for _, day := range matching {
+					days[day] = true
+				}
+			case byDay.N > 0 && byDay.N <= len(matching):
+				days[matching[byDay.N-1]] = true
+			case byDay.N < 0 && -byDay.N <= len(matching):
+				days[matching[len(matching)+byDay.N]] = true
+			}
+		}
+
+		sorted := make([]int, 0, len(days))
+		for day := range days {
+			sorted = append(sorted, day)
+		}
+		sort.Ints(sorted)
+
+		candidates := make([]time.Time, 0, len(sorted))
+		for _, day := range sorted {
+			candidates = append(candidates, at(first.Year(), first.Month(), day))
+		}
+		return first, candidates
+	}
+}
+
+func (r Rule) hasWeekday(day time.Weekday) bool {
+	for _, byDay := range r.ByDay {
+		if byDay.Day == day {
+			return true
+		}
+	}
+	return false
+}
+
+// Date is time.Date with the RFC 5545 rules for local times around DST changes: a time that falls into
+// the gap of a change is moved forward by the length of the gap, and a time that occurs twice resolves
+// to the first of the two. Occurrences of a series keep their wall clock time in loc this way.
+func Date(year int, month time.Month, day int, hour int, min int, sec int, loc *time.Location) time.Time {
+	wall := time.Date(year, month, day, hour, min, sec, 0, time.UTC)
+
+	// a DST change within 12 hours gives the wall clock time two possible offsets
+	probe := time.Date(year, month, day, hour, min, sec, 0, loc)
+	_, before := probe.Add(-12 * time.Hour).Zone()
+	_, after := probe.Add(12 * time.Hour).Zone()
+
+	first := wall.Add(-time.Duration(before) * time.Second).In(loc)
+	second := wall.Add(-time.Duration(after) * time.Second).In(loc)
+
+	if !sameWallClock(first, wall) && sameWallClock(second, wall) {
+		return second
+	}
+	return first
+}
+
+func sameWallClock(t time.Time, wall time.Time) bool {
+	return t.Day() == wall.Day() && t.Hour() == wall.Hour() && t.Minute() == wall.Minute() && t.Second() == wall.Second()
+}
@@ -0,0 +1,175 @@
+package rrule
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func loadLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("time zone database is not available: %v", err)
+	}
+	return loc
+}
+
+func TestDateAroundDSTChanges(t *testing.T) {
+	newYork := loadLocation(t, "America/New_York")
+
+	tests := []struct {
+		name  string
+		month time.Month
+		day   int
+		hour  int
+		min   int
+		want  string
+	}{
+		{"before the gap", time.March, 10, 1, 30, "2024-03-10T01:30:00-05:00"},
+		{"in the gap moves forward", time.March, 10, 2, 30, "2024-03-10T03:30:00-04:00"},
+		{"after the gap", time.March, 10, 3, 30, "2024-03-10T03:30:00-04:00"},
+		{"in the overlap takes the first", time.November, 3, 1, 30, "2024-11-03T01:30:00-04:00"},
+		{"after the overlap", time.November, 3, 2, 30, "2024-11-03T02:30:00-05:00"},
+		{"no change", time.June, 1, 9, 0, "2024-06-01T09:00:00-04:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Date(2024, tt.month, tt.day, tt.hour, tt.min, 0, newYork).Format(time.RFC3339)
+			if got != tt.want {
+				t.Errorf("Date = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBetween(t *testing.T) {
+	newYork := loadLocation(t, "America/New_York")
+	day := func(year int, month time.Month, d int, hour int, loc *time.Location) time.Time {
+		return time.Date(year, month, d, hour, 0, 0, 0, loc)
+	}
+
+	tests := []struct {
+		name    string
+		rule    string
+		dtstart time.Time
+		from    time.Time
+		to      time.Time
+		want    []string
+	}{
+		{
+			name:    "daily keeps the wall clock across DST",
+			rule:    "FREQ=DAILY",
+			dtstart: day(2024, time.March, 9, 9, newYork),
+			from:    day(2024, time.March, 9, 0, newYork),
+			to:      day(2024, time.March, 11, 23, newYork),
+			want:    []string{"2024-03-09T09:00:00-05:00", "2024-03-10T09:00:00-04:00", "2024-03-11T09:00:00-04:00"},
+		},
+		{
+			name:    "second tuesday of the month",
+			rule:    "FREQ=MONTHLY;BYDAY=2TU",
+			dtstart: day(2024, time.January, 9, 9, time.UTC),
+			from:    day(2024, time.January, 1, 0, time.UTC),
+			to:      day(2024, time.March, 31, 0, time.UTC),
+			want:    []string{"2024-01-09T09:00:00Z", "2024-02-13T09:00:00Z", "2024-03-12T09:00:00Z"},
+		},
+		{
+			name:    "last friday of the month",
+			rule:    "FREQ=MONTHLY;BYDAY=-1FR",
+			dtstart: day(2024, time.January, 26, 9, time.UTC),
+			from:    day(2024, time.January, 1, 0, time.UTC),
+			to:      day(2024, time.March, 31, 0, time.UTC),
+			want:    []string{"2024-01-26T09:00:00Z", "2024-02-23T09:00:00Z", "2024-03-29T09:00:00Z"},
+		},
+		{
+			name:    "months without a fifth monday are skipped",
+			rule:    "FREQ=MONTHLY;BYDAY=5MO",
+			dtstart: day(2024, time.January, 29, 9, time.UTC),
+			from:    day(2024, time.January, 1, 0, time.UTC),
+			to:      day(2024, time.May, 31, 0, time.UTC),
+			want:    []string{"2024-01-29T09:00:00Z", "2024-04-29T09:00:00Z"},
+		},
+		{
+			name:    "months too short for the day are skipped",
+			rule:    "FREQ=MONTHLY",
+			dtstart: day(2024, time.January, 31, 9, time.UTC),
+			from:    day(2024, time.January, 1, 0, time.UTC),
+			to:      day(2024, time.May, 31, 23, time.UTC),
+			want:    []string{"2024-01-31T09:00:00Z", "2024-03-31T09:00:00Z", "2024-05-31T09:00:00Z"},
+		},
+		{
+			name:    "count includes dtstart",
+			rule:    "FREQ=DAILY;COUNT=3",
+			dtstart: day(2024, time.January, 1, 9, time.UTC),
+			from:    day(2024, time.January, 1, 0, time.UTC),
+			to:      day(2024, time.January, 31, 0, time.UTC),
+			want:    []string{"2024-01-01T09:00:00Z", "2024-01-02T09:00:00Z", "2024-01-03T09:00:00Z"},
+		},
+		{
+			name:    "count is spent by occurrences before the window",
+			rule:    "FREQ=DAILY;COUNT=3",
+			dtstart: day(2024, time.January, 1, 9, time.UTC),
+			from:    day(2024, time.January, 2, 0, time.UTC),
+			to:      day(2024, time.January, 31, 0, time.UTC),
+			want:    []string{"2024-01-02T09:00:00Z", "2024-01-03T09:00:00Z"},
+		},
+		{
+			name:    "until is inclusive",
+			rule:    "FREQ=WEEKLY;UNTIL=20240115T090000Z",
+			dtstart: day(2024, time.January, 1, 9, time.UTC),
+			from:    day(2024, time.January, 1, 0, time.UTC),
+			to:      day(2024, time.February, 29, 0, time.UTC),
+			want:    []string{"2024-01-01T09:00:00Z", "2024-01-08T09:00:00Z", "2024-01-15T09:00:00Z"},
+		},
+		{
+			name:    "weekly on several days every other week",
+			rule:    "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,WE",
+			dtstart: day(2024, time.January, 1, 9, time.UTC),
+			from:    day(2024, time.January, 1, 0, time.UTC),
+			to:      day(2024, time.January, 21, 0, time.UTC),
+			want:    []string{"2024-01-01T09:00:00Z", "2024-01-03T09:00:00Z", "2024-01-15T09:00:00Z", "2024-01-17T09:00:00Z"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := Parse(tt.rule)
+			if err != nil {
+				t.Fatalf("parse %s: %v", tt.rule, err)
+			}
+
+			got := rule.Between(tt.dtstart, tt.from, tt.to)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Between returned %v, want %v", got, tt.want)
+			}
+
+			for i := range got {
+				if got[i].Format(time.RFC3339) != tt.want[i] {
+					t.Errorf("occurrence %d = %s, want %s", i, got[i].Format(time.RFC3339), tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseRejectsInvalidRules(t *testing.T) {
+	tests := []string{
+		"",
+		"INTERVAL=2",
+		"FREQ=YEARLY",
+		"FREQ=DAILY;INTERVAL=0",
+		"FREQ=DAILY;COUNT=-1",
+		"FREQ=DAILY;COUNT=3;UNTIL=20240115T090000Z",
+		"FREQ=WEEKLY;BYDAY=2TU",
+		"FREQ=DAILY;FREQ=WEEKLY",
+		"FREQ=DAILY;BYMONTH=1",
+	}
+
+	for _, rule := range tests {
+		if _, err := Parse(rule); !errors.Is(err, ErrInvalidRule) {
+			t.Errorf("Parse(%q) error = %v, want %v", rule, err, ErrInvalidRule)
+		}
+	}
+}
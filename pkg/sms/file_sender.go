@@ -0,0 +1,37 @@
+package sms
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+type fileSender struct {
+	mu   sync.Mutex
+	path string
+}
+
+// NewFileSender returns a Sender that appends every message as a line to the given file, for tests
+func NewFileSender(path string) (Sender, error) {
+	if path == "" {
+		return nil, errors.New("sms file path is empty")
+	}
+	return &fileSender{path: path}, nil
+}
+
+func (s *fileSender) Send(ctx context.Context, phoneNumber string, message string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = fmt.Fprintf(f, "%s\t%s\t%s\n", time.Now().Format(time.RFC3339), phoneNumber, message)
+	return err
+}
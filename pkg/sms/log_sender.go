@@ -0,0 +1,18 @@
+package sms
+
+import (
+	"context"
+	"log"
+)
+
+type logSender struct{}
+
+// NewLogSender returns a Sender that only writes messages to the application log, for local development
+func NewLogSender() Sender {
+	return &logSender{}
+}
+
+func (s *logSender) Send(ctx context.Context, phoneNumber string, message string) error {
+	log.Printf("sms to %s: %s", phoneNumber, message)
+	return nil
+}
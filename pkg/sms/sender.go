@@ -0,0 +1,29 @@
+package sms
+
+import (
+	"context"
+	"fmt"
+	"uzinfocom-todo/config"
+)
+
+const (
+	LogSenderType  = "log"
+	FileSenderType = "file"
+)
+
+// Sender delivers a text message to the given phone number
+type Sender interface {
+	Send(ctx context.Context, phoneNumber string, message string) error
+}
+
+// NewSender builds the sender selected by cfg.Sms.Sender, falling back to the log sender
+func NewSender(cfg *config.Config) (Sender, error) {
+	switch cfg.Sms.Sender {
+	case "", LogSenderType:
+		return NewLogSender(), nil
+	case FileSenderType:
+		return NewFileSender(cfg.Sms.FilePath)
+	default:
+		return nil, fmt.Errorf("unknown sms sender: %s", cfg.Sms.Sender)
+	}
+}
@@ -0,0 +1,70 @@
+package totp
+
+import (
+	"testing"
+	"time"
+)
+
+// rfc6238Secret is the SHA-1 key of the RFC 6238 test vectors, "12345678901234567890" in base32
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+// the RFC lists 8 digit codes, a 6 digit code is their last six digits
+func TestCodeMatchesRFC6238Vectors(t *testing.T) {
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+
+	for _, tt := range tests {
+		code, err := Code(rfc6238Secret, Step(time.Unix(tt.unix, 0)))
+		if err != nil {
+			t.Fatalf("code at %d: %v", tt.unix, err)
+		}
+
+		if code != tt.code {
+			t.Errorf("code at %d = %s, want %s", tt.unix, code, tt.code)
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	at := time.Unix(1111111111, 0)
+	step := Step(at)
+
+	tests := []struct {
+		name   string
+		secret string
+		code   string
+		skew   int64
+		ok     bool
+		step   int64
+	}{
+		{"current step", rfc6238Secret, "050471", 0, true, step},
+		{"previous step within skew", rfc6238Secret, "081804", 1, true, step - 1},
+		{"previous step without skew", rfc6238Secret, "081804", 0, false, 0},
+		{"wrong code", rfc6238Secret, "123456", 1, false, 0},
+		{"too short", rfc6238Secret, "50471", 1, false, 0},
+		{"lower case secret", "gezdgnbvgy3tqojqgezdgnbvgy3tqojq", "050471", 0, true, step},
+		{"invalid secret", "not base32!", "050471", 1, false, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			matched, ok := Validate(tt.secret, tt.code, at, tt.skew)
+			if ok != tt.ok {
+				t.Fatalf("Validate = %v, want %v", ok, tt.ok)
+			}
+
+			if ok && matched != tt.step {
+				t.Errorf("matched step %d, want %d", matched, tt.step)
+			}
+		})
+	}
+}
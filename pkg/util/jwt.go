@@ -1,32 +1,51 @@
 package util
 
 import (
+	"errors"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"time"
-	"uzinfocom-todo/config"
 	"uzinfocom-todo/internal/models"
 )
 
+const (
+	AccessTokenType    = "access"
+	RefreshTokenType   = "refresh"
+	ChallengeTokenType = "2fa_challenge"
+
+	AccessTokenTTL    = time.Hour * 24
+	RefreshTokenTTL   = time.Hour * 48
+	ChallengeTokenTTL = time.Minute * 5
+)
+
+var ErrUnexpectedTokenType = errors.New("unexpected token type")
+
 type Claims struct {
-	UserId      string `json:"user_id"`
-	Name        string `json:"name"`
-	PhoneNumber string `json:"phone_number"`
+	UserId      string   `json:"user_id"`
+	Name        string   `json:"name"`
+	PhoneNumber string   `json:"phone_number"`
+	TokenType   string   `json:"token_type"`
+	SessionId   string   `json:"sid"`
+	Role        string   `json:"role"`
+	Scopes      []string `json:"scopes"`
 	jwt.RegisteredClaims
 }
 
-func GenerateJWTToken(user *models.User, config *config.Config) (string, error) {
+func GenerateJWTToken(user *models.User, sessionId uuid.UUID, keySet *KeySet) (string, error) {
 	claims := &Claims{
 		UserId:      user.UserId.String(),
 		Name:        user.Name,
 		PhoneNumber: user.PhoneNumber,
+		TokenType:   AccessTokenType,
+		SessionId:   sessionId.String(),
+		Role:        user.Role,
+		Scopes:      models.ScopesForRole(user.Role),
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour * 24)),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(AccessTokenTTL)),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-
-	tokenString, err := token.SignedString([]byte(config.Server.JwtSecretKey))
+	tokenString, err := keySet.Sign(claims)
 
 	if err != nil {
 		return "", err
@@ -35,19 +54,21 @@ func GenerateJWTToken(user *models.User, config *config.Config) (string, error)
 	return tokenString, nil
 }
 
-func GenerateRefreshToken(user *models.User, config *config.Config) (string, error) {
+// GenerateRefreshToken signs a refresh token whose jti is the id of its persisted row
+func GenerateRefreshToken(user *models.User, sessionId uuid.UUID, tokenId uuid.UUID, keySet *KeySet) (string, error) {
 	claims := &Claims{
 		UserId:      user.UserId.String(),
 		Name:        user.Name,
 		PhoneNumber: user.PhoneNumber,
+		TokenType:   RefreshTokenType,
+		SessionId:   sessionId.String(),
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour * 48)),
+			ID:        tokenId.String(),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(RefreshTokenTTL)),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-
-	tokenString, err := token.SignedString([]byte(config.Server.JwtSecretKey))
+	tokenString, err := keySet.Sign(claims)
 
 	if err != nil {
 		return "", err
@@ -55,3 +76,45 @@ func GenerateRefreshToken(user *models.User, config *config.Config) (string, err
 
 	return tokenString, nil
 }
+
+// GenerateChallengeToken signs the short-lived token a user with 2FA gets after the first login step,
+// its jti is the id of the persisted challenge. It is not accepted anywhere except the second login step.
+func GenerateChallengeToken(user *models.User, challengeId uuid.UUID, expiresAt time.Time, keySet *KeySet) (string, error) {
+	claims := &Claims{
+		UserId:    user.UserId.String(),
+		TokenType: ChallengeTokenType,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        challengeId.String(),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+
+	return keySet.Sign(claims)
+}
+
+// ParseToken validates the signature and expiry of tokenString and checks that it is of the expected type
+func ParseToken(tokenString string, tokenType string, keySet *KeySet) (*Claims, error) {
+	claims := &Claims{}
+
+	token, err := jwt.ParseWithClaims(
+		tokenString,
+		claims,
+		keySet.Keyfunc,
+		jwt.WithValidMethods(keySet.ValidMethods()),
+		jwt.WithExpirationRequired(),
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !token.Valid {
+		return nil, jwt.ErrTokenInvalidClaims
+	}
+
+	if claims.TokenType != tokenType {
+		return nil, ErrUnexpectedTokenType
+	}
+
+	return claims, nil
+}
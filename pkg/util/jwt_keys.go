@@ -0,0 +1,286 @@
+package util
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"github.com/golang-jwt/jwt/v5"
+	"math/big"
+	"os"
+	"path/filepath"
+	"uzinfocom-todo/config"
+)
+
+const minRSAKeyBits = 2048
+
+var (
+	ErrUnknownKeyId        = errors.New("unknown key id")
+	ErrNoSigningKey        = errors.New("jwt signing key is not configured")
+	ErrUnsupportedKey      = errors.New("unsupported key type, expected RSA or Ed25519")
+	ErrNoSigningPrivateKey = errors.New("jwt signing key has no private key file")
+	ErrMissingKeyFile      = errors.New("key file does not exist, create it with make jwt_key or set jwt.GenerateMissingKey in development")
+)
+
+// JwtKey is a single entry of the key set. PrivateKey is nil for keys that are only kept to verify old tokens.
+type JwtKey struct {
+	KeyId      string
+	Method     jwt.SigningMethod
+	PrivateKey crypto.PrivateKey
+	PublicKey  crypto.PublicKey
+}
+
+// KeySet signs tokens with one active key and verifies them with any configured key, so keys can be rotated
+// by adding the new key, switching SigningKeyId and dropping the old key once its tokens have expired.
+type KeySet struct {
+	signingKey *JwtKey
+	keys       map[string]*JwtKey
+	keyIds     []string
+}
+
+type Jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type Jwks struct {
+	Keys []Jwk `json:"keys"`
+}
+
+func LoadKeySet(cfg *config.Config) (*KeySet, error) {
+	ks := &KeySet{
+		keys: make(map[string]*JwtKey),
+	}
+
+	for _, keyCfg := range cfg.Jwt.Keys {
+		if keyCfg.KeyId == "" {
+			return nil, errors.New("jwt key without KeyId")
+		}
+
+		if _, ok := ks.keys[keyCfg.KeyId]; ok {
+			return nil, fmt.Errorf("duplicate jwt key id: %s", keyCfg.KeyId)
+		}
+
+		if cfg.Jwt.GenerateMissingKey && keyCfg.KeyId == cfg.Jwt.SigningKeyId {
+			if err := generateMissingKey(keyCfg.PrivateKeyFile); err != nil {
+				return nil, fmt.Errorf("jwt key %s: %w", keyCfg.KeyId, err)
+			}
+		}
+
+		key, err := loadJwtKey(keyCfg)
+		if err != nil {
+			return nil, fmt.Errorf("jwt key %s: %w", keyCfg.KeyId, err)
+		}
+
+		ks.keys[key.KeyId] = key
+		ks.keyIds = append(ks.keyIds, key.KeyId)
+	}
+
+	signingKey, ok := ks.keys[cfg.Jwt.SigningKeyId]
+	if !ok {
+		return nil, ErrNoSigningKey
+	}
+
+	if signingKey.PrivateKey == nil {
+		return nil, ErrNoSigningPrivateKey
+	}
+
+	ks.signingKey = signingKey
+	return ks, nil
+}
+
+// Sign signs the claims with the active key and stamps its id into the kid header
+func (ks *KeySet) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(ks.signingKey.Method, claims)
+	token.Header["kid"] = ks.signingKey.KeyId
+
+	return token.SignedString(ks.signingKey.PrivateKey)
+}
+
+// Keyfunc picks the verification key by kid and rejects tokens whose alg does not match that key
+func (ks *KeySet) Keyfunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	key, ok := ks.keys[kid]
+	if !ok {
+		return nil, ErrUnknownKeyId
+	}
+
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+
+	return key.PublicKey, nil
+}
+
+func (ks *KeySet) ValidMethods() []string {
+	methods := []string{}
+	seen := map[string]bool{}
+
+	for _, key := range ks.keys {
+		if !seen[key.Method.Alg()] {
+			seen[key.Method.Alg()] = true
+			methods = append(methods, key.Method.Alg())
+		}
+	}
+	return methods
+}
+
+func (ks *KeySet) Jwks() Jwks {
+	jwks := Jwks{Keys: []Jwk{}}
+
+	for _, kid := range ks.keyIds {
+		key := ks.keys[kid]
+
+		switch publicKey := key.PublicKey.(type) {
+		case *rsa.PublicKey:
+			jwks.Keys = append(jwks.Keys, Jwk{
+				Kty: "RSA",
+				Kid: key.KeyId,
+				Use: "sig",
+				Alg: key.Method.Alg(),
+				N:   base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes()),
+				E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes()),
+			})
+		case ed25519.PublicKey:
+			jwks.Keys = append(jwks.Keys, Jwk{
+				Kty: "OKP",
+				Kid: key.KeyId,
+				Use: "sig",
+				Alg: key.Method.Alg(),
+				Crv: "Ed25519",
+				X:   base64.RawURLEncoding.EncodeToString(publicKey),
+			})
+		}
+	}
+	return jwks
+}
+
+func loadJwtKey(keyCfg config.JwtKeyConfig) (*JwtKey, error) {
+	key := &JwtKey{KeyId: keyCfg.KeyId}
+
+	switch {
+	case keyCfg.PrivateKeyFile != "":
+		block, err := readPemFile(keyCfg.PrivateKeyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		privateKey, err := parsePrivateKey(block)
+		if err != nil {
+			return nil, err
+		}
+
+		key.PrivateKey = privateKey
+		key.PublicKey = privateKey.(crypto.Signer).Public()
+	case keyCfg.PublicKeyFile != "":
+		block, err := readPemFile(keyCfg.PublicKeyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+
+		key.PublicKey = publicKey
+	default:
+		return nil, errors.New("neither PrivateKeyFile nor PublicKeyFile is set")
+	}
+
+	switch publicKey := key.PublicKey.(type) {
+	case *rsa.PublicKey:
+		if publicKey.N.BitLen() < minRSAKeyBits {
+			return nil, fmt.Errorf("rsa key must be at least %d bits", minRSAKeyBits)
+		}
+		key.Method = jwt.SigningMethodRS256
+	case ed25519.PublicKey:
+		key.Method = jwt.SigningMethodEdDSA
+	default:
+		return nil, ErrUnsupportedKey
+	}
+
+	return key, nil
+}
+
+func generateMissingKey(path string) error {
+	if path == "" {
+		return ErrNoSigningPrivateKey
+	}
+
+	if _, err := os.Stat(path); err == nil || !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return err
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+
+	// O_EXCL keeps two instances starting at once from overwriting each other's key
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+	if err != nil {
+		if errors.Is(err, os.ErrExist) {
+			return nil
+		}
+		return err
+	}
+	defer file.Close()
+
+	return pem.Encode(file, &pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+func readPemFile(path string) (*pem.Block, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%s: %w", path, ErrMissingKeyFile)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("%s is not a PEM file", path)
+	}
+	return block, nil
+}
+
+func parsePrivateKey(block *pem.Block) (crypto.PrivateKey, error) {
+	if block.Type == "RSA PRIVATE KEY" {
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	}
+
+	privateKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	switch privateKey.(type) {
+	case *rsa.PrivateKey, ed25519.PrivateKey:
+		return privateKey, nil
+	default:
+		return nil, ErrUnsupportedKey
+	}
+}
@@ -0,0 +1,40 @@
+package util
+
+import (
+	"time"
+	"uzinfocom-todo/config"
+)
+
+const (
+	defaultLoginMaxFailures = 5
+	defaultLoginLockout     = time.Minute
+	defaultLoginMaxLockout  = time.Hour
+)
+
+// LoginMaxFailures is the number of wrong passwords or second factor codes in a row that locks an account
+func LoginMaxFailures(cfg *config.Config) int {
+	if cfg.Server.LoginMaxFailures <= 0 {
+		return defaultLoginMaxFailures
+	}
+	return cfg.Server.LoginMaxFailures
+}
+
+func LoginLockout(cfg *config.Config) time.Duration {
+	if cfg.Server.LoginLockout <= 0 {
+		return defaultLoginLockout
+	}
+	return cfg.Server.LoginLockout
+}
+
+// LoginMaxLockout caps the lock, which doubles with every further failure
+func LoginMaxLockout(cfg *config.Config) time.Duration {
+	maxLockout := cfg.Server.LoginMaxLockout
+	if maxLockout <= 0 {
+		maxLockout = defaultLoginMaxLockout
+	}
+
+	if maxLockout < LoginLockout(cfg) {
+		return LoginLockout(cfg)
+	}
+	return maxLockout
+}
@@ -0,0 +1,47 @@
+package util
+
+import (
+	"net"
+	"net/http"
+	"strings"
+	"uzinfocom-todo/internal/models"
+)
+
+// lengths of the session columns the client metadata is stored in
+const (
+	maxDeviceLength    = 128
+	maxIpAddressLength = 64
+	maxUserAgentLength = 512
+)
+
+func ClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// SessionFromRequest collects the client metadata stored with a new session. The values come from
+// the client, so they are cut to the length of their columns instead of failing the login.
+func SessionFromRequest(r *http.Request) models.Session {
+	return models.Session{
+		Device:    truncate(r.Header.Get("X-Device-Name"), maxDeviceLength),
+		IpAddress: truncate(ClientIP(r), maxIpAddressLength),
+		UserAgent: truncate(r.UserAgent(), maxUserAgentLength),
+	}
+}
+
+// truncate cuts s to at most max characters, invalid UTF-8 is replaced so the database accepts it
+func truncate(s string, max int) string {
+	s = strings.ToValidUTF8(s, "\uFFFD")
+	runes := 0
+
+	for i := range s {
+		if runes == max {
+			return s[:i]
+		}
+		runes++
+	}
+	return s
+}
@@ -0,0 +1,103 @@
+package validator
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+	"uzinfocom-todo/pkg/message"
+)
+
+// codes of FieldError, clients can switch on them instead of parsing the message
+const (
+	CodeRequired      = "required"
+	CodeTooLong       = "too_long"
+	CodeInvalidFormat = "invalid_format"
+	CodeInvalidRange  = "invalid_range"
+)
+
+// ids of the messages of FieldError, the catalogue of every locale has a message for each of them
+const (
+	MessageRequired     = "FIELD_REQUIRED"
+	MessageRequiredWith = "FIELD_REQUIRED_WITH"
+	MessageTooLong      = "FIELD_TOO_LONG"
+	MessagePhone        = "FIELD_INVALID_PHONE"
+	MessageTime         = "FIELD_INVALID_TIME"
+	MessageAfter        = "FIELD_NOT_AFTER"
+)
+
+// Messages are the English messages by id, {field} is the name of the rejected field
+var Messages = map[string]string{
+	MessageRequired:     "{field} is required",
+	MessageRequiredWith: "{field} is required when {other} is changed",
+	MessageTooLong:      "{field} must be at most {max} characters long",
+	MessagePhone:        "{field} must be in the E.164 format, such as +998901234567",
+	MessageTime:         "{field} must be in RFC 3339 or in the format dd-mm-yyyy hh:mm",
+	MessageAfter:        "{field} must be after {other}",
+}
+
+// E164Pattern matches phone numbers in the E.164 format, such as +998901234567
+var E164Pattern = regexp.MustCompile(`^\+[1-9][0-9]{1,14}$`)
+
+// FieldError describes why a single field of a request was rejected. MessageId and Params are kept
+// so Message can be translated.
+type FieldError struct {
+	Field     string         `json:"field"`
+	Code      string         `json:"code"`
+	Message   string         `json:"message"`
+	MessageId string         `json:"-"`
+	Params    message.Params `json:"-"`
+}
+
+// Validator collects the field errors of a request, so all of them are reported at once
+type Validator struct {
+	errors []FieldError
+}
+
+func New() *Validator {
+	return &Validator{}
+}
+
+// Add records an error for field with the message messageId, params fill in its placeholders besides {field}
+func (v *Validator) Add(field string, code string, messageId string, params message.Params) {
+	filled := message.Params{"field": field}
+	for name, value := range params {
+		filled[name] = value
+	}
+
+	v.errors = append(v.errors, FieldError{
+		Field:     field,
+		Code:      code,
+		Message:   message.Format(Messages[messageId], filled),
+		MessageId: messageId,
+		Params:    filled,
+	})
+}
+
+func (v *Validator) Check(ok bool, field string, code string, messageId string, params message.Params) bool {
+	if !ok {
+		v.Add(field, code, messageId, params)
+	}
+	return ok
+}
+
+func (v *Validator) Required(field string, value string) bool {
+	return v.Check(strings.TrimSpace(value) != "", field, CodeRequired, MessageRequired, nil)
+}
+
+// MaxLength checks that value has at most max characters, the limit of a varchar(max) column
+func (v *Validator) MaxLength(field string, value string, max int) bool {
+	return v.Check(utf8.RuneCountInString(value) <= max, field, CodeTooLong, MessageTooLong, message.Params{"max": strconv.Itoa(max)})
+}
+
+func (v *Validator) Phone(field string, value string) bool {
+	return v.Check(E164Pattern.MatchString(value), field, CodeInvalidFormat, MessagePhone, nil)
+}
+
+func (v *Validator) Valid() bool {
+	return len(v.errors) == 0
+}
+
+func (v *Validator) Errors() []FieldError {
+	return v.errors
+}